@@ -0,0 +1,91 @@
+package autodiff
+
+import (
+	"fmt"
+	"math"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Elementwise is a function applied to each element of a matrix, with its derivative
+type Elementwise struct {
+	Fn    func(x float64) float64
+	Deriv func(x float64) float64
+}
+
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+//Known elementwise functions
+var (
+	Sigmoid = Elementwise{sigmoid, func(x float64) float64 { s := sigmoid(x); return s * (1 - s) }}
+	Tanh    = Elementwise{math.Tanh, func(x float64) float64 { t := math.Tanh(x); return 1 - t*t }}
+	Relu    = Elementwise{
+		func(x float64) float64 { return math.Max(x, 0) },
+		func(x float64) float64 {
+			if x > 0 {
+				return 1
+			}
+			return 0
+		},
+	}
+	Exp    = Elementwise{math.Exp, math.Exp}
+	Log    = Elementwise{math.Log, func(x float64) float64 { return 1 / x }}
+	Square = Elementwise{func(x float64) float64 { return x * x }, func(x float64) float64 { return 2 * x }}
+	Sqrt   = Elementwise{math.Sqrt, func(x float64) float64 { return 0.5 / math.Sqrt(x) }}
+	Abs    = Elementwise{math.Abs, func(x float64) float64 {
+		if x < 0 {
+			return -1
+		}
+		return 1
+	}}
+)
+
+//Pow returns the elementwise function x^p
+func Pow(p float64) Elementwise {
+	return Elementwise{
+		func(x float64) float64 { return math.Pow(x, p) },
+		func(x float64) float64 { return p * math.Pow(x, p-1) },
+	}
+}
+
+//Elu returns the exponential linear unit of parameter alpha
+func Elu(alpha float64) Elementwise {
+	return Elementwise{
+		func(x float64) float64 {
+			if x > 0 {
+				return x
+			}
+			return alpha * (math.Exp(x) - 1)
+		},
+		func(x float64) float64 {
+			if x > 0 {
+				return 1
+			}
+			return alpha * math.Exp(x)
+		},
+	}
+}
+
+//MapElem returns a new variable with fn applied to each element of a
+func MapElem(a *Var, fn Elementwise) (*Var, error) {
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+	if fn.Fn == nil || fn.Deriv == nil {
+		return nil, fmt.Errorf("fn has no function or no derivative")
+	}
+	val, err := mat.MapElem(a.val, fn.Fn)
+	if err != nil {
+		return nil, err
+	}
+	var res *Var
+	res = t.record(val, func() {
+		g, _ := mat.MapElem(a.val, fn.Deriv)
+		_ = g.MulElem(res.grad)
+		a.accumulate(g)
+	})
+	return res, nil
+}
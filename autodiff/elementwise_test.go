@@ -0,0 +1,65 @@
+package autodiff
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestMapElem(t *testing.T) {
+	te := tester.New(t)
+	src := rand.NewSource(2)
+	x := mat.NewNormal(3, 2, src, 0, 1)
+	pos := mat.NewUniform(3, 2, src, 0.5, 2)
+	tests := []struct {
+		name string
+		fn   Elementwise
+		x    *mat.M64
+	}{
+		{"sigmoid", Sigmoid, x},
+		{"tanh", Tanh, x},
+		{"relu", Relu, x},
+		{"exp", Exp, x},
+		{"log", Log, pos},
+		{"square", Square, x},
+		{"sqrt", Sqrt, pos},
+		{"abs", Abs, x},
+		{"pow", Pow(2.5), pos},
+		{"elu", Elu(0.7), x},
+	}
+	for i, test := range tests {
+		fn := test.fn
+		ok, err := gradOK(func(x *Var) (*Var, error) {
+			y, err := MapElem(x, fn)
+			if err != nil {
+				return nil, err
+			}
+			//weight the elements so that each derivative is checked
+			y, _ = MulElem(y, x.tape.mustVar(mat.NewM64(3, 2, []float64{1, 2, 3, 4, 5, 6})))
+			return Sum(y)
+		}, test.x)
+		te.CompareError(i, nil, err)
+		te.DeepEqual(i, test.name, true, ok)
+	}
+
+	//a two layer network written as its forward pass only
+	w1 := mat.NewNormal(4, 3, src, 0, 1)
+	w2 := mat.NewNormal(1, 4, src, 0, 1)
+	in := mat.NewM64(3, 1, []float64{0.5, -1, 2})
+	ok, err := gradOK(func(w *Var) (*Var, error) {
+		h, _ := Mul(w, w.tape.mustVar(in))
+		h, _ = MapElem(h, Tanh)
+		out, _ := Mul(w.tape.mustVar(w2), h)
+		out, _ = MapElem(out, Sigmoid)
+		diff, _ := Sub(out, w.tape.mustVar(mat.NewM64(1, 1, []float64{1})))
+		return MapElem(diff, Square)
+	}, w1)
+	te.CompareError(20, nil, err)
+	te.DeepEqual(20, "network", true, ok)
+
+	_, err = MapElem(NewTape().mustVar(x), Elementwise{Fn: Exp.Fn})
+	te.CompareError(21, fmt.Errorf("fn has no function or no derivative"), err)
+}
@@ -0,0 +1,120 @@
+package autodiff
+
+import mat "github.com/twiggg/math/mat64"
+
+//Add returns a new variable a+b
+func Add(a, b *Var) (*Var, error) {
+	t, err := sameTape(a, b)
+	if err != nil {
+		return nil, err
+	}
+	val, err := mat.Add(a.val, b.val)
+	if err != nil {
+		return nil, err
+	}
+	var res *Var
+	res = t.record(val, func() {
+		a.accumulate(res.grad)
+		b.accumulate(res.grad)
+	})
+	return res, nil
+}
+
+//Sub returns a new variable a-b
+func Sub(a, b *Var) (*Var, error) {
+	t, err := sameTape(a, b)
+	if err != nil {
+		return nil, err
+	}
+	val, err := mat.Sub(a.val, b.val)
+	if err != nil {
+		return nil, err
+	}
+	var res *Var
+	res = t.record(val, func() {
+		a.accumulate(res.grad)
+		neg, _ := mat.Scale(-1, res.grad)
+		b.accumulate(neg)
+	})
+	return res, nil
+}
+
+//Mul returns a new variable as the dot product a*b
+func Mul(a, b *Var) (*Var, error) {
+	t, err := sameTape(a, b)
+	if err != nil {
+		return nil, err
+	}
+	val, err := mat.Mul(a.val, b.val)
+	if err != nil {
+		return nil, err
+	}
+	var res *Var
+	res = t.record(val, func() {
+		//d/da = g*bᵀ, d/db = aᵀ*g
+		bt, _ := mat.Transpose(b.val)
+		ga, _ := mat.Mul(res.grad, bt)
+		a.accumulate(ga)
+		br, bc := b.val.Dims()
+		gb := mat.NewM64(br, bc, nil)
+		_ = mat.MulTransTo(gb, a.val, res.grad)
+		b.accumulate(gb)
+	})
+	return res, nil
+}
+
+//MulElem returns a new variable a.*b (element by element)
+func MulElem(a, b *Var) (*Var, error) {
+	t, err := sameTape(a, b)
+	if err != nil {
+		return nil, err
+	}
+	val, err := mat.MulElem(a.val, b.val)
+	if err != nil {
+		return nil, err
+	}
+	var res *Var
+	res = t.record(val, func() {
+		ga, _ := mat.MulElem(res.grad, b.val)
+		gb, _ := mat.MulElem(res.grad, a.val)
+		a.accumulate(ga)
+		b.accumulate(gb)
+	})
+	return res, nil
+}
+
+//Scale returns a new variable f*a
+func Scale(f float64, a *Var) (*Var, error) {
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+	val, err := mat.Scale(f, a.val)
+	if err != nil {
+		return nil, err
+	}
+	var res *Var
+	res = t.record(val, func() {
+		g, _ := mat.Scale(f, res.grad)
+		a.accumulate(g)
+	})
+	return res, nil
+}
+
+//Transpose returns a new variable aᵀ
+func Transpose(a *Var) (*Var, error) {
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+	val, err := mat.Transpose(a.val)
+	if err != nil {
+		return nil, err
+	}
+	var res *Var
+	res = t.record(val, func() {
+		g, _ := mat.Transpose(res.grad)
+		a.accumulate(g)
+	})
+	return res, nil
+}
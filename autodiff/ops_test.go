@@ -0,0 +1,86 @@
+package autodiff
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/math/calculus"
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+//gradOK compares the gradient of f w.r.t. its input at x with central differences
+func gradOK(f func(x *Var) (*Var, error), x *mat.M64) (bool, error) {
+	tape := NewTape()
+	v, _ := tape.Var(x)
+	out, err := f(v)
+	if err != nil {
+		return false, err
+	}
+	if err := tape.Backward(out); err != nil {
+		return false, err
+	}
+	exp, err := calculus.Gradient(func(x *mat.M64) float64 {
+		out, _ := f(NewTape().mustVar(x))
+		return out.Value().At(0, 0)
+	}, x)
+	if err != nil {
+		return false, err
+	}
+	return mat.EqualApprox(exp, v.Grad(), 1e-7), nil
+}
+
+func (t *Tape) mustVar(m *mat.M64) *Var {
+	v, _ := t.Var(m)
+	return v
+}
+
+func TestOps(t *testing.T) {
+	te := tester.New(t)
+	src := rand.NewSource(1)
+	a := mat.NewNormal(3, 4, src, 0, 1)
+	b := mat.NewNormal(4, 2, src, 0, 1)
+	c := mat.NewNormal(3, 4, src, 0, 1)
+	tests := []struct {
+		name string
+		f    func(x *Var) (*Var, error)
+		x    *mat.M64
+	}{
+		{"add", func(x *Var) (*Var, error) {
+			s, _ := Add(x, x.tape.mustVar(c))
+			s, _ = MulElem(s, s)
+			return Sum(s)
+		}, a},
+		{"sub", func(x *Var) (*Var, error) {
+			s, _ := Sub(x.tape.mustVar(c), x)
+			s, _ = MulElem(s, x)
+			return Sum(s)
+		}, a},
+		{"mul left", func(x *Var) (*Var, error) {
+			s, _ := Mul(x, x.tape.mustVar(b))
+			s, _ = MulElem(s, s)
+			return Sum(s)
+		}, a},
+		{"mul right", func(x *Var) (*Var, error) {
+			s, _ := Mul(x.tape.mustVar(a), x)
+			s, _ = MulElem(s, s)
+			return Mean(s)
+		}, b},
+		{"mul self", func(x *Var) (*Var, error) {
+			xt, _ := Transpose(x)
+			s, _ := Mul(x, xt)
+			s, _ = Scale(0.5, s)
+			s, _ = MulElem(s, s)
+			return Sum(s)
+		}, a},
+	}
+	for i, test := range tests {
+		ok, err := gradOK(test.f, test.x)
+		te.CompareError(i, nil, err)
+		te.DeepEqual(i, test.name, true, ok)
+	}
+	tape := NewTape()
+	_, err := Mul(tape.mustVar(a), tape.mustVar(c))
+	te.CompareError(10, fmt.Errorf("m colomns != n rows"), err)
+}
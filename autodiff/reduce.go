@@ -0,0 +1,85 @@
+package autodiff
+
+import (
+	"fmt"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Sum returns a new 1*1 variable with the sum of the elements of a
+func Sum(a *Var) (*Var, error) {
+	return sum(a, 1)
+}
+
+//Mean returns a new 1*1 variable with the mean of the elements of a
+func Mean(a *Var) (*Var, error) {
+	if _, err := sameTape(a); err != nil {
+		return nil, err
+	}
+	return sum(a, 1/float64(a.val.Size()))
+}
+
+//sum returns f times the sum of the elements of a
+func sum(a *Var, f float64) (*Var, error) {
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+	r, c := a.val.Dims()
+	s := 0.0
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			s += a.val.At(i, j)
+		}
+	}
+	var res *Var
+	res = t.record(mat.NewM64(1, 1, []float64{f * s}), func() {
+		g := mat.NewM64(r, c, nil)
+		v := f * res.grad.At(0, 0)
+		_ = g.MapElem(func(float64) float64 { return v })
+		a.accumulate(g)
+	})
+	return res, nil
+}
+
+//SumAxis returns a new variable with the sums of a along axis: a 1*c row of colomn sums for mat.AxisRows, a r*1 colomn of row sums for mat.AxisCols
+func SumAxis(a *Var, axis int) (*Var, error) {
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+	r, c := a.val.Dims()
+	var val *mat.M64
+	switch axis {
+	case mat.AxisRows:
+		val = mat.NewM64(1, c, nil)
+	case mat.AxisCols:
+		val = mat.NewM64(r, 1, nil)
+	default:
+		return nil, fmt.Errorf("axis must be %d or %d", mat.AxisRows, mat.AxisCols)
+	}
+	//pos returns the position in val of the element (i,j) of a
+	pos := func(i, j int) (int, int) {
+		if axis == mat.AxisRows {
+			return 0, j
+		}
+		return i, 0
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			pi, pj := pos(i, j)
+			val.Set(pi, pj, val.At(pi, pj)+a.val.At(i, j))
+		}
+	}
+	var res *Var
+	res = t.record(val, func() {
+		g := mat.NewM64(r, c, nil)
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				g.Set(i, j, res.grad.At(pos(i, j)))
+			}
+		}
+		a.accumulate(g)
+	})
+	return res, nil
+}
@@ -0,0 +1,42 @@
+package autodiff
+
+import (
+	"fmt"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestReductions(t *testing.T) {
+	te := tester.New(t)
+	tape := NewTape()
+	x := tape.mustVar(mat.NewM64(2, 3, []float64{1, 2, 3, 4, 5, 6}))
+	m, err := Mean(x)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "mean", 3.5, m.Value().At(0, 0))
+	te.CompareError(0, nil, tape.Backward(m))
+	sixth := 1.0 / 6
+	te.DeepEqual(0, "mean grad", mat.NewM64(2, 3, []float64{sixth, sixth, sixth, sixth, sixth, sixth}), x.Grad())
+
+	cols, err := SumAxis(x, mat.AxisRows)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "colomn sums", mat.NewM64(1, 3, []float64{5, 7, 9}), cols.Value())
+	rows, err := SumAxis(x, mat.AxisCols)
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "row sums", mat.NewM64(2, 1, []float64{6, 15}), rows.Value())
+
+	//f=sum(colsums.*[1 2 3])+sum(rowsums.*[10 20]) -> df/dx_ij=j+1+10*(i+1)
+	wc, _ := MulElem(cols, tape.mustVar(mat.NewM64(1, 3, []float64{1, 2, 3})))
+	wr, _ := MulElem(rows, tape.mustVar(mat.NewM64(2, 1, []float64{10, 20})))
+	sc, _ := Sum(wc)
+	sr, _ := Sum(wr)
+	f, _ := Add(sc, sr)
+	te.CompareError(3, nil, tape.Backward(f))
+	te.DeepEqual(3, "axis grad", mat.NewM64(2, 3, []float64{11, 12, 13, 21, 22, 23}), x.Grad())
+
+	_, err = SumAxis(x, 2)
+	te.CompareError(4, fmt.Errorf("axis must be 0 or 1"), err)
+	_, err = Mean(nil)
+	te.CompareError(5, fmt.Errorf("var is nil"), err)
+}
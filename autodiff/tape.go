@@ -0,0 +1,116 @@
+//Package autodiff computes gradients of scalar functions of M64 by reverse mode automatic differentiation: operations on variables are recorded on a tape, which is then walked backward from the output
+package autodiff
+
+import (
+	"fmt"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Tape records the operations on its variables, in order
+type Tape struct {
+	vars []*Var
+}
+
+//NewTape returns a new empty tape
+func NewTape() *Tape {
+	return &Tape{}
+}
+
+//Var is a matrix value recorded on a tape, with the gradient of the output w.r.t. it once Backward was called
+type Var struct {
+	tape *Tape
+	val  *mat.M64
+	grad *mat.M64
+	//back propagates the gradient of the var to the vars it was computed from
+	back func()
+}
+
+//Var returns a new input variable holding m. m is not copied and must not be modified while the tape is used
+func (t *Tape) Var(m *mat.M64) (*Var, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	return t.record(m, nil), nil
+}
+
+func (t *Tape) record(val *mat.M64, back func()) *Var {
+	v := &Var{tape: t, val: val, back: back}
+	t.vars = append(t.vars, v)
+	return v
+}
+
+//Len returns the number of variables recorded
+func (t *Tape) Len() int {
+	return len(t.vars)
+}
+
+//Backward computes the gradient of out, which must be 1*1, w.r.t. every variable of the tape. Previous gradients are discarded
+func (t *Tape) Backward(out *Var) error {
+	if out == nil {
+		return fmt.Errorf("out is nil")
+	}
+	if out.tape != t {
+		return fmt.Errorf("out is not on the tape")
+	}
+	if r, c := out.val.Dims(); r != 1 || c != 1 {
+		return fmt.Errorf("out must be 1*1")
+	}
+	for _, v := range t.vars {
+		v.grad = nil
+	}
+	out.grad = mat.NewM64(1, 1, []float64{1})
+	for i := len(t.vars) - 1; i >= 0; i-- {
+		v := t.vars[i]
+		if v.grad != nil && v.back != nil {
+			v.back()
+		}
+	}
+	return nil
+}
+
+//Value returns the value of v
+func (v *Var) Value() *mat.M64 {
+	return v.val
+}
+
+//Grad returns the gradient of the output of the last Backward w.r.t. v, with the dims of v. Zero if v does not contribute to the output, nil before Backward
+func (v *Var) Grad() *mat.M64 {
+	if v.grad == nil && v.tape.ran() {
+		r, c := v.val.Dims()
+		return mat.NewM64(r, c, nil)
+	}
+	return v.grad
+}
+
+//ran returns true if Backward was called, i.e. some variable has a gradient
+func (t *Tape) ran() bool {
+	for _, v := range t.vars {
+		if v.grad != nil {
+			return true
+		}
+	}
+	return false
+}
+
+//accumulate adds g to the gradient of v
+func (v *Var) accumulate(g *mat.M64) {
+	if v.grad == nil {
+		r, c := v.val.Dims()
+		v.grad = mat.NewM64(r, c, nil)
+	}
+	_ = v.grad.Add(g)
+}
+
+//sameTape returns an error if the vars are nil or not all on the same tape
+func sameTape(vs ...*Var) (*Tape, error) {
+	for _, v := range vs {
+		if v == nil {
+			return nil, fmt.Errorf("var is nil")
+		}
+		if v.tape != vs[0].tape {
+			return nil, fmt.Errorf("vars are on different tapes")
+		}
+	}
+	return vs[0].tape, nil
+}
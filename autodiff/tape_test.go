@@ -0,0 +1,47 @@
+package autodiff
+
+import (
+	"fmt"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestTape(t *testing.T) {
+	te := tester.New(t)
+	tape := NewTape()
+	x, err := tape.Var(mat.NewM64(2, 1, []float64{1, 2}))
+	te.CompareError(0, nil, err)
+	y, _ := tape.Var(mat.NewM64(2, 1, []float64{3, 4}))
+	unused, _ := tape.Var(mat.NewM64(1, 3, nil))
+	te.DeepEqual(0, "no gradient yet", (*mat.M64)(nil), x.Grad())
+
+	//f=sum(x.*y)+sum(x) -> df/dx=y+1, df/dy=x
+	p, _ := MulElem(x, y)
+	s1, _ := Sum(p)
+	s2, _ := Sum(x)
+	f, _ := Add(s1, s2)
+	te.DeepEqual(0, "value", mat.NewM64(1, 1, []float64{14}), f.Value())
+	te.DeepEqual(0, "recorded", 7, tape.Len())
+	te.CompareError(1, nil, tape.Backward(f))
+	te.DeepEqual(1, "dx", mat.NewM64(2, 1, []float64{4, 5}), x.Grad())
+	te.DeepEqual(1, "dy", mat.NewM64(2, 1, []float64{1, 2}), y.Grad())
+	te.DeepEqual(1, "unused", mat.NewM64(1, 3, nil), unused.Grad())
+	//gradients are recomputed, not accumulated
+	te.CompareError(2, nil, tape.Backward(f))
+	te.DeepEqual(2, "again", mat.NewM64(2, 1, []float64{4, 5}), x.Grad())
+	//w.r.t. an intermediate result
+	te.CompareError(3, nil, tape.Backward(s1))
+	te.DeepEqual(3, "dx", mat.NewM64(2, 1, []float64{3, 4}), x.Grad())
+
+	te.CompareError(4, fmt.Errorf("out must be 1*1"), tape.Backward(p))
+	te.CompareError(5, fmt.Errorf("out is not on the tape"), NewTape().Backward(f))
+	_, err = tape.Var(nil)
+	te.CompareError(6, fmt.Errorf("m is nil"), err)
+	other, _ := NewTape().Var(mat.NewM64(2, 1, nil))
+	_, err = Add(x, other)
+	te.CompareError(7, fmt.Errorf("vars are on different tapes"), err)
+	_, err = Add(x, nil)
+	te.CompareError(8, fmt.Errorf("var is nil"), err)
+}
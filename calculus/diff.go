@@ -0,0 +1,178 @@
+package calculus
+
+import (
+	"fmt"
+	"math"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Derivative returns the derivative of f at x and an estimate of its error, with Ridders' method: central differences of steps h, h/1.4, h/1.4², ... extrapolated to a zero step (Richardson). h should not be small, 0.1*max(1,|x|) is used if h <= 0
+func Derivative(f func(x float64) float64, x, h float64) (float64, float64) {
+	const (
+		con  = 1.4
+		con2 = con * con
+		ntab = 10
+	)
+	if h <= 0 {
+		h = 0.1 * math.Max(1, math.Abs(x))
+	}
+	//a[j][i] is the central difference of step h/con^i extrapolated j times
+	var a [ntab][ntab]float64
+	central := func(h float64) float64 { return (f(x+h) - f(x-h)) / (2 * h) }
+	a[0][0] = central(h)
+	ans, err := a[0][0], math.Inf(1)
+	for i := 1; i < ntab; i++ {
+		h /= con
+		a[0][i] = central(h)
+		fac := con2
+		for j := 1; j <= i; j++ {
+			a[j][i] = (a[j-1][i]*fac - a[j-1][i-1]) / (fac - 1)
+			fac *= con2
+			if e := math.Max(math.Abs(a[j][i]-a[j-1][i]), math.Abs(a[j][i]-a[j-1][i-1])); e <= err {
+				ans, err = a[j][i], e
+			}
+		}
+		//stop when the higher orders get worse, rounding errors dominate
+		if math.Abs(a[i][i]-a[i-1][i-1]) >= 2*err {
+			break
+		}
+	}
+	return ans, err
+}
+
+//step returns the finite difference step for a parameter of value v, balancing truncation and rounding errors of an order 4 scheme
+func step(v float64) float64 {
+	return 1e-3 * math.Max(1, math.Abs(v))
+}
+
+//clone returns a copy of x that can be perturbed
+func clone(x *mat.M64) *mat.M64 {
+	r, c := x.Dims()
+	res := mat.NewM64(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			res.Set(i, j, x.At(i, j))
+		}
+	}
+	return res
+}
+
+//Gradient returns a new matrix with the dims of x holding the partial derivatives of f at x, from central differences improved by one Richardson extrapolation. It can check an analytical gradient
+func Gradient(f func(x *mat.M64) float64, x *mat.M64) (*mat.M64, error) {
+	if !x.Valid() {
+		return nil, fmt.Errorf("x is nil")
+	}
+	r, c := x.Dims()
+	p := clone(x)
+	res := mat.NewM64(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			v := x.At(i, j)
+			h := step(v)
+			diff := func(h float64) float64 {
+				p.Set(i, j, v+h)
+				fp := f(p)
+				p.Set(i, j, v-h)
+				fm := f(p)
+				return (fp - fm) / (2 * h)
+			}
+			d1, d2 := diff(h), diff(h/2)
+			p.Set(i, j, v)
+			res.Set(i, j, (4*d2-d1)/3)
+		}
+	}
+	return res, nil
+}
+
+//Jacobian returns a new m*n matrix with the partial derivatives of each of the m values returned by f, in row major order, w.r.t. each of the n values of x, in row major order. It uses central differences improved by one Richardson extrapolation
+func Jacobian(f func(x *mat.M64) *mat.M64, x *mat.M64) (*mat.M64, error) {
+	if !x.Valid() {
+		return nil, fmt.Errorf("x is nil")
+	}
+	fx := f(x)
+	if !fx.Valid() {
+		return nil, fmt.Errorf("f returned nil")
+	}
+	r, c := x.Dims()
+	fr, fc := fx.Dims()
+	p := clone(x)
+	res := mat.NewM64(fr*fc, r*c, nil)
+	//diff adds w*(f(x+h)-f(x-h))/2h to colomn col of res
+	diff := func(i, j, col int, h, w float64) error {
+		v := x.At(i, j)
+		p.Set(i, j, v+h)
+		fp := clone(f(p))
+		p.Set(i, j, v-h)
+		fm := f(p)
+		p.Set(i, j, v)
+		if r, c := fp.Dims(); r != fr || c != fc {
+			return fmt.Errorf("f returned matrices of different dims")
+		}
+		if r, c := fm.Dims(); r != fr || c != fc {
+			return fmt.Errorf("f returned matrices of different dims")
+		}
+		for a := 0; a < fr; a++ {
+			for b := 0; b < fc; b++ {
+				row := a*fc + b
+				res.Set(row, col, res.At(row, col)+w*(fp.At(a, b)-fm.At(a, b))/(2*h))
+			}
+		}
+		return nil
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			h := step(x.At(i, j))
+			if err := diff(i, j, i*c+j, h, -1.0/3); err != nil {
+				return nil, err
+			}
+			if err := diff(i, j, i*c+j, h/2, 4.0/3); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+//Hessian returns a new n*n symmetric matrix with the second partial derivatives of f at x, w.r.t. each of the n values of x in row major order, from central differences
+func Hessian(f func(x *mat.M64) float64, x *mat.M64) (*mat.M64, error) {
+	if !x.Valid() {
+		return nil, fmt.Errorf("x is nil")
+	}
+	r, c := x.Dims()
+	n := r * c
+	p := clone(x)
+	//flat access to the perturbed copy
+	get := func(k int) float64 { return p.At(k/c, k%c) }
+	set := func(k int, v float64) { p.Set(k/c, k%c, v) }
+	h := make([]float64, n)
+	for k := range h {
+		//about ε^(1/4), balancing the truncation and rounding errors of second differences
+		h[k] = 1e-4 * math.Max(1, math.Abs(get(k)))
+	}
+	f0 := f(p)
+	res := mat.NewM64(n, n, nil)
+	for a := 0; a < n; a++ {
+		va := get(a)
+		set(a, va+h[a])
+		fp := f(p)
+		set(a, va-h[a])
+		fm := f(p)
+		set(a, va)
+		res.Set(a, a, (fp-2*f0+fm)/(h[a]*h[a]))
+		for b := a + 1; b < n; b++ {
+			vb := get(b)
+			eval := func(sa, sb float64) float64 {
+				set(a, va+sa*h[a])
+				set(b, vb+sb*h[b])
+				return f(p)
+			}
+			v := (eval(1, 1) - eval(1, -1) - eval(-1, 1) + eval(-1, -1)) / (4 * h[a] * h[b])
+			set(a, va)
+			set(b, vb)
+			res.Set(a, b, v)
+			res.Set(b, a, v)
+		}
+	}
+	return res, nil
+}
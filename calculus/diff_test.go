@@ -0,0 +1,94 @@
+package calculus
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestDerivative(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		f   func(float64) float64
+		x   float64
+		exp float64
+	}{
+		{math.Sin, 1, math.Cos(1)},
+		{math.Exp, 2, math.Exp(2)},
+		{func(x float64) float64 { return x * x * x }, -3, 27},
+		{math.Log, 0.5, 2},
+	}
+	for i, test := range tests {
+		d, err := Derivative(test.f, test.x, 0)
+		te.DeepEqual(i, "derivative", true, math.Abs(d-test.exp) < 1e-10*math.Max(1, math.Abs(test.exp)))
+		te.DeepEqual(i, "error estimate", true, err < 1e-8)
+	}
+	d, _ := Derivative(math.Log, 0.5, 0.1)
+	te.DeepEqual(10, "explicit step", true, math.Abs(d-2) < 1e-10)
+}
+
+func TestMatrixDerivatives(t *testing.T) {
+	te := tester.New(t)
+	//f(x)=sum x_ij² * (i+1) * e^(x_ij), on a 2*2 matrix
+	f := func(x *mat.M64) float64 {
+		sum := 0.0
+		for i := 0; i < 2; i++ {
+			for j := 0; j < 2; j++ {
+				v := x.At(i, j)
+				sum += v * v * float64(i+1) * math.Exp(v)
+			}
+		}
+		return sum
+	}
+	x := mat.NewM64(2, 2, []float64{0.5, -1, 2, 0.1})
+	g, err := Gradient(f, x)
+	te.CompareError(0, nil, err)
+	exp := mat.NewM64(2, 2, nil)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			v := x.At(i, j)
+			exp.Set(i, j, float64(i+1)*(2*v+v*v)*math.Exp(v))
+		}
+	}
+	te.DeepEqual(0, "gradient", true, mat.EqualApprox(exp, g, 1e-9))
+	te.DeepEqual(0, "x untouched", mat.NewM64(2, 2, []float64{0.5, -1, 2, 0.1}), x)
+
+	h, err := Hessian(f, x)
+	te.CompareError(1, nil, err)
+	expH := mat.NewM64(4, 4, nil)
+	for k := 0; k < 4; k++ {
+		v := x.At(k/2, k%2)
+		expH.Set(k, k, float64(k/2+1)*(2+4*v+v*v)*math.Exp(v))
+	}
+	te.DeepEqual(1, "hessian", true, mat.EqualApprox(expH, h, 1e-5))
+
+	//the jacobian of x -> a*x is a
+	a := mat.NewM64(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	lin := func(x *mat.M64) *mat.M64 {
+		res, _ := mat.Mul(a, x)
+		return res
+	}
+	j, err := Jacobian(lin, mat.NewM64(2, 1, []float64{0.3, -2}))
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "jacobian", true, mat.EqualApprox(a, j, 1e-10))
+
+	//polar to cartesian
+	polar := func(x *mat.M64) *mat.M64 {
+		r, th := x.At(0, 0), x.At(0, 1)
+		return mat.NewM64(2, 1, []float64{r * math.Cos(th), r * math.Sin(th)})
+	}
+	j, err = Jacobian(polar, mat.NewM64(1, 2, []float64{2, 0.7}))
+	te.CompareError(3, nil, err)
+	expJ := mat.NewM64(2, 2, []float64{math.Cos(0.7), -2 * math.Sin(0.7), math.Sin(0.7), 2 * math.Cos(0.7)})
+	te.DeepEqual(3, "polar", true, mat.EqualApprox(expJ, j, 1e-10))
+
+	_, err = Gradient(f, nil)
+	te.CompareError(4, fmt.Errorf("x is nil"), err)
+	_, err = Jacobian(func(x *mat.M64) *mat.M64 { return nil }, x)
+	te.CompareError(5, fmt.Errorf("f returned nil"), err)
+	_, err = Hessian(f, nil)
+	te.CompareError(6, fmt.Errorf("x is nil"), err)
+}
@@ -0,0 +1,136 @@
+package calculus
+
+import "math"
+
+//legendre returns P_n(z) and its derivative, from the three term recurrence
+func legendre(n int, z float64) (float64, float64) {
+	p0, p1 := 1.0, z
+	for k := 2; k <= n; k++ {
+		p0, p1 = p1, (float64(2*k-1)*z*p1-float64(k-1)*p0)/float64(k)
+	}
+	return p1, float64(n) * (z*p1 - p0) / (z*z - 1)
+}
+
+//LegendreNodes returns the n nodes in (-1,1), in increasing order, and the weights of the n point Gauss-Legendre rule, which is exact for polynomials up to degree 2n-1
+func LegendreNodes(n int) ([]float64, []float64) {
+	if n <= 0 {
+		return nil, nil
+	}
+	x, w := make([]float64, n), make([]float64, n)
+	for i := 0; i < (n+1)/2; i++ {
+		//Newton iterations on P_n from an asymptotic guess of the i-th largest root
+		z := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		for it := 0; it < 100; it++ {
+			p, dp := legendre(n, z)
+			dz := p / dp
+			z -= dz
+			if math.Abs(dz) <= 1e-16 {
+				break
+			}
+		}
+		_, dp := legendre(n, z)
+		x[i], x[n-1-i] = -z, z
+		w[i] = 2 / ((1 - z*z) * dp * dp)
+		w[n-1-i] = w[i]
+	}
+	if n%2 == 1 {
+		x[n/2] = 0
+	}
+	return x, w
+}
+
+//GaussLegendre returns the integral of f over [a,b] with the n point Gauss-Legendre rule
+func GaussLegendre(f func(x float64) float64, a, b float64, n int) float64 {
+	x, w := LegendreNodes(n)
+	half, mid := (b-a)/2, (a+b)/2
+	sum := 0.0
+	for i, xi := range x {
+		sum += w[i] * f(mid+half*xi)
+	}
+	return half * sum
+}
+
+//kronrod holds the nodes in [0,1) of the 15 point Kronrod rule, in decreasing order, the odd ones being the nodes of the embedded 7 point Gauss rule
+var kronrod = [8]float64{
+	0.991455371120812639206854697526329,
+	0.949107912342758524526189684047851,
+	0.864864423359769072789712788640926,
+	0.741531185599394439863864773280788,
+	0.586087235467691130294144845693013,
+	0.405845151377397166906606412076961,
+	0.207784955007898467600689403773245,
+	0,
+}
+
+var kronrodW = [8]float64{
+	0.022935322010529224963732008058970,
+	0.063092092629978553290700663189204,
+	0.104790010322250183839876322541518,
+	0.140653259715525918745189590510238,
+	0.169004726639267902826583426598550,
+	0.190350578064785409913256402421014,
+	0.204432940075298892414161999234649,
+	0.209482141084727828012999174891714,
+}
+
+//gaussW holds the weights of the 7 point Gauss rule, at kronrod[1], kronrod[3], kronrod[5] and kronrod[7]
+var gaussW = [4]float64{
+	0.129484966168869693270611432679082,
+	0.279705391489276667901467771423780,
+	0.381830050505118944950369775488975,
+	0.417959183673469387755102040816327,
+}
+
+//gk15 returns the 15 point Kronrod estimate of the integral over [a,b], and its difference with the 7 point Gauss estimate
+func gk15(f func(x float64) float64, a, b float64) (float64, float64) {
+	half, mid := (b-a)/2, (a+b)/2
+	fc := f(mid)
+	k, g := kronrodW[7]*fc, gaussW[3]*fc
+	for i := 0; i < 7; i++ {
+		d := half * kronrod[i]
+		sum := f(mid-d) + f(mid+d)
+		k += kronrodW[i] * sum
+		if i%2 == 1 {
+			g += gaussW[i/2] * sum
+		}
+	}
+	return half * k, math.Abs(half * (k - g))
+}
+
+//GaussKronrod returns the integral of f over [a,b] with the adaptive 7-15 Gauss-Kronrod rule: the interval with the largest error estimate is halved until the total estimate meets the tolerance. The best estimate is returned with an error if MaxEval is reached
+func GaussKronrod(f func(x float64) float64, a, b float64, s *Settings) (*Result, error) {
+	set := settingsOrDefault(s)
+	type interval struct {
+		a, b, value, err float64
+	}
+	v, e := gk15(f, a, b)
+	parts := []interval{{a, b, v, e}}
+	res := &Result{Value: v, Err: e, Evals: 15}
+	for res.Err > set.Tol*math.Max(1, math.Abs(res.Value)) || math.IsNaN(res.Err) {
+		if err := finite(res); err != nil {
+			return res, err
+		}
+		if res.Evals+30 > set.MaxEval {
+			return res, notReached(res)
+		}
+		worst := 0
+		for i, p := range parts {
+			if p.err > parts[worst].err {
+				worst = i
+			}
+		}
+		p := parts[worst]
+		m := (p.a + p.b) / 2
+		lv, le := gk15(f, p.a, m)
+		rv, re := gk15(f, m, p.b)
+		res.Evals += 30
+		parts[worst] = interval{p.a, m, lv, le}
+		parts = append(parts, interval{m, p.b, rv, re})
+		res.Value, res.Err = 0, 0
+		for _, p := range parts {
+			res.Value += p.value
+			res.Err += p.err
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,33 @@
+package calculus
+
+import (
+	"math"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestGaussLegendre(t *testing.T) {
+	te := tester.New(t)
+	x, w := LegendreNodes(3)
+	te.DeepEqual(0, "nodes", true, math.Abs(x[0]+math.Sqrt(0.6)) < 1e-15 && x[1] == 0 && math.Abs(x[2]-math.Sqrt(0.6)) < 1e-15)
+	te.DeepEqual(0, "weights", true, math.Abs(w[0]-5.0/9) < 1e-15 && math.Abs(w[1]-8.0/9) < 1e-15 && math.Abs(w[2]-5.0/9) < 1e-15)
+	x, w = LegendreNodes(1)
+	te.DeepEqual(1, "one node", []float64{0}, x)
+	te.DeepEqual(1, "one weight", []float64{2}, w)
+	x, _ = LegendreNodes(0)
+	te.DeepEqual(2, "none", 0, len(x))
+
+	//n points are exact up to degree 2n-1
+	for n := 1; n <= 20; n++ {
+		deg := 2*n - 1
+		got := GaussLegendre(func(x float64) float64 { return math.Pow(x, float64(deg)) + math.Pow(x, float64(deg-1)) }, 0, 1, n)
+		exp := 1/float64(deg+1) + 1/float64(deg)
+		te.DeepEqual(3+n, "exact degree", true, math.Abs(got-exp) < 1e-13)
+	}
+	got := GaussLegendre(math.Cos, 0, math.Pi/2, 10)
+	te.DeepEqual(30, "cos", true, math.Abs(got-1) < 1e-15)
+
+	v, e := gk15(math.Exp, 0, 1)
+	te.DeepEqual(31, "gk15", true, math.Abs(v-(math.E-1)) < 1e-15 && e < 1e-12)
+}
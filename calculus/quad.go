@@ -0,0 +1,128 @@
+//Package calculus provides numerical integration of functions of one variable and numerical differentiation, including jacobians and hessians of functions of M64
+package calculus
+
+import (
+	"fmt"
+	"math"
+)
+
+//Settings holds the parameters of the adaptive integration routines. Zero values are replaced by defaults
+type Settings struct {
+	//Tol is the target error, relative to max(1,|integral|), 1e-10 by default
+	Tol float64
+	//MaxEval is the maximum number of evaluations of the function, 100000 by default
+	MaxEval int
+}
+
+func settingsOrDefault(s *Settings) Settings {
+	var res Settings
+	if s != nil {
+		res = *s
+	}
+	if res.Tol <= 0 {
+		res.Tol = 1e-10
+	}
+	if res.MaxEval <= 0 {
+		res.MaxEval = 100000
+	}
+	return res
+}
+
+//Result holds the outcome of an adaptive integration
+type Result struct {
+	//Value is the estimated integral
+	Value float64
+	//Err is the estimated absolute error of Value
+	Err float64
+	//Evals is the number of evaluations of the function
+	Evals int
+}
+
+//finite returns an error if the estimate is not a finite number, which happens when the integrand is infinite or undefined at a node
+func finite(res *Result) error {
+	if math.IsNaN(res.Value) || math.IsInf(res.Value, 0) {
+		return fmt.Errorf("integrand is not finite")
+	}
+	return nil
+}
+
+//notReached returns the error of a routine which stopped before reaching the tolerance
+func notReached(res *Result) error {
+	return fmt.Errorf("tolerance not reached after %d evaluations, estimated error %g", res.Evals, res.Err)
+}
+
+//Simpson returns the integral of f over [a,b] with adaptive Simpson's rule: intervals are halved until the Richardson estimate of their error meets their share of the tolerance. The best estimate is returned with an error if MaxEval is reached
+func Simpson(f func(x float64) float64, a, b float64, s *Settings) (*Result, error) {
+	set := settingsOrDefault(s)
+	res := &Result{Evals: 3}
+	fa, fm, fb := f(a), f((a+b)/2), f(b)
+	whole := (b - a) / 6 * (fa + 4*fm + fb)
+	tol := set.Tol * math.Max(1, math.Abs(whole))
+	exhausted := false
+	//rec refines [a,b] whose Simpson estimate is whole, with an error estimated at est by the parent
+	var rec func(a, b, fa, fm, fb, whole, tol, est float64) float64
+	rec = func(a, b, fa, fm, fb, whole, tol, est float64) float64 {
+		if res.Evals+2 > set.MaxEval {
+			exhausted = true
+			res.Err += est
+			return whole
+		}
+		m := (a + b) / 2
+		lm, rm := (a+m)/2, (m+b)/2
+		flm, frm := f(lm), f(rm)
+		res.Evals += 2
+		left := (m - a) / 6 * (fa + 4*flm + fm)
+		right := (b - m) / 6 * (fm + 4*frm + fb)
+		delta := left + right - whole
+		if math.Abs(delta) <= 15*tol || m == a || m == b {
+			res.Err += math.Abs(delta) / 15
+			return left + right + delta/15
+		}
+		est = math.Abs(delta) / 30
+		return rec(a, m, fa, flm, fm, left, tol/2, est) + rec(m, b, fm, frm, fb, right, tol/2, est)
+	}
+	res.Value = rec(a, b, fa, fm, fb, whole, tol, math.Inf(1))
+	if err := finite(res); err != nil {
+		return res, err
+	}
+	if exhausted {
+		return res, notReached(res)
+	}
+	return res, nil
+}
+
+//Romberg returns the integral of f over [a,b] with Romberg's method: trapezoid rules on 1,2,4,... intervals improved by Richardson extrapolation. It suits smooth integrands. The best estimate is returned with an error if MaxEval is reached
+func Romberg(f func(x float64) float64, a, b float64, s *Settings) (*Result, error) {
+	set := settingsOrDefault(s)
+	h := b - a
+	prev := []float64{h / 2 * (f(a) + f(b))}
+	res := &Result{Value: prev[0], Err: math.Inf(1), Evals: 2}
+	for k, n := 1, 1; ; k, n = k+1, n*2 {
+		if res.Evals+n > set.MaxEval {
+			return res, notReached(res)
+		}
+		//trapezoid rule on 2n intervals from the one on n intervals
+		h /= 2
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			sum += f(a + float64(2*i+1)*h)
+		}
+		res.Evals += n
+		row := make([]float64, k+1)
+		row[0] = prev[0]/2 + h*sum
+		pow := 1.0
+		for j := 1; j <= k; j++ {
+			pow *= 4
+			row[j] = row[j-1] + (row[j-1]-prev[j-1])/(pow-1)
+		}
+		res.Value, res.Err = row[k], math.Abs(row[k]-prev[k-1])
+		if err := finite(res); err != nil {
+			return res, err
+		}
+		//a few levels are needed before the estimate can be trusted
+		if k >= 4 && res.Err <= set.Tol*math.Max(1, math.Abs(res.Value)) {
+			return res, nil
+		}
+		prev = row
+	}
+}
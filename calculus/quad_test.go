@@ -0,0 +1,54 @@
+package calculus
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+//integrals holds test integrands with their exact integral
+var integrals = []struct {
+	name string
+	f    func(x float64) float64
+	a, b float64
+	exp  float64
+}{
+	{"cubic", func(x float64) float64 { return x*x*x - x + 1 }, -1, 2, 5.25},
+	{"sin", math.Sin, 0, math.Pi, 2},
+	{"exp", math.Exp, 0, 1, math.E - 1},
+	{"peak", func(x float64) float64 { return 1 / (1e-4 + x*x) }, -1, 1, 2 * 100 * math.Atan(100)},
+	{"sqrt", math.Sqrt, 0, 1, 2.0 / 3},
+	{"reversed", math.Exp, 1, 0, 1 - math.E},
+}
+
+func TestAdaptive(t *testing.T) {
+	te := tester.New(t)
+	type method func(f func(x float64) float64, a, b float64, s *Settings) (*Result, error)
+	for i, test := range integrals {
+		for _, m := range []struct {
+			name string
+			fn   method
+		}{{"simpson", Simpson}, {"romberg", Romberg}, {"gauss-kronrod", GaussKronrod}} {
+			res, err := m.fn(test.f, test.a, test.b, &Settings{Tol: 1e-9})
+			if m.name == "romberg" && (test.name == "peak" || test.name == "sqrt") {
+				//not smooth enough for Romberg within the default budget: it must at least say so or be close
+				te.DeepEqual(i, m.name+" "+test.name, true, err != nil || math.Abs(res.Value-test.exp) < 1e-6*math.Max(1, math.Abs(test.exp)))
+				continue
+			}
+			te.CompareError(i, nil, err)
+			te.DeepEqual(i, m.name+" "+test.name, true, math.Abs(res.Value-test.exp) <= 1e-8*math.Max(1, math.Abs(test.exp)))
+			te.DeepEqual(i, m.name+" "+test.name+" evals", true, res.Evals > 0)
+		}
+	}
+	singular := func(x float64) float64 { return 1 / math.Sqrt(math.Abs(x-0.3)) }
+	res, err := GaussKronrod(singular, 0, 1, &Settings{MaxEval: 300})
+	te.DeepEqual(10, "budget", true, err != nil && res.Evals <= 300 && res.Err > 0)
+	res, err = Simpson(singular, 0, 1, &Settings{MaxEval: 1000})
+	te.DeepEqual(11, "budget", true, err != nil && res.Evals <= 1000 && res.Err > 0)
+	_, err = Simpson(func(x float64) float64 { return 1 / x }, 0, 1, nil)
+	te.CompareError(12, fmt.Errorf("integrand is not finite"), err)
+	_, err = GaussKronrod(func(x float64) float64 { return 1 / (x - 0.5) }, 0, 1, nil)
+	te.CompareError(13, fmt.Errorf("integrand is not finite"), err)
+}
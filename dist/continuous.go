@@ -0,0 +1,182 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+//Normal is the normal distribution N(Mu,Sigma²)
+type Normal struct {
+	Mu    float64
+	Sigma float64
+}
+
+//NewNormal returns a new normal distribution with mean mu and standard deviation sigma
+func NewNormal(mu, sigma float64) (*Normal, error) {
+	if !(sigma > 0) {
+		return nil, fmt.Errorf("sigma must be positive")
+	}
+	return &Normal{Mu: mu, Sigma: sigma}, nil
+}
+
+//PDF returns the density at x
+func (d *Normal) PDF(x float64) float64 {
+	return math.Exp(d.LogPDF(x))
+}
+
+//LogPDF returns the logarithm of the density at x
+func (d *Normal) LogPDF(x float64) float64 {
+	z := (x - d.Mu) / d.Sigma
+	return -z*z/2 - math.Log(d.Sigma) - math.Log(2*math.Pi)/2
+}
+
+//CDF returns the probability of a value <= x
+func (d *Normal) CDF(x float64) float64 {
+	return math.Erfc(-(x-d.Mu)/(d.Sigma*math.Sqrt2)) / 2
+}
+
+//Quantile returns x such that CDF(x)=p
+func (d *Normal) Quantile(p float64) float64 {
+	if badProb(p) {
+		return math.NaN()
+	}
+	return d.Mu - d.Sigma*math.Sqrt2*math.Erfcinv(2*p)
+}
+
+//Rand draws a value using rnd
+func (d *Normal) Rand(rnd *rand.Rand) float64 {
+	return d.Mu + d.Sigma*rnd.NormFloat64()
+}
+
+//Mean returns the mean of the distribution
+func (d *Normal) Mean() float64 {
+	return d.Mu
+}
+
+//Variance returns the variance of the distribution
+func (d *Normal) Variance() float64 {
+	return d.Sigma * d.Sigma
+}
+
+//Uniform is the uniform distribution on [Min,Max]
+type Uniform struct {
+	Min float64
+	Max float64
+}
+
+//NewUniform returns a new uniform distribution on [min,max]
+func NewUniform(min, max float64) (*Uniform, error) {
+	if !(max > min) {
+		return nil, fmt.Errorf("max must be greater than min")
+	}
+	return &Uniform{Min: min, Max: max}, nil
+}
+
+//PDF returns the density at x
+func (d *Uniform) PDF(x float64) float64 {
+	if x < d.Min || x > d.Max {
+		return 0
+	}
+	return 1 / (d.Max - d.Min)
+}
+
+//LogPDF returns the logarithm of the density at x
+func (d *Uniform) LogPDF(x float64) float64 {
+	return math.Log(d.PDF(x))
+}
+
+//CDF returns the probability of a value <= x
+func (d *Uniform) CDF(x float64) float64 {
+	switch {
+	case x <= d.Min:
+		return 0
+	case x >= d.Max:
+		return 1
+	}
+	return (x - d.Min) / (d.Max - d.Min)
+}
+
+//Quantile returns x such that CDF(x)=p
+func (d *Uniform) Quantile(p float64) float64 {
+	if badProb(p) {
+		return math.NaN()
+	}
+	return d.Min + p*(d.Max-d.Min)
+}
+
+//Rand draws a value using rnd
+func (d *Uniform) Rand(rnd *rand.Rand) float64 {
+	return d.Min + (d.Max-d.Min)*rnd.Float64()
+}
+
+//Mean returns the mean of the distribution
+func (d *Uniform) Mean() float64 {
+	return (d.Min + d.Max) / 2
+}
+
+//Variance returns the variance of the distribution
+func (d *Uniform) Variance() float64 {
+	w := d.Max - d.Min
+	return w * w / 12
+}
+
+//Exponential is the exponential distribution with rate Rate (mean 1/Rate)
+type Exponential struct {
+	Rate float64
+}
+
+//NewExponential returns a new exponential distribution with the given rate
+func NewExponential(rate float64) (*Exponential, error) {
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		return nil, fmt.Errorf("rate must be positive")
+	}
+	return &Exponential{Rate: rate}, nil
+}
+
+//PDF returns the density at x
+func (d *Exponential) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return d.Rate * math.Exp(-d.Rate*x)
+}
+
+//LogPDF returns the logarithm of the density at x
+func (d *Exponential) LogPDF(x float64) float64 {
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	return math.Log(d.Rate) - d.Rate*x
+}
+
+//CDF returns the probability of a value <= x
+func (d *Exponential) CDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return -math.Expm1(-d.Rate * x)
+}
+
+//Quantile returns x such that CDF(x)=p
+func (d *Exponential) Quantile(p float64) float64 {
+	if badProb(p) {
+		return math.NaN()
+	}
+	return -math.Log1p(-p) / d.Rate
+}
+
+//Rand draws a value using rnd
+func (d *Exponential) Rand(rnd *rand.Rand) float64 {
+	return rnd.ExpFloat64() / d.Rate
+}
+
+//Mean returns the mean of the distribution
+func (d *Exponential) Mean() float64 {
+	return 1 / d.Rate
+}
+
+//Variance returns the variance of the distribution
+func (d *Exponential) Variance() float64 {
+	return 1 / (d.Rate * d.Rate)
+}
@@ -0,0 +1,84 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestContinuousValues(t *testing.T) {
+	te := tester.New(t)
+	n, _ := NewNormal(0, 1)
+	u, _ := NewUniform(2, 6)
+	e, _ := NewExponential(2)
+	g, _ := NewGamma(2, 1)
+	b, _ := NewBeta(2, 2)
+	s, _ := NewStudentT(1, 0, 1)
+	tests := []struct {
+		name string
+		got  float64
+		exp  float64
+	}{
+		{"normal pdf", n.PDF(0), 1 / math.Sqrt(2*math.Pi)},
+		{"normal cdf", n.CDF(1.96), 0.9750021048517795},
+		{"normal quantile", n.Quantile(0.975), 1.959963984540054},
+		{"normal quantile 0", n.Quantile(0), math.Inf(-1)},
+		{"uniform pdf", u.PDF(3), 0.25},
+		{"uniform pdf out", u.PDF(7), 0},
+		{"uniform cdf", u.CDF(5), 0.75},
+		{"uniform quantile", u.Quantile(0.5), 4},
+		{"exponential pdf", e.PDF(1), 2 * math.Exp(-2)},
+		{"exponential pdf out", e.PDF(-1), 0},
+		{"exponential quantile", e.Quantile(0.5), math.Ln2 / 2},
+		//gamma(2,1) pdf is x*e^-x
+		{"gamma pdf", g.PDF(3), 3 * math.Exp(-3)},
+		{"gamma cdf", g.CDF(1), 1 - 2*math.Exp(-1)},
+		{"gamma pdf out", g.PDF(-1), 0},
+		//beta(2,2) pdf is 6x(1-x)
+		{"beta pdf", b.PDF(0.25), 1.125},
+		{"beta cdf", b.CDF(0.5), 0.5},
+		{"beta quantile", b.Quantile(0.5), 0.5},
+		//student with 1 degree of freedom is Cauchy
+		{"cauchy pdf", s.PDF(1), 1 / (2 * math.Pi)},
+		{"cauchy cdf", s.CDF(1), 0.75},
+		{"cauchy quantile", s.Quantile(0.25), -1},
+	}
+	for ind, test := range tests {
+		if math.Abs(test.got-test.exp) > 1e-12 && test.got != test.exp {
+			te.DeepEqual(ind, test.name, test.exp, test.got)
+		}
+	}
+	te.DeepEqual(0, "cauchy mean", true, math.IsNaN(s.Mean()))
+	s, _ = NewStudentT(2, 0, 1)
+	te.DeepEqual(0, "student variance", math.Inf(1), s.Variance())
+}
+
+func TestContinuousErrors(t *testing.T) {
+	te := tester.New(t)
+	_, e0 := NewNormal(0, 0)
+	_, e1 := NewUniform(1, 1)
+	_, e2 := NewExponential(-1)
+	_, e3 := NewGamma(0, 1)
+	_, e4 := NewGamma(1, math.NaN())
+	_, e5 := NewBeta(1, 0)
+	_, e6 := NewStudentT(0, 0, 1)
+	_, e7 := NewStudentT(1, 0, -1)
+	tests := []struct {
+		got error
+		exp error
+	}{
+		{e0, fmt.Errorf("sigma must be positive")},
+		{e1, fmt.Errorf("max must be greater than min")},
+		{e2, fmt.Errorf("rate must be positive")},
+		{e3, fmt.Errorf("shape must be positive")},
+		{e4, fmt.Errorf("rate must be positive")},
+		{e5, fmt.Errorf("alpha and beta must be positive")},
+		{e6, fmt.Errorf("nu must be positive")},
+		{e7, fmt.Errorf("sigma must be positive")},
+	}
+	for ind, test := range tests {
+		te.CompareError(ind, test.exp, test.got)
+	}
+}
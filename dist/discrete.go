@@ -0,0 +1,264 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+//Binomial is the distribution of the number of successes among N independent trials of probability P
+type Binomial struct {
+	N int
+	P float64
+}
+
+//NewBinomial returns a new binomial distribution of n trials with success probability p
+func NewBinomial(n int, p float64) (*Binomial, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("n must not be negative")
+	}
+	if badProb(p) {
+		return nil, fmt.Errorf("p must be in [0,1]")
+	}
+	return &Binomial{N: n, P: p}, nil
+}
+
+//PDF returns the probability of x successes
+func (d *Binomial) PDF(x float64) float64 {
+	return math.Exp(d.LogPDF(x))
+}
+
+//LogPDF returns the logarithm of the probability of x successes
+func (d *Binomial) LogPDF(x float64) float64 {
+	n := float64(d.N)
+	if !isInt(x) || x < 0 || x > n {
+		return math.Inf(-1)
+	}
+	return lgamma(n+1) - lgamma(x+1) - lgamma(n-x+1) + xlogy(x, d.P) + xlogy(n-x, 1-d.P)
+}
+
+//CDF returns the probability of at most x successes
+func (d *Binomial) CDF(x float64) float64 {
+	k := math.Floor(x)
+	switch {
+	case k < 0:
+		return 0
+	case k >= float64(d.N):
+		return 1
+	}
+	return betaInc(float64(d.N)-k, k+1, 1-d.P)
+}
+
+//Quantile returns the smallest number of successes k such that CDF(k) >= p
+func (d *Binomial) Quantile(p float64) float64 {
+	if badProb(p) {
+		return math.NaN()
+	}
+	return math.Min(invertDiscrete(d.CDF, p, d.Mean()), float64(d.N))
+}
+
+//Rand draws a value using rnd, by inversion: sequential search from 0 for small means, bisection on the CDF otherwise
+func (d *Binomial) Rand(rnd *rand.Rand) float64 {
+	u := rnd.Float64()
+	p, flip := d.P, false
+	if p > 0.5 {
+		p, flip = 1-p, true
+	}
+	n := float64(d.N)
+	if n*p > 30 {
+		return d.Quantile(u)
+	}
+	k, pk := 0.0, math.Pow(1-p, n)
+	cum := pk
+	for u > cum && k < n {
+		pk *= (n - k) / (k + 1) * p / (1 - p)
+		k++
+		cum += pk
+	}
+	if flip {
+		return n - k
+	}
+	return k
+}
+
+//Mean returns the mean of the distribution
+func (d *Binomial) Mean() float64 {
+	return float64(d.N) * d.P
+}
+
+//Variance returns the variance of the distribution
+func (d *Binomial) Variance() float64 {
+	return float64(d.N) * d.P * (1 - d.P)
+}
+
+//Poisson is the Poisson distribution of mean Lambda
+type Poisson struct {
+	Lambda float64
+}
+
+//NewPoisson returns a new Poisson distribution of mean lambda
+func NewPoisson(lambda float64) (*Poisson, error) {
+	if !(lambda > 0) || math.IsInf(lambda, 1) {
+		return nil, fmt.Errorf("lambda must be positive")
+	}
+	return &Poisson{Lambda: lambda}, nil
+}
+
+//PDF returns the probability of the value x
+func (d *Poisson) PDF(x float64) float64 {
+	return math.Exp(d.LogPDF(x))
+}
+
+//LogPDF returns the logarithm of the probability of the value x
+func (d *Poisson) LogPDF(x float64) float64 {
+	if !isInt(x) || x < 0 {
+		return math.Inf(-1)
+	}
+	return xlogy(x, d.Lambda) - d.Lambda - lgamma(x+1)
+}
+
+//CDF returns the probability of a value <= x
+func (d *Poisson) CDF(x float64) float64 {
+	k := math.Floor(x)
+	if k < 0 {
+		return 0
+	}
+	return gammaQ(k+1, d.Lambda)
+}
+
+//Quantile returns the smallest value k such that CDF(k) >= p
+func (d *Poisson) Quantile(p float64) float64 {
+	switch {
+	case badProb(p):
+		return math.NaN()
+	case p == 1:
+		return math.Inf(1)
+	}
+	return invertDiscrete(d.CDF, p, d.Lambda)
+}
+
+//Rand draws a value using rnd, by inversion: sequential search from 0 for small means, bisection on the CDF otherwise
+func (d *Poisson) Rand(rnd *rand.Rand) float64 {
+	u := rnd.Float64()
+	if d.Lambda > 30 {
+		return d.Quantile(u)
+	}
+	k, pk := 0.0, math.Exp(-d.Lambda)
+	cum := pk
+	for u > cum && pk > 0 {
+		k++
+		pk *= d.Lambda / k
+		cum += pk
+	}
+	return k
+}
+
+//Mean returns the mean of the distribution
+func (d *Poisson) Mean() float64 {
+	return d.Lambda
+}
+
+//Variance returns the variance of the distribution
+func (d *Poisson) Variance() float64 {
+	return d.Lambda
+}
+
+//Categorical is the distribution over the indices 0..K-1 of K categories
+type Categorical struct {
+	p   []float64
+	cum []float64
+}
+
+//NewCategorical returns a new categorical distribution, the probability of category i being proportional to weights[i]
+func NewCategorical(weights []float64) (*Categorical, error) {
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("weights must not be empty")
+	}
+	sum := 0.0
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return nil, fmt.Errorf("weights must be non negative and finite")
+		}
+		sum += w
+	}
+	if sum == 0 {
+		return nil, fmt.Errorf("weights sum to 0")
+	}
+	d := &Categorical{p: make([]float64, len(weights)), cum: make([]float64, len(weights))}
+	acc := 0.0
+	for i, w := range weights {
+		d.p[i] = w / sum
+		acc += d.p[i]
+		d.cum[i] = acc
+	}
+	d.cum[len(d.cum)-1] = 1
+	return d, nil
+}
+
+//Probs returns the probabilities of the categories
+func (d *Categorical) Probs() []float64 {
+	return append([]float64(nil), d.p...)
+}
+
+//PDF returns the probability of the category x
+func (d *Categorical) PDF(x float64) float64 {
+	if !isInt(x) || x < 0 || x >= float64(len(d.p)) {
+		return 0
+	}
+	return d.p[int(x)]
+}
+
+//LogPDF returns the logarithm of the probability of the category x
+func (d *Categorical) LogPDF(x float64) float64 {
+	return math.Log(d.PDF(x))
+}
+
+//CDF returns the probability of a category <= x
+func (d *Categorical) CDF(x float64) float64 {
+	k := math.Floor(x)
+	switch {
+	case k < 0:
+		return 0
+	case k >= float64(len(d.p)):
+		return 1
+	}
+	return d.cum[int(k)]
+}
+
+//Quantile returns the smallest category k such that CDF(k) >= p, the first category of positive probability for p=0
+func (d *Categorical) Quantile(p float64) float64 {
+	if badProb(p) {
+		return math.NaN()
+	}
+	return float64(sort.Search(len(d.cum), func(i int) bool {
+		if p == 0 {
+			return d.cum[i] > 0
+		}
+		return d.cum[i] >= p
+	}))
+}
+
+//Rand draws a category using rnd
+func (d *Categorical) Rand(rnd *rand.Rand) float64 {
+	u := rnd.Float64()
+	return float64(sort.Search(len(d.cum), func(i int) bool { return d.cum[i] > u }))
+}
+
+//Mean returns the mean of the category index
+func (d *Categorical) Mean() float64 {
+	res := 0.0
+	for i, p := range d.p {
+		res += float64(i) * p
+	}
+	return res
+}
+
+//Variance returns the variance of the category index
+func (d *Categorical) Variance() float64 {
+	m, res := d.Mean(), 0.0
+	for i, p := range d.p {
+		res += (float64(i) - m) * (float64(i) - m) * p
+	}
+	return res
+}
@@ -0,0 +1,81 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestDiscreteValues(t *testing.T) {
+	te := tester.New(t)
+	b, _ := NewBinomial(4, 0.5)
+	p, _ := NewPoisson(2)
+	c, _ := NewCategorical([]float64{1, 0, 3})
+	tests := []struct {
+		name string
+		got  float64
+		exp  float64
+	}{
+		{"binomial pmf", b.PDF(2), 6.0 / 16},
+		{"binomial cdf", b.CDF(1), 5.0 / 16},
+		{"binomial cdf n", b.CDF(4), 1},
+		{"binomial pmf out", b.PDF(5), 0},
+		{"binomial quantile", b.Quantile(0.5), 2},
+		{"binomial quantile 1", b.Quantile(1), 4},
+		{"poisson pmf", p.PDF(3), 8 * math.Exp(-2) / 6},
+		{"poisson cdf", p.CDF(1.7), 3 * math.Exp(-2)},
+		{"poisson quantile 0", p.Quantile(0), 0},
+		{"categorical pmf", c.PDF(2), 0.75},
+		{"categorical cdf", c.CDF(1), 0.25},
+		{"categorical quantile", c.Quantile(0.3), 2},
+		{"categorical quantile 0", c.Quantile(0), 0},
+		{"categorical mean", c.Mean(), 1.5},
+	}
+	for ind, test := range tests {
+		if math.Abs(test.got-test.exp) > 1e-14 {
+			te.DeepEqual(ind, test.name, test.exp, test.got)
+		}
+	}
+	te.DeepEqual(0, "probs", []float64{0.25, 0, 0.75}, c.Probs())
+
+	//degenerate binomials
+	b, _ = NewBinomial(5, 1)
+	te.DeepEqual(0, "always", 5.0, b.Rand(rand.New(rand.NewSource(1))))
+	b, _ = NewBinomial(5, 0)
+	te.DeepEqual(0, "never", 0.0, b.Rand(rand.New(rand.NewSource(1))))
+	//a zero weight category is never drawn
+	rnd := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		if c.Rand(rnd) == 1 {
+			te.DeepEqual(i, "zero weight", true, false)
+			break
+		}
+	}
+}
+
+func TestDiscreteErrors(t *testing.T) {
+	te := tester.New(t)
+	_, e0 := NewBinomial(-1, 0.5)
+	_, e1 := NewBinomial(3, 1.5)
+	_, e2 := NewPoisson(0)
+	_, e3 := NewCategorical(nil)
+	_, e4 := NewCategorical([]float64{1, -1})
+	_, e5 := NewCategorical([]float64{0, 0})
+	tests := []struct {
+		got error
+		exp error
+	}{
+		{e0, fmt.Errorf("n must not be negative")},
+		{e1, fmt.Errorf("p must be in [0,1]")},
+		{e2, fmt.Errorf("lambda must be positive")},
+		{e3, fmt.Errorf("weights must not be empty")},
+		{e4, fmt.Errorf("weights must be non negative and finite")},
+		{e5, fmt.Errorf("weights sum to 0")},
+	}
+	for ind, test := range tests {
+		te.CompareError(ind, test.exp, test.got)
+	}
+}
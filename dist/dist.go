@@ -0,0 +1,68 @@
+//Package dist provides probability distributions: univariate continuous and discrete distributions with their density, cumulative distribution, quantile function and sampling, and the multivariate normal distribution
+package dist
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/twiggg/math/optimize"
+)
+
+//Univariate is the distribution of a real random variable. For discrete distributions, values are integers and PDF is the probability mass function
+type Univariate interface {
+	//PDF returns the density (or the probability) at x
+	PDF(x float64) float64
+	//LogPDF returns the logarithm of the density at x, -Inf outside the support
+	LogPDF(x float64) float64
+	//CDF returns the probability of a value <= x
+	CDF(x float64) float64
+	//Quantile returns the smallest x such that CDF(x) >= p, NaN if p is not in [0,1]
+	Quantile(p float64) float64
+	//Rand draws a value using rnd. Its signature matches mat.Sampler, so mat.NewRandom(r, c, src, d.Rand) fills a matrix with draws
+	Rand(rnd *rand.Rand) float64
+	Mean() float64
+	Variance() float64
+}
+
+//invert returns x such that cdf(x)=p for a continuous cdf. [lo,hi] is the initial bracket, extended on the sides which are not bounded
+func invert(cdf func(x float64) float64, p, lo, hi float64, boundedLo, boundedHi bool) float64 {
+	for w := hi - lo; !boundedLo && cdf(lo) > p; w *= 2 {
+		lo -= w
+	}
+	for w := hi - lo; !boundedHi && cdf(hi) < p; w *= 2 {
+		hi += w
+	}
+	res, _ := optimize.Brent(func(x float64) float64 { return cdf(x) - p }, lo, hi, &optimize.Settings{XTol: 1e-15 * (1 + math.Abs(lo) + math.Abs(hi)), MaxIter: 300})
+	if res == nil {
+		return math.NaN()
+	}
+	return res.X
+}
+
+//invertDiscrete returns the smallest integer k >= 0 such that cdf(k) >= p, by bisection. guess is a starting upper bound
+func invertDiscrete(cdf func(x float64) float64, p, guess float64) float64 {
+	lo, hi := -1.0, math.Max(math.Ceil(guess), 1)
+	for cdf(hi) < p {
+		lo, hi = hi, 2*hi
+	}
+	//cdf(lo) < p <= cdf(hi)
+	for hi-lo > 1 {
+		mid := math.Floor((lo + hi) / 2)
+		if cdf(mid) >= p {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi
+}
+
+//badProb returns true if p is not a probability
+func badProb(p float64) bool {
+	return !(p >= 0 && p <= 1)
+}
+
+//isInt returns true if x is an integer
+func isInt(x float64) bool {
+	return x == math.Floor(x) && !math.IsInf(x, 0)
+}
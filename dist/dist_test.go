@@ -0,0 +1,132 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+//must returns d, failing the test if err is not nil
+func must(t *testing.T, d Univariate, err error) Univariate {
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+//continuous returns distributions with a density
+func continuous(t *testing.T) []Univariate {
+	var res []Univariate
+	add := func(d Univariate, err error) { res = append(res, must(t, d, err)) }
+	n, err := NewNormal(1, 2)
+	add(n, err)
+	u, err := NewUniform(-1, 3)
+	add(u, err)
+	e, err := NewExponential(1.5)
+	add(e, err)
+	g, err := NewGamma(2.5, 0.5)
+	add(g, err)
+	g, err = NewGamma(0.6, 2)
+	add(g, err)
+	b, err := NewBeta(2, 5)
+	add(b, err)
+	b, err = NewBeta(0.7, 0.5)
+	add(b, err)
+	s, err := NewStudentT(5, 1, 2)
+	add(s, err)
+	return res
+}
+
+//discrete returns distributions over integers
+func discrete(t *testing.T) []Univariate {
+	var res []Univariate
+	add := func(d Univariate, err error) { res = append(res, must(t, d, err)) }
+	b, err := NewBinomial(12, 0.3)
+	add(b, err)
+	b, err = NewBinomial(200, 0.7)
+	add(b, err)
+	p, err := NewPoisson(3.5)
+	add(p, err)
+	p, err = NewPoisson(80)
+	add(p, err)
+	c, err := NewCategorical([]float64{1, 0, 3, 4})
+	add(c, err)
+	return res
+}
+
+func TestQuantileCDF(t *testing.T) {
+	te := tester.New(t)
+	for ind, d := range continuous(t) {
+		for _, p := range []float64{1e-6, 0.01, 0.3, 0.5, 0.77, 0.99} {
+			if x := d.Quantile(p); math.Abs(d.CDF(x)-p) > 1e-10 {
+				te.DeepEqual(ind, fmt.Sprintf("%T cdf(quantile(%g))", d, p), p, d.CDF(x))
+			}
+		}
+		te.DeepEqual(ind, "nan", true, math.IsNaN(d.Quantile(1.5)))
+	}
+	for ind, d := range discrete(t) {
+		for _, p := range []float64{0.01, 0.3, 0.5, 0.77, 0.99} {
+			k := d.Quantile(p)
+			if d.CDF(k) < p || d.CDF(k-1) >= p {
+				te.DeepEqual(ind, fmt.Sprintf("%T quantile(%g)", d, p), true, false)
+			}
+		}
+		te.DeepEqual(ind, "nan", true, math.IsNaN(d.Quantile(-0.5)))
+	}
+}
+
+func TestPDFMatchesCDF(t *testing.T) {
+	te := tester.New(t)
+	for ind, d := range continuous(t) {
+		for _, p := range []float64{0.1, 0.4, 0.6, 0.9} {
+			x := d.Quantile(p)
+			h := 1e-5 * (1 + math.Abs(x))
+			num := (d.CDF(x+h) - d.CDF(x-h)) / (2 * h)
+			if math.Abs(num-d.PDF(x)) > 1e-6*(1+d.PDF(x)) {
+				te.DeepEqual(ind, fmt.Sprintf("%T pdf at %g", d, x), num, d.PDF(x))
+			}
+			if math.Abs(math.Log(d.PDF(x))-d.LogPDF(x)) > 1e-12 {
+				te.DeepEqual(ind, fmt.Sprintf("%T logpdf at %g", d, x), math.Log(d.PDF(x)), d.LogPDF(x))
+			}
+		}
+	}
+	for ind, d := range discrete(t) {
+		sum := 0.0
+		for k := 0.0; k < 150; k++ {
+			sum += d.PDF(k)
+			if math.Abs(sum-d.CDF(k)) > 1e-10 {
+				te.DeepEqual(ind, fmt.Sprintf("%T cdf at %g", d, k), sum, d.CDF(k))
+				break
+			}
+		}
+		te.DeepEqual(ind, "not integer", 0.0, d.PDF(1.5))
+		te.DeepEqual(ind, "negative", 0.0, d.CDF(-1))
+	}
+}
+
+func TestRandMoments(t *testing.T) {
+	te := tester.New(t)
+	rnd := rand.New(rand.NewSource(7))
+	const n = 100000
+	for ind, d := range append(continuous(t), discrete(t)...) {
+		sum, sum2 := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			x := d.Rand(rnd)
+			sum += x
+			sum2 += x * x
+		}
+		mean := sum / n
+		variance := sum2/n - mean*mean
+		sd := math.Sqrt(d.Variance())
+		//5 standard errors
+		if math.Abs(mean-d.Mean()) > 5*sd/math.Sqrt(n) {
+			te.DeepEqual(ind, fmt.Sprintf("%T mean", d), d.Mean(), mean)
+		}
+		if math.Abs(variance-d.Variance()) > 0.05*d.Variance() {
+			te.DeepEqual(ind, fmt.Sprintf("%T variance", d), d.Variance(), variance)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+//Gamma is the gamma distribution with shape Shape and rate Rate (mean Shape/Rate)
+type Gamma struct {
+	Shape float64
+	Rate  float64
+}
+
+//NewGamma returns a new gamma distribution with the given shape and rate
+func NewGamma(shape, rate float64) (*Gamma, error) {
+	if !(shape > 0) || math.IsInf(shape, 1) {
+		return nil, fmt.Errorf("shape must be positive")
+	}
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		return nil, fmt.Errorf("rate must be positive")
+	}
+	return &Gamma{Shape: shape, Rate: rate}, nil
+}
+
+//PDF returns the density at x
+func (d *Gamma) PDF(x float64) float64 {
+	return math.Exp(d.LogPDF(x))
+}
+
+//LogPDF returns the logarithm of the density at x
+func (d *Gamma) LogPDF(x float64) float64 {
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	return d.Shape*math.Log(d.Rate) + xlogy(d.Shape-1, x) - d.Rate*x - lgamma(d.Shape)
+}
+
+//CDF returns the probability of a value <= x
+func (d *Gamma) CDF(x float64) float64 {
+	return gammaP(d.Shape, d.Rate*x)
+}
+
+//Quantile returns x such that CDF(x)=p, solved numerically
+func (d *Gamma) Quantile(p float64) float64 {
+	switch {
+	case badProb(p):
+		return math.NaN()
+	case p == 0:
+		return 0
+	case p == 1:
+		return math.Inf(1)
+	}
+	return invert(d.CDF, p, 0, d.Mean()+4*math.Sqrt(d.Variance()), true, false)
+}
+
+//Rand draws a value using rnd
+func (d *Gamma) Rand(rnd *rand.Rand) float64 {
+	return randGamma(rnd, d.Shape) / d.Rate
+}
+
+//randGamma draws from the gamma distribution with the given shape and rate 1 (Marsaglia-Tsang)
+func randGamma(rnd *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		//boost the shape, then scale down by U^(1/shape)
+		return randGamma(rnd, shape+1) * math.Pow(rnd.Float64(), 1/shape)
+	}
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := rnd.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := rnd.Float64()
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < x*x/2+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+//Mean returns the mean of the distribution
+func (d *Gamma) Mean() float64 {
+	return d.Shape / d.Rate
+}
+
+//Variance returns the variance of the distribution
+func (d *Gamma) Variance() float64 {
+	return d.Shape / (d.Rate * d.Rate)
+}
+
+//Beta is the beta distribution on [0,1] with shapes Alpha and Beta
+type Beta struct {
+	Alpha float64
+	Beta  float64
+}
+
+//NewBeta returns a new beta distribution with shapes alpha and beta
+func NewBeta(alpha, beta float64) (*Beta, error) {
+	if !(alpha > 0) || !(beta > 0) || math.IsInf(alpha, 1) || math.IsInf(beta, 1) {
+		return nil, fmt.Errorf("alpha and beta must be positive")
+	}
+	return &Beta{Alpha: alpha, Beta: beta}, nil
+}
+
+//PDF returns the density at x
+func (d *Beta) PDF(x float64) float64 {
+	return math.Exp(d.LogPDF(x))
+}
+
+//LogPDF returns the logarithm of the density at x
+func (d *Beta) LogPDF(x float64) float64 {
+	if x < 0 || x > 1 {
+		return math.Inf(-1)
+	}
+	return xlogy(d.Alpha-1, x) + xlogy(d.Beta-1, 1-x) - lbeta(d.Alpha, d.Beta)
+}
+
+//CDF returns the probability of a value <= x
+func (d *Beta) CDF(x float64) float64 {
+	return betaInc(d.Alpha, d.Beta, x)
+}
+
+//Quantile returns x such that CDF(x)=p, solved numerically
+func (d *Beta) Quantile(p float64) float64 {
+	switch {
+	case badProb(p):
+		return math.NaN()
+	case p == 0:
+		return 0
+	case p == 1:
+		return 1
+	}
+	return invert(d.CDF, p, 0, 1, true, true)
+}
+
+//Rand draws a value using rnd
+func (d *Beta) Rand(rnd *rand.Rand) float64 {
+	x := randGamma(rnd, d.Alpha)
+	y := randGamma(rnd, d.Beta)
+	return x / (x + y)
+}
+
+//Mean returns the mean of the distribution
+func (d *Beta) Mean() float64 {
+	return d.Alpha / (d.Alpha + d.Beta)
+}
+
+//Variance returns the variance of the distribution
+func (d *Beta) Variance() float64 {
+	s := d.Alpha + d.Beta
+	return d.Alpha * d.Beta / (s * s * (s + 1))
+}
+
+//StudentT is the Student's t distribution with Nu degrees of freedom, shifted by Mu and scaled by Sigma
+type StudentT struct {
+	Nu    float64
+	Mu    float64
+	Sigma float64
+}
+
+//NewStudentT returns a new Student's t distribution with nu degrees of freedom, location mu and scale sigma
+func NewStudentT(nu, mu, sigma float64) (*StudentT, error) {
+	if !(nu > 0) {
+		return nil, fmt.Errorf("nu must be positive")
+	}
+	if !(sigma > 0) {
+		return nil, fmt.Errorf("sigma must be positive")
+	}
+	return &StudentT{Nu: nu, Mu: mu, Sigma: sigma}, nil
+}
+
+//PDF returns the density at x
+func (d *StudentT) PDF(x float64) float64 {
+	return math.Exp(d.LogPDF(x))
+}
+
+//LogPDF returns the logarithm of the density at x
+func (d *StudentT) LogPDF(x float64) float64 {
+	z := (x - d.Mu) / d.Sigma
+	return lgamma((d.Nu+1)/2) - lgamma(d.Nu/2) - math.Log(d.Nu*math.Pi)/2 - math.Log(d.Sigma) - (d.Nu+1)/2*math.Log1p(z*z/d.Nu)
+}
+
+//CDF returns the probability of a value <= x
+func (d *StudentT) CDF(x float64) float64 {
+	z := (x - d.Mu) / d.Sigma
+	if math.IsInf(z, 0) {
+		return math.Max(0, math.Copysign(1, z))
+	}
+	tail := betaInc(d.Nu/2, 0.5, d.Nu/(d.Nu+z*z)) / 2
+	if z < 0 {
+		return tail
+	}
+	return 1 - tail
+}
+
+//Quantile returns x such that CDF(x)=p, solved numerically
+func (d *StudentT) Quantile(p float64) float64 {
+	switch {
+	case badProb(p):
+		return math.NaN()
+	case p == 0:
+		return math.Inf(-1)
+	case p == 1:
+		return math.Inf(1)
+	}
+	return invert(d.CDF, p, d.Mu-d.Sigma, d.Mu+d.Sigma, false, false)
+}
+
+//Rand draws a value using rnd, as a normal draw divided by the root of an independent chi-squared draw over Nu
+func (d *StudentT) Rand(rnd *rand.Rand) float64 {
+	chi2 := 2 * randGamma(rnd, d.Nu/2)
+	return d.Mu + d.Sigma*rnd.NormFloat64()/math.Sqrt(chi2/d.Nu)
+}
+
+//Mean returns the mean of the distribution, NaN if Nu <= 1
+func (d *StudentT) Mean() float64 {
+	if d.Nu <= 1 {
+		return math.NaN()
+	}
+	return d.Mu
+}
+
+//Variance returns the variance of the distribution, +Inf if 1 < Nu <= 2 and NaN if Nu <= 1
+func (d *StudentT) Variance() float64 {
+	switch {
+	case d.Nu <= 1:
+		return math.NaN()
+	case d.Nu <= 2:
+		return math.Inf(1)
+	}
+	return d.Sigma * d.Sigma * d.Nu / (d.Nu - 2)
+}
@@ -0,0 +1,104 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//MVNormal is the multivariate normal distribution N(mean,cov)
+type MVNormal struct {
+	mean []float64
+	chol *mat.Cholesky
+	l    *mat.M64
+}
+
+//NewMVNormal returns a new multivariate normal distribution. cov must be symmetric positive definite, only its lower triangle is read. mean is copied
+func NewMVNormal(mean []float64, cov *mat.M64) (*MVNormal, error) {
+	if len(mean) == 0 {
+		return nil, fmt.Errorf("mean must not be empty")
+	}
+	if !cov.Valid() {
+		return nil, fmt.Errorf("cov is nil")
+	}
+	if r, c := cov.Dims(); r != len(mean) || c != len(mean) {
+		return nil, fmt.Errorf("cov is %d*%d, expected %d*%d", r, c, len(mean), len(mean))
+	}
+	chol, err := mat.NewCholesky(cov)
+	if err != nil {
+		return nil, err
+	}
+	return &MVNormal{mean: append([]float64(nil), mean...), chol: chol, l: chol.L()}, nil
+}
+
+//Dim returns the dimension of the distribution
+func (d *MVNormal) Dim() int {
+	return len(d.mean)
+}
+
+//Mean returns the mean of the distribution
+func (d *MVNormal) Mean() []float64 {
+	return append([]float64(nil), d.mean...)
+}
+
+//LogPDF returns the logarithm of the density at x, which must have Dim() values
+func (d *MVNormal) LogPDF(x []float64) (float64, error) {
+	n := len(d.mean)
+	if len(x) != n {
+		return 0, fmt.Errorf("x has %d values, expected %d", len(x), n)
+	}
+	diff := mat.NewM64(n, 1, nil)
+	for i := range x {
+		diff.Set(i, 0, x[i]-d.mean[i])
+	}
+	sol, err := d.chol.Solve(diff)
+	if err != nil {
+		return 0, err
+	}
+	maha := 0.0
+	for i := 0; i < n; i++ {
+		maha += diff.At(i, 0) * sol.At(i, 0)
+	}
+	return -(maha + d.chol.LogDet() + float64(n)*math.Log(2*math.Pi)) / 2, nil
+}
+
+//PDF returns the density at x, which must have Dim() values
+func (d *MVNormal) PDF(x []float64) (float64, error) {
+	lp, err := d.LogPDF(x)
+	if err != nil {
+		return 0, err
+	}
+	return math.Exp(lp), nil
+}
+
+//Rand draws a value using rnd, as mean+L*z with L the Cholesky factor of cov and z standard normal
+func (d *MVNormal) Rand(rnd *rand.Rand) []float64 {
+	n := len(d.mean)
+	z := make([]float64, n)
+	for i := range z {
+		z[i] = rnd.NormFloat64()
+	}
+	res := append([]float64(nil), d.mean...)
+	for i := 0; i < n; i++ {
+		for k := 0; k <= i; k++ {
+			res[i] += d.l.At(i, k) * z[k]
+		}
+	}
+	return res
+}
+
+//Sample returns a new n*Dim() matrix, each row being a draw. n must be positive
+func (d *MVNormal) Sample(n int, rnd *rand.Rand) (*mat.M64, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("n must be positive")
+	}
+	res := mat.NewM64(n, len(d.mean), nil)
+	for i := 0; i < n; i++ {
+		for j, v := range d.Rand(rnd) {
+			res.Set(i, j, v)
+		}
+	}
+	return res, nil
+}
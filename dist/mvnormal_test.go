@@ -0,0 +1,90 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestMVNormal(t *testing.T) {
+	te := tester.New(t)
+	cov := mat.NewM64(2, 2, []float64{
+		4, 1.2,
+		1.2, 1,
+	})
+	d, err := NewMVNormal([]float64{1, -2}, cov)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "dim", 2, d.Dim())
+	te.DeepEqual(0, "mean", []float64{1, -2}, d.Mean())
+	//density at the mean is 1/(2π sqrt(det))
+	det := 4 - 1.2*1.2
+	pdf, err := d.PDF([]float64{1, -2})
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "pdf", true, math.Abs(pdf-1/(2*math.Pi*math.Sqrt(det))) < 1e-14)
+
+	//diagonal covariance factorizes into univariate normals
+	diag, err := NewMVNormal([]float64{0, 3}, mat.Diag([]float64{1, 9}))
+	te.CompareError(1, nil, err)
+	n0, _ := NewNormal(0, 1)
+	n1, _ := NewNormal(3, 3)
+	exp := n0.LogPDF(0.5) + n1.LogPDF(-1)
+	lp, err := diag.LogPDF([]float64{0.5, -1})
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "logpdf", true, math.Abs(lp-exp) < 1e-13)
+	_, err = diag.LogPDF([]float64{0.5})
+	te.CompareError(1, fmt.Errorf("x has 1 values, expected 2"), err)
+	_, err = diag.PDF([]float64{0.5, -1, 2})
+	te.CompareError(1, fmt.Errorf("x has 3 values, expected 2"), err)
+
+	//sample moments
+	s, err := d.Sample(50000, rand.New(rand.NewSource(3)))
+	te.CompareError(2, nil, err)
+	r, c := s.Dims()
+	te.DeepEqual(2, "dims", []int{50000, 2}, []int{r, c})
+	var m [2]float64
+	var cv [2][2]float64
+	for i := 0; i < r; i++ {
+		for j := 0; j < 2; j++ {
+			m[j] += s.At(i, j) / float64(r)
+		}
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < 2; j++ {
+			for k := 0; k < 2; k++ {
+				cv[j][k] += (s.At(i, j) - m[j]) * (s.At(i, k) - m[k]) / float64(r)
+			}
+		}
+	}
+	te.DeepEqual(2, "mean", true, math.Abs(m[0]-1) < 0.05 && math.Abs(m[1]+2) < 0.05)
+	for j := 0; j < 2; j++ {
+		for k := 0; k < 2; k++ {
+			if math.Abs(cv[j][k]-cov.At(j, k)) > 0.1 {
+				te.DeepEqual(2, fmt.Sprintf("cov %d,%d", j, k), cov.At(j, k), cv[j][k])
+			}
+		}
+	}
+
+	for _, n := range []int{0, -1} {
+		_, err = d.Sample(n, rand.New(rand.NewSource(3)))
+		te.CompareError(3, fmt.Errorf("n must be positive"), err)
+	}
+
+	tests := []struct {
+		mean []float64
+		cov  *mat.M64
+		err  error
+	}{
+		{nil, cov, fmt.Errorf("mean must not be empty")},
+		{[]float64{0, 0}, nil, fmt.Errorf("cov is nil")},
+		{[]float64{0, 0, 0}, cov, fmt.Errorf("cov is 2*2, expected 3*3")},
+		{[]float64{0, 0}, mat.NewM64(2, 2, []float64{1, 2, 2, 1}), fmt.Errorf("matrix is not positive definite")},
+	}
+	for ind, test := range tests {
+		_, err := NewMVNormal(test.mean, test.cov)
+		te.CompareError(ind, test.err, err)
+	}
+}
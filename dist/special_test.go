@@ -0,0 +1,53 @@
+package dist
+
+import (
+	"math"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestGammaP(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		a, x, exp float64
+	}{
+		{1, 0, 0},
+		{1, 2, 1 - math.Exp(-2)},
+		{1, 30, 1 - math.Exp(-30)},
+		{0.5, 0.3, math.Erf(math.Sqrt(0.3))},
+		{0.5, 9, math.Erf(3)},
+		//P(3,x) = 1 - e^-x (1+x+x²/2)
+		{3, 2.5, 1 - math.Exp(-2.5)*(1+2.5+2.5*2.5/2)},
+		{3, math.Inf(1), 1},
+	}
+	for ind, test := range tests {
+		if got := gammaP(test.a, test.x); math.Abs(got-test.exp) > 1e-14 {
+			te.DeepEqual(ind, "p", test.exp, got)
+		}
+		if got := gammaQ(test.a, test.x); math.Abs(got-(1-test.exp)) > 1e-14 {
+			te.DeepEqual(ind, "q", 1-test.exp, got)
+		}
+	}
+}
+
+func TestBetaInc(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		a, b, x, exp float64
+	}{
+		{1, 1, 0.3, 0.3},
+		{2.5, 1, 0.4, math.Pow(0.4, 2.5)},
+		{1, 3, 0.2, 1 - math.Pow(0.8, 3)},
+		{2, 3, 0.4, 0.5248},
+		{2, 3, 0.9, 1 - betaInc(3, 2, 0.1)},
+		{4, 4, 0.5, 0.5},
+		{2, 3, 0, 0},
+		{2, 3, 1, 1},
+	}
+	for ind, test := range tests {
+		if got := betaInc(test.a, test.b, test.x); math.Abs(got-test.exp) > 1e-14 {
+			te.DeepEqual(ind, "betainc", test.exp, got)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package fft
+
+//Convolve returns the full linear convolution of a and b, of length len(a)+len(b)-1, as c[k]=sum a[j]*b[k-j]. nil if a or b is empty
+func Convolve(a, b []float64) []float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	n := len(a) + len(b) - 1
+	m := 1
+	for m < n {
+		m <<= 1
+	}
+	//a in the real part and b in the imaginary part share one transform: A[k]*B[k] = (Z[k]²-conj(Z[m-k])²)/4i
+	z := make([]complex128, m)
+	for i, v := range a {
+		z[i] = complex(v, 0)
+	}
+	for i, v := range b {
+		z[i] += complex(0, v)
+	}
+	radix2(z, false)
+	p := make([]complex128, m)
+	for k := range z {
+		zc := z[(m-k)%m]
+		zc = complex(real(zc), -imag(zc))
+		p[k] = (z[k]*z[k] - zc*zc) / complex(0, 4)
+	}
+	radix2(p, true)
+	res := make([]float64, n)
+	for i := range res {
+		res[i] = real(p[i]) / float64(m)
+	}
+	return res
+}
+
+//Correlate returns the full cross-correlation of a and b, of length len(a)+len(b)-1, as c[k]=sum a[j+k-len(b)+1]*b[j]. The lag 0 is at index len(b)-1. nil if a or b is empty
+func Correlate(a, b []float64) []float64 {
+	rev := make([]float64, len(b))
+	for i, v := range b {
+		rev[len(b)-1-i] = v
+	}
+	return Convolve(a, rev)
+}
@@ -0,0 +1,38 @@
+package fft
+
+import (
+	"math"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func nearReal(x, y []float64, tol float64) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if math.Abs(x[i]-y[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestConvolve(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		a, b   []float64
+		conv   []float64
+		correl []float64
+	}{
+		{[]float64{1, 2, 3}, []float64{0, 1, 0.5}, []float64{0, 1, 2.5, 4, 1.5}, []float64{0.5, 2, 3.5, 3, 0}},
+		{[]float64{2}, []float64{3}, []float64{6}, []float64{6}},
+		{[]float64{1, 1, 1, 1}, []float64{1, -1}, []float64{1, 0, 0, 0, -1}, []float64{-1, 0, 0, 0, 1}},
+		{nil, []float64{1}, nil, nil},
+	}
+	for i, test := range tests {
+		te.DeepEqual(i, "convolve", true, nearReal(test.conv, Convolve(test.a, test.b), 1e-12))
+		te.DeepEqual(i, "correlate", true, nearReal(test.correl, Correlate(test.a, test.b), 1e-12))
+	}
+}
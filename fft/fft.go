@@ -0,0 +1,156 @@
+//Package fft provides discrete Fourier transforms of any length, for complex and real inputs in 1 and 2 dimensions, and FFT based convolution
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+//maxRadix is the largest prime factor handled by the mixed radix transform, longer prime lengths use Bluestein's algorithm
+const maxRadix = 7
+
+//FFT returns a new slice with the discrete Fourier transform X[k]=sum x[j]*exp(-2πi*j*k/n) of x. Any length is accepted: powers of 2 use radix-2, lengths with small prime factors use a mixed radix transform, others Bluestein's algorithm, all in O(n*log(n))
+func FFT(x []complex128) []complex128 {
+	return transform(x, false)
+}
+
+//IFFT returns a new slice with the inverse discrete Fourier transform x[j]=1/n*sum X[k]*exp(2πi*j*k/n) of x, so that IFFT(FFT(x))=x
+func IFFT(x []complex128) []complex128 {
+	res := transform(x, true)
+	f := complex(1/float64(len(res)), 0)
+	for i := range res {
+		res[i] *= f
+	}
+	return res
+}
+
+//transform returns the unscaled forward or inverse transform of x
+func transform(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	res := append([]complex128(nil), x...)
+	switch {
+	case n <= 1:
+		return res
+	case n&(n-1) == 0:
+		radix2(res, inverse)
+		return res
+	}
+	p := smallestFactor(n)
+	if p > maxRadix {
+		return bluestein(res, inverse)
+	}
+	return mixedRadix(res, p, inverse)
+}
+
+//twiddle returns exp(∓2πi*k/n), with the + sign for the inverse transform
+func twiddle(k, n int, inverse bool) complex128 {
+	s, c := math.Sincos(2 * math.Pi * float64(k%n) / float64(n))
+	if inverse {
+		return complex(c, s)
+	}
+	return complex(c, -s)
+}
+
+func smallestFactor(n int) int {
+	if n%2 == 0 {
+		return 2
+	}
+	for p := 3; p*p <= n; p += 2 {
+		if n%p == 0 {
+			return p
+		}
+	}
+	return n
+}
+
+//radix2 transforms x in place, len(x) must be a power of 2
+func radix2(x []complex128, inverse bool) {
+	n := len(x)
+	//bit reversal permutation
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j |= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half := size >> 1
+		for k := 0; k < half; k++ {
+			w := twiddle(k, size, inverse)
+			for start := 0; start < n; start += size {
+				a, b := x[start+k], w*x[start+k+half]
+				x[start+k], x[start+k+half] = a+b, a-b
+			}
+		}
+	}
+}
+
+//mixedRadix splits x into p interleaved sub sequences, transforms them and recombines them
+func mixedRadix(x []complex128, p int, inverse bool) []complex128 {
+	n := len(x)
+	m := n / p
+	subs := make([][]complex128, p)
+	buf := make([]complex128, m)
+	for r := 0; r < p; r++ {
+		for k := 0; k < m; k++ {
+			buf[k] = x[k*p+r]
+		}
+		subs[r] = transform(buf, inverse)
+	}
+	res := make([]complex128, n)
+	for k := 0; k < m; k++ {
+		for q := 0; q < p; q++ {
+			idx := k + q*m
+			var sum complex128
+			for r := 0; r < p; r++ {
+				sum += subs[r][k] * twiddle(r*idx, n, inverse)
+			}
+			res[idx] = sum
+		}
+	}
+	return res
+}
+
+//bluestein computes the transform of any length as a circular convolution of power of 2 length
+func bluestein(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	m := 1
+	for m < 2*n-1 {
+		m <<= 1
+	}
+	//chirp[k]=exp(∓πi*k²/n), k² is reduced modulo 2n to keep the angle accurate
+	chirp := make([]complex128, n)
+	for k := range chirp {
+		s, c := math.Sincos(math.Pi * float64((k*k)%(2*n)) / float64(n))
+		if inverse {
+			chirp[k] = complex(c, s)
+		} else {
+			chirp[k] = complex(c, -s)
+		}
+	}
+	a := make([]complex128, m)
+	b := make([]complex128, m)
+	for k := 0; k < n; k++ {
+		a[k] = x[k] * chirp[k]
+		b[k] = cmplx.Conj(chirp[k])
+		if k > 0 {
+			b[m-k] = b[k]
+		}
+	}
+	radix2(a, false)
+	radix2(b, false)
+	for i := range a {
+		a[i] *= b[i]
+	}
+	radix2(a, true)
+	res := make([]complex128, n)
+	f := complex(1/float64(m), 0)
+	for k := range res {
+		res[k] = a[k] * f * chirp[k]
+	}
+	return res
+}
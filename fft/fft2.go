@@ -0,0 +1,64 @@
+package fft
+
+import (
+	"fmt"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//FFT2 returns a new matrix with the 2-D transform of m, computed as the transform of each row then of each colomn
+func FFT2(m *mat.C128) (*mat.C128, error) {
+	return transform2(m, false)
+}
+
+//IFFT2 returns a new matrix with the inverse 2-D transform of m, so that IFFT2(FFT2(m))=m
+func IFFT2(m *mat.C128) (*mat.C128, error) {
+	res, err := transform2(m, true)
+	if err != nil {
+		return nil, err
+	}
+	r, c := res.Dims()
+	f := complex(1/float64(r*c), 0)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			res.Set(i, j, res.At(i, j)*f)
+		}
+	}
+	return res, nil
+}
+
+//FFT2Real returns a new complex matrix with the 2-D transform of the real matrix m
+func FFT2Real(m *mat.M64) (*mat.C128, error) {
+	c, err := mat.C128From(m, nil)
+	if err != nil {
+		return nil, fmt.Errorf("m is nil")
+	}
+	return FFT2(c)
+}
+
+func transform2(m *mat.C128, inverse bool) (*mat.C128, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	r, c := m.Dims()
+	res := mat.NewC128(r, c, nil)
+	row := make([]complex128, c)
+	for i := 0; i < r; i++ {
+		for j := range row {
+			row[j] = m.At(i, j)
+		}
+		for j, v := range transform(row, inverse) {
+			res.Set(i, j, v)
+		}
+	}
+	col := make([]complex128, r)
+	for j := 0; j < c; j++ {
+		for i := range col {
+			col[i] = res.At(i, j)
+		}
+		for i, v := range transform(col, inverse) {
+			res.Set(i, j, v)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,46 @@
+package fft
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestFFT2(t *testing.T) {
+	te := tester.New(t)
+	m := mat.NewM64(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	f, err := FFT2Real(m)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "dc", true, near([]complex128{21}, []complex128{f.At(0, 0)}, 1e-12))
+	te.DeepEqual(0, "row frequency", true, near([]complex128{-9}, []complex128{f.At(1, 0)}, 1e-12))
+
+	//the transform of a separable product is the product of the transforms
+	rnd := rand.New(rand.NewSource(3))
+	u, v := randComplex(rnd, 5), randComplex(rnd, 8)
+	c := mat.NewC128(5, 8, nil)
+	for i := range u {
+		for j := range v {
+			c.Set(i, j, u[i]*v[j])
+		}
+	}
+	f, err = FFT2(c)
+	te.CompareError(1, nil, err)
+	fu, fv := dft(u), dft(v)
+	ok := true
+	for i := range u {
+		ok = ok && near([]complex128{fu[i] * fv[0], fu[i] * fv[7]}, []complex128{f.At(i, 0), f.At(i, 7)}, 1e-11)
+	}
+	te.DeepEqual(1, "separable", true, ok)
+	back, err := IFFT2(f)
+	te.CompareError(1, nil, err)
+	diff, _ := mat.SubC128(back, c)
+	te.DeepEqual(1, "round trip", true, mat.EqualApprox(mat.NewM64(5, 8, nil), diff.Real(), 1e-12) && mat.EqualApprox(mat.NewM64(5, 8, nil), diff.Imag(), 1e-12))
+
+	_, err = FFT2(nil)
+	te.CompareError(2, fmt.Errorf("m is nil"), err)
+	_, err = FFT2Real(nil)
+	te.CompareError(3, fmt.Errorf("m is nil"), err)
+}
@@ -0,0 +1,62 @@
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+//dft is the O(n²) definition of the transform
+func dft(x []complex128) []complex128 {
+	n := len(x)
+	res := make([]complex128, n)
+	for k := range res {
+		for j, v := range x {
+			res[k] += v * cmplx.Exp(complex(0, -2*math.Pi*float64(j*k)/float64(n)))
+		}
+	}
+	return res
+}
+
+func randComplex(rnd *rand.Rand, n int) []complex128 {
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+	}
+	return x
+}
+
+func near(x, y []complex128, tol float64) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if cmplx.Abs(x[i]-y[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFFT(t *testing.T) {
+	te := tester.New(t)
+	te.DeepEqual(0, "empty", 0, len(FFT([]complex128{})))
+	te.DeepEqual(0, "one", []complex128{2i}, FFT([]complex128{2i}))
+	te.DeepEqual(0, "two", []complex128{3, -1}, FFT([]complex128{1, 2}))
+
+	rnd := rand.New(rand.NewSource(1))
+	//powers of 2, mixed radix, primes and lengths mixing small and large factors
+	for i, n := range []int{4, 64, 6, 12, 15, 35, 49, 11, 13, 22, 51, 97} {
+		x := randComplex(rnd, n)
+		tol := 1e-12 * float64(n)
+		te.DeepEqual(1+i, "fft", true, near(dft(x), FFT(x), tol))
+		te.DeepEqual(1+i, "round trip", true, near(x, IFFT(FFT(x)), tol))
+	}
+	x := randComplex(rnd, 8)
+	orig := append([]complex128(nil), x...)
+	FFT(x)
+	te.DeepEqual(20, "input untouched", orig, x)
+}
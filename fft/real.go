@@ -0,0 +1,56 @@
+package fft
+
+import (
+	"fmt"
+	"math/cmplx"
+)
+
+//RFFT returns the n/2+1 first coefficients of the transform of the real sequence x, the others being their conjugates. Even lengths are computed as a complex transform of half the length
+func RFFT(x []float64) []complex128 {
+	n := len(x)
+	if n == 0 {
+		return nil
+	}
+	if n%2 != 0 {
+		z := make([]complex128, n)
+		for i, v := range x {
+			z[i] = complex(v, 0)
+		}
+		return FFT(z)[:n/2+1]
+	}
+	h := n / 2
+	z := make([]complex128, h)
+	for k := range z {
+		z[k] = complex(x[2*k], x[2*k+1])
+	}
+	z = FFT(z)
+	res := make([]complex128, h+1)
+	for k := 0; k <= h; k++ {
+		zk, zc := z[k%h], cmplx.Conj(z[(h-k)%h])
+		even := (zk + zc) / 2
+		odd := (zk - zc) / complex(0, 2)
+		res[k] = even + twiddle(k, n, false)*odd
+	}
+	return res
+}
+
+//IRFFT returns the real sequence of length n whose RFFT is x. len(x) must be n/2+1
+func IRFFT(x []complex128, n int) ([]float64, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("n must be positive")
+	}
+	if len(x) != n/2+1 {
+		return nil, fmt.Errorf("len(x) != n/2+1")
+	}
+	full := make([]complex128, n)
+	copy(full, x)
+	for k := n/2 + 1; k < n; k++ {
+		full[k] = cmplx.Conj(x[n-k])
+	}
+	z := IFFT(full)
+	res := make([]float64, n)
+	for i, v := range z {
+		res[i] = real(v)
+	}
+	return res, nil
+}
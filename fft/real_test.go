@@ -0,0 +1,37 @@
+package fft
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestRFFT(t *testing.T) {
+	te := tester.New(t)
+	rnd := rand.New(rand.NewSource(2))
+	for i, n := range []int{1, 2, 8, 10, 7, 30} {
+		x := make([]float64, n)
+		z := make([]complex128, n)
+		for j := range x {
+			x[j] = rnd.NormFloat64()
+			z[j] = complex(x[j], 0)
+		}
+		spec := RFFT(x)
+		te.DeepEqual(i, "rfft", true, near(FFT(z)[:n/2+1], spec, 1e-12))
+		back, err := IRFFT(spec, n)
+		te.CompareError(i, nil, err)
+		ok := true
+		for j := range x {
+			ok = ok && math.Abs(x[j]-back[j]) < 1e-12
+		}
+		te.DeepEqual(i, "irfft", true, ok)
+	}
+	te.DeepEqual(10, "empty", []complex128(nil), RFFT(nil))
+	_, err := IRFFT(make([]complex128, 3), 8)
+	te.CompareError(11, fmt.Errorf("len(x) != n/2+1"), err)
+	_, err = IRFFT(nil, 0)
+	te.CompareError(12, fmt.Errorf("n must be positive"), err)
+}
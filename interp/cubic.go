@@ -0,0 +1,163 @@
+package interp
+
+import "math"
+
+//Cubic is a piecewise cubic interpolator, defined by the values and the first derivatives at the samples (Hermite form). Splines, PCHIP and Akima only differ by how the derivatives are chosen
+type Cubic struct {
+	xs, ys, ds []float64
+}
+
+//newCubic copies the samples and the derivatives
+func newCubic(xs, ys, ds []float64) *Cubic {
+	return &Cubic{xs: append([]float64(nil), xs...), ys: append([]float64(nil), ys...), ds: ds}
+}
+
+//At returns the interpolated value at x
+func (c *Cubic) At(x float64) float64 {
+	i := piece(c.xs, x)
+	h := c.xs[i+1] - c.xs[i]
+	t := (x - c.xs[i]) / h
+	h00 := (1 + 2*t) * (1 - t) * (1 - t)
+	h10 := t * (1 - t) * (1 - t)
+	h01 := t * t * (3 - 2*t)
+	h11 := t * t * (t - 1)
+	return h00*c.ys[i] + h10*h*c.ds[i] + h01*c.ys[i+1] + h11*h*c.ds[i+1]
+}
+
+//Deriv returns the first derivative of the interpolant at x
+func (c *Cubic) Deriv(x float64) float64 {
+	i := piece(c.xs, x)
+	h := c.xs[i+1] - c.xs[i]
+	t := (x - c.xs[i]) / h
+	d00 := 6 * t * (t - 1) / h
+	d10 := (1 - t) * (1 - 3*t)
+	d01 := -d00
+	d11 := t * (3*t - 2)
+	return d00*c.ys[i] + d10*c.ds[i] + d01*c.ys[i+1] + d11*c.ds[i+1]
+}
+
+//slopes returns the slopes of the segments between samples
+func slopes(xs, ys []float64) []float64 {
+	m := make([]float64, len(xs)-1)
+	for i := range m {
+		m[i] = (ys[i+1] - ys[i]) / (xs[i+1] - xs[i])
+	}
+	return m
+}
+
+//NewNaturalSpline returns a new cubic spline through the samples, with zero second derivatives at both ends
+func NewNaturalSpline(xs, ys []float64) (*Cubic, error) {
+	if err := checkSamples(xs, ys, 2); err != nil {
+		return nil, err
+	}
+	return newCubic(xs, ys, splineDerivs(xs, ys, false, 0, 0)), nil
+}
+
+//NewClampedSpline returns a new cubic spline through the samples, with first derivatives d0 and dn at both ends
+func NewClampedSpline(xs, ys []float64, d0, dn float64) (*Cubic, error) {
+	if err := checkSamples(xs, ys, 2); err != nil {
+		return nil, err
+	}
+	return newCubic(xs, ys, splineDerivs(xs, ys, true, d0, dn)), nil
+}
+
+//splineDerivs returns the first derivatives at the samples of the cubic spline with continuous second derivatives. They solve a tridiagonal system, with natural or clamped end conditions
+func splineDerivs(xs, ys []float64, clamped bool, d0, dn float64) []float64 {
+	n := len(xs)
+	m := slopes(xs, ys)
+	//row i: sub[i]*d[i-1] + diag[i]*d[i] + sup[i]*d[i+1] = rhs[i]
+	sub, diag, sup, rhs := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	if clamped {
+		diag[0], rhs[0] = 1, d0
+		diag[n-1], rhs[n-1] = 1, dn
+	} else {
+		h0, hn := xs[1]-xs[0], xs[n-1]-xs[n-2]
+		diag[0], sup[0], rhs[0] = 2/h0, 1/h0, 3*m[0]/h0
+		sub[n-1], diag[n-1], rhs[n-1] = 1/hn, 2/hn, 3*m[n-2]/hn
+	}
+	for i := 1; i < n-1; i++ {
+		hl, hr := xs[i]-xs[i-1], xs[i+1]-xs[i]
+		sub[i], diag[i], sup[i] = 1/hl, 2/hl+2/hr, 1/hr
+		rhs[i] = 3 * (m[i-1]/hl + m[i]/hr)
+	}
+	return thomas(sub, diag, sup, rhs)
+}
+
+//thomas solves a diagonally dominant tridiagonal system
+func thomas(sub, diag, sup, rhs []float64) []float64 {
+	n := len(diag)
+	c, d := make([]float64, n), make([]float64, n)
+	c[0], d[0] = sup[0]/diag[0], rhs[0]/diag[0]
+	for i := 1; i < n; i++ {
+		den := diag[i] - sub[i]*c[i-1]
+		c[i] = sup[i] / den
+		d[i] = (rhs[i] - sub[i]*d[i-1]) / den
+	}
+	for i := n - 2; i >= 0; i-- {
+		d[i] -= c[i] * d[i+1]
+	}
+	return d
+}
+
+//NewPCHIP returns a new piecewise cubic Hermite interpolator preserving the monotonicity of the samples (Fritsch-Carlson): it does not overshoot, and is flat at local extrema
+func NewPCHIP(xs, ys []float64) (*Cubic, error) {
+	if err := checkSamples(xs, ys, 2); err != nil {
+		return nil, err
+	}
+	n := len(xs)
+	m := slopes(xs, ys)
+	d := make([]float64, n)
+	if n == 2 {
+		d[0], d[1] = m[0], m[0]
+		return newCubic(xs, ys, d), nil
+	}
+	for i := 1; i < n-1; i++ {
+		if m[i-1]*m[i] <= 0 {
+			continue
+		}
+		//weighted harmonic mean of the slopes around
+		hl, hr := xs[i]-xs[i-1], xs[i+1]-xs[i]
+		w1, w2 := 2*hr+hl, hr+2*hl
+		d[i] = (w1 + w2) / (w1/m[i-1] + w2/m[i])
+	}
+	d[0] = pchipEnd(xs[1]-xs[0], xs[2]-xs[1], m[0], m[1])
+	d[n-1] = pchipEnd(xs[n-1]-xs[n-2], xs[n-2]-xs[n-3], m[n-2], m[n-3])
+	return newCubic(xs, ys, d), nil
+}
+
+//pchipEnd returns the derivative at an end from a three points formula, h0 and m0 being the width and the slope of the end segment, h1 and m1 of its neighbour, limited to preserve the shape
+func pchipEnd(h0, h1, m0, m1 float64) float64 {
+	d := ((2*h0+h1)*m0 - h0*m1) / (h0 + h1)
+	switch {
+	case math.Signbit(d) != math.Signbit(m0) || d == 0:
+		return 0
+	case math.Signbit(m0) != math.Signbit(m1) && math.Abs(d) > 3*math.Abs(m0):
+		return 3 * m0
+	}
+	return d
+}
+
+//NewAkima returns a new Akima interpolator: the derivative at each sample is a mean of the slopes around weighted to ignore outliers, which avoids the wiggles of splines. At least 3 samples are needed
+func NewAkima(xs, ys []float64) (*Cubic, error) {
+	if err := checkSamples(xs, ys, 3); err != nil {
+		return nil, err
+	}
+	n := len(xs)
+	//slopes extended by two virtual segments on each side: m[k+2] is the slope of segment k
+	m := make([]float64, n+3)
+	copy(m[2:], slopes(xs, ys))
+	m[1] = 2*m[2] - m[3]
+	m[0] = 2*m[1] - m[2]
+	m[n+1] = 2*m[n] - m[n-1]
+	m[n+2] = 2*m[n+1] - m[n]
+	d := make([]float64, n)
+	for i := range d {
+		w1, w2 := math.Abs(m[i+3]-m[i+2]), math.Abs(m[i+1]-m[i])
+		if w1+w2 == 0 {
+			d[i] = (m[i+1] + m[i+2]) / 2
+			continue
+		}
+		d[i] = (w1*m[i+1] + w2*m[i+2]) / (w1 + w2)
+	}
+	return newCubic(xs, ys, d), nil
+}
@@ -0,0 +1,137 @@
+package interp
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestCubicReproduces(t *testing.T) {
+	te := tester.New(t)
+	xs := []float64{0, 0.5, 1.5, 2, 3.5, 4}
+	line := func(x float64) float64 { return 2*x - 1 }
+	cube := func(x float64) float64 { return x*x*x - 2*x }
+	sample := func(f func(float64) float64) []float64 {
+		ys := make([]float64, len(xs))
+		for i, x := range xs {
+			ys[i] = f(x)
+		}
+		return ys
+	}
+	mustCubic := func(c *Cubic, err error) *Cubic {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	tests := []struct {
+		name string
+		c    *Cubic
+		f    func(float64) float64
+	}{
+		{"natural line", mustCubic(NewNaturalSpline(xs, sample(line))), line},
+		{"clamped line", mustCubic(NewClampedSpline(xs, sample(line), 2, 2)), line},
+		//a clamped spline with the exact end derivatives reproduces a cubic
+		{"clamped cube", mustCubic(NewClampedSpline(xs, sample(cube), -2, 46)), cube},
+		{"pchip line", mustCubic(NewPCHIP(xs, sample(line))), line},
+		{"akima line", mustCubic(NewAkima(xs, sample(line))), line},
+	}
+	for ind, test := range tests {
+		for x := -0.5; x <= 4.5; x += 0.125 {
+			if got := test.c.At(x); !near(got, test.f(x), 1e-9) {
+				te.DeepEqual(ind, test.name, test.f(x), got)
+				break
+			}
+		}
+	}
+}
+
+func TestCubicInterpolates(t *testing.T) {
+	te := tester.New(t)
+	xs := []float64{0, 1, 2, 3, 4, 5}
+	ys := []float64{0, 1, 0, 2, 2, -1}
+	for ind, build := range []func(xs, ys []float64) (*Cubic, error){NewNaturalSpline, NewPCHIP, NewAkima} {
+		c, err := build(xs, ys)
+		te.CompareError(ind, nil, err)
+		for i, x := range xs {
+			if !near(c.At(x), ys[i], 1e-12) {
+				te.DeepEqual(ind, "sample", ys[i], c.At(x))
+			}
+		}
+	}
+}
+
+func TestNaturalSpline(t *testing.T) {
+	te := tester.New(t)
+	xs := []float64{0, 1, 2, 3}
+	ys := []float64{0, 1, 0, 1}
+	c, err := NewNaturalSpline(xs, ys)
+	te.CompareError(0, nil, err)
+	//second derivatives at the ends are zero
+	h := 1e-5
+	for ind, x := range []float64{0, 3} {
+		d2 := (c.Deriv(x+h) - c.Deriv(x)) / h
+		if x != 0 {
+			d2 = (c.Deriv(x) - c.Deriv(x-h)) / h
+		}
+		te.DeepEqual(ind, "end curvature", true, near(d2, 0, 1e-3))
+	}
+	//first derivatives are continuous at inner samples
+	for ind, x := range []float64{1, 2} {
+		te.DeepEqual(ind, "continuity", true, near(c.Deriv(x-1e-9), c.Deriv(x+1e-9), 1e-6))
+	}
+	//known solution for this data: slopes at the samples
+	te.DeepEqual(0, "slope", true, near(c.Deriv(0), 5.0/3, 1e-12))
+	te.DeepEqual(0, "slope", true, near(c.Deriv(1), -1.0/3, 1e-12))
+	//two samples give a line
+	c, err = NewNaturalSpline([]float64{0, 2}, []float64{1, 5})
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "line", 3.0, c.At(1))
+}
+
+func TestPCHIPMonotone(t *testing.T) {
+	te := tester.New(t)
+	xs := []float64{0, 1, 2, 3, 4, 5, 6}
+	ys := []float64{0, 0, 0, 1, 1, 1, 5}
+	c, err := NewPCHIP(xs, ys)
+	te.CompareError(0, nil, err)
+	prev := c.At(0)
+	for x := 0.01; x <= 6; x += 0.01 {
+		v := c.At(x)
+		if v < prev-1e-12 {
+			te.DeepEqual(0, fmt.Sprintf("monotone at %g", x), true, false)
+			break
+		}
+		prev = v
+	}
+	//flat parts stay flat
+	te.DeepEqual(0, "flat", 0.0, c.At(1.5))
+	te.DeepEqual(0, "flat", 1.0, c.At(4.5))
+	//a natural spline overshoots on the same data
+	s, _ := NewNaturalSpline(xs, ys)
+	te.DeepEqual(0, "spline overshoots", true, s.At(4.5) < 1 || s.At(4.5) > 1)
+}
+
+func TestAkima(t *testing.T) {
+	te := tester.New(t)
+	_, err := NewAkima([]float64{0, 1}, []float64{0, 1})
+	te.CompareError(0, fmt.Errorf("at least 3 samples are needed"), err)
+	//an outlier only moves the neighbouring pieces
+	xs := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8}
+	ys := []float64{0, 0, 0, 0, 10, 0, 0, 0, 0}
+	c, err := NewAkima(xs, ys)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "flat far", 0.0, c.At(1.5))
+	te.DeepEqual(0, "flat far", 0.0, c.At(6.5))
+	//smooth data is followed closely
+	xs, ys = make([]float64, 21), make([]float64, 21)
+	for i := range xs {
+		xs[i] = float64(i) * math.Pi / 10
+		ys[i] = math.Sin(xs[i])
+	}
+	c, err = NewAkima(xs, ys)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "sin", true, near(c.At(1), math.Sin(1), 1e-3))
+}
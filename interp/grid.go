@@ -0,0 +1,94 @@
+package interp
+
+import (
+	"fmt"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Grid interpolates a function of two variables sampled on a rectangular grid: z.At(i,j) is the value at (xs[i],ys[j])
+type Grid struct {
+	xs, ys []float64
+	z      *mat.M64
+}
+
+//NewGrid returns a new grid interpolator. z must have len(xs) rows and len(ys) colomns, xs and ys must be strictly increasing with at least 2 values each. The slices are copied, z is not
+func NewGrid(xs, ys []float64, z *mat.M64) (*Grid, error) {
+	if !z.Valid() {
+		return nil, fmt.Errorf("z is nil")
+	}
+	r, c := z.Dims()
+	if r != len(xs) || c != len(ys) {
+		return nil, fmt.Errorf("z is %d*%d, expected %d*%d", r, c, len(xs), len(ys))
+	}
+	for _, v := range [][]float64{xs, ys} {
+		if err := checkSamples(v, v, 2); err != nil {
+			return nil, err
+		}
+	}
+	return &Grid{xs: append([]float64(nil), xs...), ys: append([]float64(nil), ys...), z: z}, nil
+}
+
+//Bilinear returns the bilinear interpolation at (x,y). Outside the grid, the border cells are extended
+func (g *Grid) Bilinear(x, y float64) float64 {
+	i, j := piece(g.xs, x), piece(g.ys, y)
+	t := (x - g.xs[i]) / (g.xs[i+1] - g.xs[i])
+	u := (y - g.ys[j]) / (g.ys[j+1] - g.ys[j])
+	return (1-t)*(1-u)*g.z.At(i, j) + t*(1-u)*g.z.At(i+1, j) + (1-t)*u*g.z.At(i, j+1) + t*u*g.z.At(i+1, j+1)
+}
+
+//Bicubic returns the bicubic interpolation at (x,y), a tensor product of cubic Hermite pieces whose derivatives are estimated by finite differences on the grid. It is exact for functions a+b*x+c*y+d*x*y. Outside the grid, the border cells are extended
+func (g *Grid) Bicubic(x, y float64) float64 {
+	i, j := piece(g.xs, x), piece(g.ys, y)
+	hx, hy := g.xs[i+1]-g.xs[i], g.ys[j+1]-g.ys[j]
+	t, u := (x-g.xs[i])/hx, (y-g.ys[j])/hy
+	bt, dt := hermite(t)
+	bu, du := hermite(u)
+	res := 0.0
+	for a := 0; a < 2; a++ {
+		for b := 0; b < 2; b++ {
+			p, q := i+a, j+b
+			res += bt[a] * bu[b] * g.z.At(p, q)
+			res += dt[a] * hx * bu[b] * g.dx(p, q)
+			res += bt[a] * du[b] * hy * g.dy(p, q)
+			res += dt[a] * hx * du[b] * hy * g.dxy(p, q)
+		}
+	}
+	return res
+}
+
+//hermite returns the cubic Hermite basis at t in [0,1]: the weights of the values and of the derivatives at 0 and 1
+func hermite(t float64) ([2]float64, [2]float64) {
+	return [2]float64{(1 + 2*t) * (1 - t) * (1 - t), t * t * (3 - 2*t)}, [2]float64{t * (1 - t) * (1 - t), t * t * (t - 1)}
+}
+
+//around returns the neighbours of index i among n used by finite differences: one sided at the borders
+func around(i, n int) (int, int) {
+	lo, hi := i-1, i+1
+	if lo < 0 {
+		lo = 0
+	}
+	if hi > n-1 {
+		hi = n - 1
+	}
+	return lo, hi
+}
+
+//dx returns the derivative along x at the grid point (i,j)
+func (g *Grid) dx(i, j int) float64 {
+	lo, hi := around(i, len(g.xs))
+	return (g.z.At(hi, j) - g.z.At(lo, j)) / (g.xs[hi] - g.xs[lo])
+}
+
+//dy returns the derivative along y at the grid point (i,j)
+func (g *Grid) dy(i, j int) float64 {
+	lo, hi := around(j, len(g.ys))
+	return (g.z.At(i, hi) - g.z.At(i, lo)) / (g.ys[hi] - g.ys[lo])
+}
+
+//dxy returns the cross derivative at the grid point (i,j)
+func (g *Grid) dxy(i, j int) float64 {
+	il, ih := around(i, len(g.xs))
+	jl, jh := around(j, len(g.ys))
+	return (g.z.At(ih, jh) - g.z.At(ih, jl) - g.z.At(il, jh) + g.z.At(il, jl)) / ((g.xs[ih] - g.xs[il]) * (g.ys[jh] - g.ys[jl]))
+}
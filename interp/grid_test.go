@@ -0,0 +1,80 @@
+package interp
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+//sampleGrid returns the values of f on the grid xs*ys
+func sampleGrid(xs, ys []float64, f func(x, y float64) float64) *mat.M64 {
+	z := mat.NewM64(len(xs), len(ys), nil)
+	for i, x := range xs {
+		for j, y := range ys {
+			z.Set(i, j, f(x, y))
+		}
+	}
+	return z
+}
+
+func TestNewGrid(t *testing.T) {
+	te := tester.New(t)
+	xs, ys := []float64{0, 1, 2}, []float64{0, 1}
+	tests := []struct {
+		xs, ys []float64
+		z      *mat.M64
+		err    error
+	}{
+		{xs, ys, mat.NewM64(3, 2, nil), nil},
+		{xs, ys, nil, fmt.Errorf("z is nil")},
+		{xs, ys, mat.NewM64(2, 3, nil), fmt.Errorf("z is 2*3, expected 3*2")},
+		{[]float64{0, 0, 1}, ys, mat.NewM64(3, 2, nil), fmt.Errorf("xs must be strictly increasing")},
+		{xs, []float64{0}, mat.NewM64(3, 1, nil), fmt.Errorf("at least 2 samples are needed")},
+	}
+	for ind, test := range tests {
+		_, err := NewGrid(test.xs, test.ys, test.z)
+		te.CompareError(ind, test.err, err)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	te := tester.New(t)
+	xs := []float64{0, 0.5, 1.5, 2, 3}
+	ys := []float64{-1, 0, 2, 2.5}
+	bilin := func(x, y float64) float64 { return 1 + 2*x - y + 0.5*x*y }
+	g, err := NewGrid(xs, ys, sampleGrid(xs, ys, bilin))
+	te.CompareError(0, nil, err)
+	ind := 0
+	for x := -0.5; x <= 3.5; x += 0.25 {
+		for y := -1.5; y <= 3; y += 0.25 {
+			if !near(g.Bilinear(x, y), bilin(x, y), 1e-12) {
+				te.DeepEqual(ind, fmt.Sprintf("bilinear at %g,%g", x, y), bilin(x, y), g.Bilinear(x, y))
+			}
+			if !near(g.Bicubic(x, y), bilin(x, y), 1e-12) {
+				te.DeepEqual(ind, fmt.Sprintf("bicubic at %g,%g", x, y), bilin(x, y), g.Bicubic(x, y))
+			}
+			ind++
+		}
+	}
+	//on a smooth surface, bicubic is more accurate than bilinear
+	n := 11
+	xs, ys = make([]float64, n), make([]float64, n)
+	for i := range xs {
+		xs[i] = float64(i) / float64(n-1) * math.Pi
+		ys[i] = xs[i]
+	}
+	f := func(x, y float64) float64 { return math.Sin(x) * math.Cos(y) }
+	g, err = NewGrid(xs, ys, sampleGrid(xs, ys, f))
+	te.CompareError(1, nil, err)
+	var errLin, errCub float64
+	for x := 0.5; x < 2.7; x += 0.1 {
+		for y := 0.5; y < 2.7; y += 0.1 {
+			errLin = math.Max(errLin, math.Abs(g.Bilinear(x, y)-f(x, y)))
+			errCub = math.Max(errCub, math.Abs(g.Bicubic(x, y)-f(x, y)))
+		}
+	}
+	te.DeepEqual(1, "bicubic better", true, errCub < errLin/2)
+}
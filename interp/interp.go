@@ -0,0 +1,60 @@
+//Package interp interpolates samples of functions of one variable (piecewise linear and cubic) and of two variables on a grid, and fits polynomials by least squares
+package interp
+
+import (
+	"fmt"
+	"sort"
+)
+
+//Interpolator returns interpolated values between the samples it was built from. Outside the samples, the first or last piece is extended
+type Interpolator interface {
+	At(x float64) float64
+}
+
+//checkSamples returns an error if xs and ys do not hold at least min samples with strictly increasing xs
+func checkSamples(xs, ys []float64, min int) error {
+	if len(xs) != len(ys) {
+		return fmt.Errorf("xs,ys lengths not equal")
+	}
+	if len(xs) < min {
+		return fmt.Errorf("at least %d samples are needed", min)
+	}
+	for i := 1; i < len(xs); i++ {
+		if !(xs[i] > xs[i-1]) {
+			return fmt.Errorf("xs must be strictly increasing")
+		}
+	}
+	return nil
+}
+
+//piece returns the index i of the interval [xs[i],xs[i+1]] holding x, the first or last one outside
+func piece(xs []float64, x float64) int {
+	i := sort.SearchFloat64s(xs, x) - 1
+	if i < 0 {
+		return 0
+	}
+	if i > len(xs)-2 {
+		return len(xs) - 2
+	}
+	return i
+}
+
+//Linear interpolates linearly between samples
+type Linear struct {
+	xs, ys []float64
+}
+
+//NewLinear returns a new piecewise linear interpolator through the samples (xs[i],ys[i]), xs being strictly increasing. The slices are copied
+func NewLinear(xs, ys []float64) (*Linear, error) {
+	if err := checkSamples(xs, ys, 2); err != nil {
+		return nil, err
+	}
+	return &Linear{xs: append([]float64(nil), xs...), ys: append([]float64(nil), ys...)}, nil
+}
+
+//At returns the interpolated value at x
+func (l *Linear) At(x float64) float64 {
+	i := piece(l.xs, x)
+	t := (x - l.xs[i]) / (l.xs[i+1] - l.xs[i])
+	return l.ys[i] + t*(l.ys[i+1]-l.ys[i])
+}
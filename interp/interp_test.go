@@ -0,0 +1,54 @@
+package interp
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+//near returns true if a and b differ by at most tol
+func near(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol
+}
+
+func TestCheckSamples(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		xs, ys []float64
+		min    int
+		err    error
+	}{
+		{[]float64{0, 1}, []float64{0, 1}, 2, nil},
+		{[]float64{0, 1}, []float64{0}, 2, fmt.Errorf("xs,ys lengths not equal")},
+		{[]float64{0, 1}, []float64{0, 1}, 3, fmt.Errorf("at least 3 samples are needed")},
+		{[]float64{0, 1, 1}, []float64{0, 1, 2}, 2, fmt.Errorf("xs must be strictly increasing")},
+		{[]float64{0, math.NaN()}, []float64{0, 1}, 2, fmt.Errorf("xs must be strictly increasing")},
+	}
+	for ind, test := range tests {
+		te.CompareError(ind, test.err, checkSamples(test.xs, test.ys, test.min))
+	}
+}
+
+func TestLinear(t *testing.T) {
+	te := tester.New(t)
+	l, err := NewLinear([]float64{0, 1, 3}, []float64{0, 2, 0})
+	te.CompareError(0, nil, err)
+	tests := []struct {
+		x, exp float64
+	}{
+		{0, 0},
+		{0.5, 1},
+		{1, 2},
+		{2, 1},
+		{3, 0},
+		{-1, -2},
+		{4, -1},
+	}
+	for ind, test := range tests {
+		te.DeepEqual(ind, "at", test.exp, l.At(test.x))
+	}
+	_, err = NewLinear([]float64{0}, []float64{0})
+	te.CompareError(0, fmt.Errorf("at least 2 samples are needed"), err)
+}
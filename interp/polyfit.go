@@ -0,0 +1,50 @@
+package interp
+
+import (
+	"fmt"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//PolyFit returns the coefficients c[0..deg] of the polynomial c[0]+c[1]*x+...+c[deg]*x^deg minimizing the squared error over the samples. The Vandermonde system is solved by QR decomposition. At least deg+1 samples with distinct xs are needed
+func PolyFit(xs, ys []float64, deg int) ([]float64, error) {
+	if len(xs) != len(ys) {
+		return nil, fmt.Errorf("xs,ys lengths not equal")
+	}
+	if deg < 0 {
+		return nil, fmt.Errorf("deg must not be negative")
+	}
+	if len(xs) < deg+1 {
+		return nil, fmt.Errorf("at least %d samples are needed", deg+1)
+	}
+	a := mat.NewM64(len(xs), deg+1, nil)
+	for i, x := range xs {
+		p := 1.0
+		for j := 0; j <= deg; j++ {
+			a.Set(i, j, p)
+			p *= x
+		}
+	}
+	b, err := mat.FromData(len(ys), 1, append([]float64(nil), ys...))
+	if err != nil {
+		return nil, err
+	}
+	sol, err := mat.LeastSquares(a, b)
+	if err != nil {
+		return nil, err
+	}
+	c := make([]float64, deg+1)
+	for j := range c {
+		c[j] = sol.At(j, 0)
+	}
+	return c, nil
+}
+
+//PolyVal returns the value of the polynomial with coefficients c (constant first) at x, by Horner's scheme
+func PolyVal(c []float64, x float64) float64 {
+	res := 0.0
+	for i := len(c) - 1; i >= 0; i-- {
+		res = res*x + c[i]
+	}
+	return res
+}
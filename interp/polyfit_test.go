@@ -0,0 +1,63 @@
+package interp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestPolyFit(t *testing.T) {
+	te := tester.New(t)
+	xs := []float64{-2, -1, 0, 1, 2, 3}
+	quad := make([]float64, len(xs))
+	for i, x := range xs {
+		quad[i] = 1 - 2*x + 0.5*x*x
+	}
+	tests := []struct {
+		xs, ys []float64
+		deg    int
+		exp    []float64
+		err    error
+	}{
+		{xs, quad, 2, []float64{1, -2, 0.5}, nil},
+		{xs, quad, 3, []float64{1, -2, 0.5, 0}, nil},
+		//best line through symmetric data
+		{[]float64{-1, 0, 1}, []float64{1, 0, 1}, 1, []float64{2.0 / 3, 0}, nil},
+		{[]float64{0, 1, 2}, []float64{3, 3, 3}, 0, []float64{3}, nil},
+		{xs, quad[:2], 1, nil, fmt.Errorf("xs,ys lengths not equal")},
+		{xs, quad, -1, nil, fmt.Errorf("deg must not be negative")},
+		{xs[:2], quad[:2], 2, nil, fmt.Errorf("at least 3 samples are needed")},
+		{[]float64{1, 1, 1}, []float64{1, 2, 3}, 1, nil, fmt.Errorf("matrix is rank deficient")},
+	}
+	for ind, test := range tests {
+		c, err := PolyFit(test.xs, test.ys, test.deg)
+		te.CompareError(ind, test.err, err)
+		if err != nil {
+			continue
+		}
+		ok := len(c) == len(test.exp)
+		for i := 0; ok && i < len(c); i++ {
+			ok = near(c[i], test.exp[i], 1e-10)
+		}
+		if !ok {
+			te.DeepEqual(ind, "coefficients", test.exp, c)
+		}
+	}
+}
+
+func TestPolyVal(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		c      []float64
+		x, exp float64
+	}{
+		{nil, 2, 0},
+		{[]float64{3}, 2, 3},
+		{[]float64{1, -2, 0.5}, 2, -1},
+		{[]float64{0, 0, 0, 1}, -2, -8},
+	}
+	for ind, test := range tests {
+		te.DeepEqual(ind, "val", test.exp, PolyVal(test.c, test.x))
+	}
+}
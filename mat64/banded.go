@@ -0,0 +1,108 @@
+package mat
+
+import (
+	"fmt"
+
+	"github.com/twiggg/math/mat64/internal/kernel"
+)
+
+//Banded represents a r*c band matrix with kl sub-diagonals and ku super-diagonals. Each row stores the kl+ku+1 values of the band
+type Banded struct {
+	r    int
+	c    int
+	kl   int
+	ku   int
+	data []float64
+}
+
+//NewBanded returns a new r*c band matrix. data holds r*(kl+ku+1) values: for each row, the values at colomns i-kl to i+ku (positions outside the matrix are ignored). Zeros are used if its length does not match
+func NewBanded(r, c, kl, ku int, data []float64) *Banded {
+	if r <= 0 {
+		r = 1
+	}
+	if c <= 0 {
+		c = 1
+	}
+	if kl < 0 {
+		kl = 0
+	}
+	if ku < 0 {
+		ku = 0
+	}
+	b := &Banded{r: r, c: c, kl: kl, ku: ku}
+	if len(data) == r*(kl+ku+1) {
+		b.data = data
+	} else {
+		b.data = make([]float64, r*(kl+ku+1))
+	}
+	return b
+}
+
+//Dims returns the number of rows and colomns
+func (b *Banded) Dims() (int, int) {
+	if b == nil {
+		return 0, 0
+	}
+	return b.r, b.c
+}
+
+//Bandwidth returns the number of sub and super diagonals
+func (b *Banded) Bandwidth() (int, int) {
+	return b.kl, b.ku
+}
+
+func (b *Banded) inside(i, j int) bool {
+	return j >= i-b.kl && j <= i+b.ku
+}
+
+func (b *Banded) index(i, j int) int {
+	return i*(b.kl+b.ku+1) + j - i + b.kl
+}
+
+//At returns the value at position row=i,col=j, 0 outside the band. panics if b is nil or index out of range
+func (b *Banded) At(i, j int) float64 {
+	if i < 0 || j < 0 || i >= b.r || j >= b.c {
+		panic("index out of range")
+	}
+	if !b.inside(i, j) {
+		return 0
+	}
+	return b.data[b.index(i, j)]
+}
+
+//Set sets val at position row=i,col=j. panics if b is nil or the position is outside the band
+func (b *Banded) Set(i, j int, val float64) {
+	if i < 0 || j < 0 || i >= b.r || j >= b.c || !b.inside(i, j) {
+		panic("index out of band")
+	}
+	b.data[b.index(i, j)] = val
+}
+
+//Dense returns a new M64 with the values of b
+func (b *Banded) Dense() *M64 {
+	return NewDense(b)
+}
+
+//Mul returns a new matrix as the dot product b*m, in O(r*(kl+ku+1)) per colomn of m
+func (b *Banded) Mul(m *M64) (*M64, error) {
+	if b == nil {
+		return nil, fmt.Errorf("m is nil")
+	}
+	if err := structSize(b.c, m); err != nil {
+		return nil, err
+	}
+	res := NewM64(b.r, m.c, nil)
+	for i := 0; i < b.r; i++ {
+		from, to := i-b.kl, i+b.ku+1
+		if from < 0 {
+			from = 0
+		}
+		if to > b.c {
+			to = b.c
+		}
+		for k := from; k < to; k++ {
+			kernel.Daxpy(b.data[b.index(i, k)], m.data[k*m.c:(k+1)*m.c], res.data[i*m.c:(i+1)*m.c])
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,23 @@
+package mat
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestBanded(t *testing.T) {
+	te := tester.New(t)
+	//tridiagonal, values outside the matrix are ignored
+	b := NewBanded(3, 3, 1, 1, []float64{0, 1, 2, 3, 4, 5, 6, 7, 0})
+	te.DeepEqual(0, "dense", NewM64(3, 3, []float64{1, 2, 0, 3, 4, 5, 0, 6, 7}), b.Dense())
+
+	src := rand.NewSource(3)
+	b = NewBanded(5, 4, 2, 1, NewNormal(5, 4, src, 0, 1).data)
+	m := NewNormal(4, 2, src, 0, 1)
+	exp, _ := Mul(b.Dense(), m)
+	res, err := b.Mul(m)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "mul", true, EqualApprox(exp, res, 1e-12))
+}
@@ -0,0 +1,78 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/twiggg/math/mat64/internal/kernel"
+)
+
+//Cholesky holds the Cholesky decomposition of a symmetric positive definite matrix: A=L*Lᵀ, L being lower triangular with a positive diagonal
+type Cholesky struct {
+	n  int
+	l  []float64
+	lt []float64 //Lᵀ, for the back substitution
+}
+
+//NewCholesky returns the Cholesky decomposition of the symmetric positive definite matrix a. Only the lower triangle of a is read, a is not modified
+func NewCholesky(a *M64) (*Cholesky, error) {
+	if err := checkSquare(a); err != nil {
+		return nil, err
+	}
+	n := a.r
+	f := &Cholesky{n: n, l: make([]float64, n*n)}
+	l := f.l
+	for j := 0; j < n; j++ {
+		d := a.data[j*n+j]
+		for k := 0; k < j; k++ {
+			d -= l[j*n+k] * l[j*n+k]
+		}
+		if !(d > 0) {
+			return nil, fmt.Errorf("matrix is not positive definite")
+		}
+		d = math.Sqrt(d)
+		l[j*n+j] = d
+		for i := j + 1; i < n; i++ {
+			s := a.data[i*n+j]
+			for k := 0; k < j; k++ {
+				s -= l[i*n+k] * l[j*n+k]
+			}
+			l[i*n+j] = s / d
+		}
+	}
+	f.lt = make([]float64, n*n)
+	kernel.Dtrans(n, n, l, n, f.lt, n)
+	return f, nil
+}
+
+//L returns a new matrix with the lower triangular factor
+func (f *Cholesky) L() *M64 {
+	return NewM64(f.n, f.n, append([]float64(nil), f.l...))
+}
+
+//LogDet returns the natural logarithm of the determinant of the decomposed matrix
+func (f *Cholesky) LogDet() float64 {
+	res := 0.0
+	for i := 0; i < f.n; i++ {
+		res += math.Log(f.l[i*f.n+i])
+	}
+	return 2 * res
+}
+
+//Solve returns x such that a*x=b, by forward substitution with L then back substitution with Lᵀ
+func (f *Cholesky) Solve(b *M64) (*M64, error) {
+	if f == nil {
+		return nil, fmt.Errorf("decomposition is nil")
+	}
+	if !b.Valid() {
+		return nil, fmt.Errorf("b is nil")
+	}
+	if b.r != f.n {
+		return nil, fmt.Errorf("a,b rows not equal")
+	}
+	n, nb := f.n, b.c
+	x := NewM64(n, nb, append([]float64(nil), b.data...))
+	kernel.Dtrsm(false, false, n, nb, 1, f.l, n, x.data, nb)
+	kernel.Dtrsm(true, false, n, nb, 1, f.lt, n, x.data, nb)
+	return x, nil
+}
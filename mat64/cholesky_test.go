@@ -0,0 +1,52 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestCholesky(t *testing.T) {
+	te := tester.New(t)
+	f, err := NewCholesky(NewM64(2, 2, []float64{4, 2, 2, 5}))
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "l", NewM64(2, 2, []float64{2, 0, 1, 2}), f.L())
+	te.DeepEqual(0, "logdet", true, math.Abs(f.LogDet()-math.Log(16)) < 1e-14)
+
+	src := rand.NewSource(5)
+	a := NewSPD(6, src)
+	f, err = NewCholesky(a)
+	te.CompareError(1, nil, err)
+	l := f.L()
+	lt, _ := Transpose(l)
+	llt, _ := Mul(l, lt)
+	te.DeepEqual(1, "l*lt", true, EqualApprox(a, llt, 1e-12))
+	det, _ := Det(a)
+	te.DeepEqual(1, "logdet", true, math.Abs(f.LogDet()-math.Log(det)) < 1e-10)
+	b := NewNormal(6, 3, src, 0, 1)
+	x, err := f.Solve(b)
+	te.CompareError(1, nil, err)
+	exp, _ := Solve(a, b)
+	te.DeepEqual(1, "solve", true, EqualApprox(exp, x, 1e-12))
+
+	tests := []struct {
+		a   *M64
+		err error
+	}{
+		{NewM64(2, 2, []float64{1, 2, 2, 1}), fmt.Errorf("matrix is not positive definite")},
+		{NewM64(2, 2, []float64{0, 0, 0, 1}), fmt.Errorf("matrix is not positive definite")},
+		{NewM64(2, 3, nil), fmt.Errorf("a is not square")},
+		{nil, fmt.Errorf("a is nil")},
+	}
+	for ind, test := range tests {
+		_, err := NewCholesky(test.a)
+		te.CompareError(ind, test.err, err)
+	}
+	_, err = f.Solve(NewM64(5, 1, nil))
+	te.CompareError(0, fmt.Errorf("a,b rows not equal"), err)
+	_, err = (*Cholesky)(nil).Solve(b)
+	te.CompareError(1, fmt.Errorf("decomposition is nil"), err)
+}
@@ -0,0 +1,314 @@
+package mat
+
+import (
+	"fmt"
+	"math/cmplx"
+
+	"github.com/twiggg/math/mat64/internal/kernel"
+)
+
+//C128 represents a complex128 matrix with r rows and c colomns
+type C128 struct {
+	r    int
+	c    int
+	data []complex128
+}
+
+//NewC128 returns a new C128 instance, initialized with data if len==r*c, with zeros otherwise
+func NewC128(r, c int, data []complex128) *C128 {
+	if r <= 0 {
+		r = 1
+	}
+	if c <= 0 {
+		c = 1
+	}
+	m := &C128{r: r, c: c}
+	if len(data) == r*c {
+		m.data = data
+	} else {
+		m.data = make([]complex128, r*c)
+	}
+	return m
+}
+
+//C128From returns a new complex matrix re+i*im. im may be nil for a real matrix
+func C128From(re, im *M64) (*C128, error) {
+	if !re.Valid() {
+		return nil, fmt.Errorf("re is nil")
+	}
+	if im != nil {
+		if err := sameSize2(re, im); err != nil {
+			return nil, fmt.Errorf("re,im: %s", err.Error())
+		}
+	}
+	m := NewC128(re.r, re.c, nil)
+	for i, v := range re.data {
+		if im != nil {
+			m.data[i] = complex(v, im.data[i])
+		} else {
+			m.data[i] = complex(v, 0)
+		}
+	}
+	return m, nil
+}
+
+//Valid returns false if m is nil, and initiates with empty data of size=r*c if invalid size
+func (m *C128) Valid() bool {
+	if m == nil {
+		return false
+	}
+	if m.r <= 0 {
+		m.r = 1
+	}
+	if m.c <= 0 {
+		m.c = 1
+	}
+	if len(m.data) != m.r*m.c {
+		m.data = make([]complex128, m.r*m.c)
+	}
+	return true
+}
+
+//Dims returns the number of rows and colomns
+func (m *C128) Dims() (int, int) {
+	if m == nil {
+		return 0, 0
+	}
+	return m.r, m.c
+}
+
+//At returns the value at position row=i,col=j. panics if m is nil or index out of range
+func (m *C128) At(i, j int) complex128 {
+	return m.data[i*m.c+j]
+}
+
+//Set sets val at position row=i,col=j. panics if m is nil or index out of range
+func (m *C128) Set(i, j int, val complex128) {
+	m.data[i*m.c+j] = val
+}
+
+//Real returns a new matrix with the real parts of m, nil if m is nil
+func (m *C128) Real() *M64 {
+	if !m.Valid() {
+		return nil
+	}
+	res := NewM64(m.r, m.c, nil)
+	for i, v := range m.data {
+		res.data[i] = real(v)
+	}
+	return res
+}
+
+//Imag returns a new matrix with the imaginary parts of m, nil if m is nil
+func (m *C128) Imag() *M64 {
+	if !m.Valid() {
+		return nil
+	}
+	res := NewM64(m.r, m.c, nil)
+	for i, v := range m.data {
+		res.data[i] = imag(v)
+	}
+	return res
+}
+
+func sameSizeC(m, n *C128) error {
+	if !m.Valid() {
+		return fmt.Errorf("m is nil")
+	}
+	if !n.Valid() {
+		return fmt.Errorf("n is nil")
+	}
+	if m.r != n.r {
+		return fmt.Errorf("m,n rows not equal")
+	}
+	if m.c != n.c {
+		return fmt.Errorf("m,n colomns not equal")
+	}
+	return nil
+}
+
+//MapElemC128 returns a new matrix with fn applied to each pair of elements of m and n
+func MapElemC128(m, n *C128, fn func(x, y complex128) complex128) (*C128, error) {
+	if err := sameSizeC(m, n); err != nil {
+		return nil, err
+	}
+	res := NewC128(m.r, m.c, nil)
+	for i, v := range m.data {
+		res.data[i] = fn(v, n.data[i])
+	}
+	return res, nil
+}
+
+//AddC128 returns a new matrix as m+n (element by element)
+func AddC128(m, n *C128) (*C128, error) {
+	return MapElemC128(m, n, func(x, y complex128) complex128 { return x + y })
+}
+
+//SubC128 returns a new matrix as m-n (element by element)
+func SubC128(m, n *C128) (*C128, error) {
+	return MapElemC128(m, n, func(x, y complex128) complex128 { return x - y })
+}
+
+//MulElemC128 returns a new matrix as m.*n (element by element)
+func MulElemC128(m, n *C128) (*C128, error) {
+	return MapElemC128(m, n, func(x, y complex128) complex128 { return x * y })
+}
+
+//Conj returns a new matrix with the conjugates of the elements of m
+func Conj(m *C128) (*C128, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	res := NewC128(m.r, m.c, nil)
+	for i, v := range m.data {
+		res.data[i] = cmplx.Conj(v)
+	}
+	return res, nil
+}
+
+//ConjTranspose returns a new matrix as the conjugate transpose mᴴ
+func ConjTranspose(m *C128) (*C128, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	res := NewC128(m.c, m.r, nil)
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			res.data[j*m.r+i] = cmplx.Conj(m.data[i*m.c+j])
+		}
+	}
+	return res, nil
+}
+
+//MulC128 returns a new matrix as the dot product of m and n
+func MulC128(m, n *C128) (*C128, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	if !n.Valid() {
+		return nil, fmt.Errorf("n is nil")
+	}
+	if m.c != n.r {
+		return nil, fmt.Errorf("m colomns != n rows")
+	}
+	return gemmC128(false, m, n), nil
+}
+
+//MulH returns a new matrix as the Hermitian product mᴴ*n, without building mᴴ
+func MulH(m, n *C128) (*C128, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	if !n.Valid() {
+		return nil, fmt.Errorf("n is nil")
+	}
+	if m.r != n.r {
+		return nil, fmt.Errorf("m,n rows not equal")
+	}
+	return gemmC128(true, m, n), nil
+}
+
+//parts returns the real and imaginary parts of the values of m, the imaginary ones negated if conj
+func (m *C128) parts(conj bool) ([]float64, []float64) {
+	re, im := make([]float64, len(m.data)), make([]float64, len(m.data))
+	for i, v := range m.data {
+		re[i], im[i] = real(v), imag(v)
+		if conj {
+			im[i] = -im[i]
+		}
+	}
+	return re, im
+}
+
+//gemmC128 returns a new matrix as m*n, or mᴴ*n if herm, through four real products: (a+ib)(c+id)=(ac-bd)+i(ad+bc)
+func gemmC128(herm bool, m, n *C128) *C128 {
+	r, k := m.r, m.c
+	if herm {
+		r, k = m.c, m.r
+	}
+	a, b := m.parts(herm)
+	c, d := n.parts(false)
+	re, im := make([]float64, r*n.c), make([]float64, r*n.c)
+	kernel.Dgemm(herm, false, r, n.c, k, 1, a, m.c, c, n.c, 0, re, n.c)
+	kernel.Dgemm(herm, false, r, n.c, k, -1, b, m.c, d, n.c, 1, re, n.c)
+	kernel.Dgemm(herm, false, r, n.c, k, 1, a, m.c, d, n.c, 0, im, n.c)
+	kernel.Dgemm(herm, false, r, n.c, k, 1, b, m.c, c, n.c, 1, im, n.c)
+	res := NewC128(r, n.c, nil)
+	for i := range res.data {
+		res.data[i] = complex(re[i], im[i])
+	}
+	return res
+}
+
+//IsHermitian returns true if m is square and m=mᴴ within tol
+func IsHermitian(m *C128, tol float64) bool {
+	if !m.Valid() || m.r != m.c {
+		return false
+	}
+	for i := 0; i < m.r; i++ {
+		for j := i; j < m.c; j++ {
+			if cmplx.Abs(m.data[i*m.c+j]-cmplx.Conj(m.data[j*m.c+i])) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//SolveC128 returns x such that a*x=b, using the LU decomposition of a with partial pivoting
+func SolveC128(a, b *C128) (*C128, error) {
+	if !a.Valid() {
+		return nil, fmt.Errorf("a is nil")
+	}
+	if !b.Valid() {
+		return nil, fmt.Errorf("b is nil")
+	}
+	if a.r != a.c {
+		return nil, fmt.Errorf("a is not square")
+	}
+	if a.r != b.r {
+		return nil, fmt.Errorf("a,b rows not equal")
+	}
+	n, nb := a.r, b.c
+	lu := append([]complex128(nil), a.data...)
+	x := append([]complex128(nil), b.data...)
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if cmplx.Abs(lu[i*n+k]) > cmplx.Abs(lu[p*n+k]) {
+				p = i
+			}
+		}
+		if lu[p*n+k] == 0 {
+			return nil, fmt.Errorf("matrix is singular")
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				lu[k*n+j], lu[p*n+j] = lu[p*n+j], lu[k*n+j]
+			}
+			for j := 0; j < nb; j++ {
+				x[k*nb+j], x[p*nb+j] = x[p*nb+j], x[k*nb+j]
+			}
+		}
+		for i := k + 1; i < n; i++ {
+			f := lu[i*n+k] / lu[k*n+k]
+			lu[i*n+k] = f
+			for j := k + 1; j < n; j++ {
+				lu[i*n+j] -= f * lu[k*n+j]
+			}
+			for j := 0; j < nb; j++ {
+				x[i*nb+j] -= f * x[k*nb+j]
+			}
+		}
+	}
+	for j := 0; j < nb; j++ {
+		for i := n - 1; i >= 0; i-- {
+			sum := x[i*nb+j]
+			for k := i + 1; k < n; k++ {
+				sum -= lu[i*n+k] * x[k*nb+j]
+			}
+			x[i*nb+j] = sum / lu[i*n+i]
+		}
+	}
+	return NewC128(n, nb, x), nil
+}
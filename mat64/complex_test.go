@@ -0,0 +1,88 @@
+package mat
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func equalApproxC(m, n *C128, tol float64) bool {
+	if m.r != n.r || m.c != n.c {
+		return false
+	}
+	for i, v := range m.data {
+		if cmplx.Abs(v-n.data[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestC128From(t *testing.T) {
+	te := tester.New(t)
+	re := NewM64(1, 2, []float64{1, 2})
+	im := NewM64(1, 2, []float64{3, 4})
+	m, err := C128From(re, im)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "m", NewC128(1, 2, []complex128{1 + 3i, 2 + 4i}), m)
+	te.DeepEqual(0, "re", re, m.Real())
+	te.DeepEqual(0, "im", im, m.Imag())
+	var null *C128
+	te.DeepEqual(1, "nil re", (*M64)(nil), null.Real())
+	te.DeepEqual(1, "nil im", (*M64)(nil), null.Imag())
+	m, err = C128From(re, nil)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "m", NewC128(1, 2, []complex128{1, 2}), m)
+	_, err = C128From(re, NewM64(2, 1, nil))
+	te.CompareError(2, fmt.Errorf("re,im: m,dest rows not equal"), err)
+}
+
+func TestC128Ops(t *testing.T) {
+	te := tester.New(t)
+	m := NewC128(2, 2, []complex128{1 + 1i, 2, 0, 1i})
+	n := NewC128(2, 2, []complex128{1, 1i, 2 - 1i, 3})
+
+	res, err := AddC128(m, n)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "add", NewC128(2, 2, []complex128{2 + 1i, 2 + 1i, 2 - 1i, 3 + 1i}), res)
+	res, err = MulElemC128(m, n)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "mulelem", NewC128(2, 2, []complex128{1 + 1i, 2i, 0, 3i}), res)
+	res, err = ConjTranspose(m)
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "H", NewC128(2, 2, []complex128{1 - 1i, 0, 2, -1i}), res)
+	res, err = MulC128(m, n)
+	te.CompareError(3, nil, err)
+	te.DeepEqual(3, "mul", NewC128(2, 2, []complex128{5 - 1i, 5 + 1i, 1 + 2i, 3i}), res)
+
+	mh, _ := ConjTranspose(m)
+	exp, _ := MulC128(mh, n)
+	res, err = MulH(m, n)
+	te.CompareError(4, nil, err)
+	te.DeepEqual(4, "mulH", exp, res)
+	gram, _ := MulH(m, m)
+	te.DeepEqual(5, "hermitian", true, IsHermitian(gram, 0))
+	te.DeepEqual(6, "hermitian", false, IsHermitian(m, 0))
+
+	_, err = SubC128(m, NewC128(1, 2, nil))
+	te.CompareError(7, fmt.Errorf("m,n rows not equal"), err)
+}
+
+func TestSolveC128(t *testing.T) {
+	te := tester.New(t)
+	src := rand.NewSource(1)
+	a, _ := C128From(NewNormal(6, 6, src, 0, 1), NewNormal(6, 6, src, 0, 1))
+	x, _ := C128From(NewNormal(6, 2, src, 0, 1), NewNormal(6, 2, src, 0, 1))
+	b, _ := MulC128(a, x)
+	res, err := SolveC128(a, b)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "x", true, equalApproxC(x, res, 1e-10))
+
+	_, err = SolveC128(NewC128(2, 2, []complex128{1, 1i, 1i, -1}), NewC128(2, 1, nil))
+	te.CompareError(1, fmt.Errorf("matrix is singular"), err)
+	_, err = SolveC128(NewC128(2, 3, nil), NewC128(2, 1, nil))
+	te.CompareError(2, fmt.Errorf("a is not square"), err)
+}
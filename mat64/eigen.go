@@ -0,0 +1,346 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+//EigenSym returns the eigenvalues of the symmetric matrix a in ascending order, and the matching orthonormal eigenvectors as the colomns of a matrix. It uses the cyclic Jacobi method. Only the upper triangle of a is read
+func EigenSym(a *M64) ([]float64, *M64, error) {
+	if err := checkSquare(a); err != nil {
+		return nil, nil, err
+	}
+	n := a.r
+	s := NewM64(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			s.data[i*n+j] = a.data[i*n+j]
+			s.data[j*n+i] = a.data[i*n+j]
+		}
+	}
+	v := Identity(n)
+	converged := false
+	for sweep := 0; sweep < 100 && !converged; sweep++ {
+		off := 0.0
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				off += s.data[i*n+j] * s.data[i*n+j]
+			}
+		}
+		if off == 0 || math.Sqrt(off) <= 1e-15*norm1(s) {
+			converged = true
+			break
+		}
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := s.data[p*n+q]
+				if apq == 0 {
+					continue
+				}
+				//rotation zeroing s[p,q]
+				theta := (s.data[q*n+q] - s.data[p*n+p]) / (2 * apq)
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				sn := t * c
+				for k := 0; k < n; k++ {
+					skp, skq := s.data[k*n+p], s.data[k*n+q]
+					s.data[k*n+p] = c*skp - sn*skq
+					s.data[k*n+q] = sn*skp + c*skq
+				}
+				for k := 0; k < n; k++ {
+					spk, sqk := s.data[p*n+k], s.data[q*n+k]
+					s.data[p*n+k] = c*spk - sn*sqk
+					s.data[q*n+k] = sn*spk + c*sqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v.data[k*n+p], v.data[k*n+q]
+					v.data[k*n+p] = c*vkp - sn*vkq
+					v.data[k*n+q] = sn*vkp + c*vkq
+				}
+			}
+		}
+	}
+	if !converged {
+		return nil, nil, fmt.Errorf("eigen decomposition did not converge")
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return s.data[order[i]*n+order[i]] < s.data[order[j]*n+order[j]]
+	})
+	vals := make([]float64, n)
+	vecs := NewM64(n, n, nil)
+	for k, o := range order {
+		vals[k] = s.data[o*n+o]
+		for i := 0; i < n; i++ {
+			vecs.data[i*n+k] = v.data[i*n+o]
+		}
+	}
+	return vals, vecs, nil
+}
+
+//Eigenvalues returns the eigenvalues of the square matrix a, sorted by real part then imaginary part. Complex eigenvalues come in conjugate pairs. a is balanced, reduced to Hessenberg form, then the shifted QR algorithm is applied
+func Eigenvalues(a *M64) ([]complex128, error) {
+	if err := checkSquare(a); err != nil {
+		return nil, err
+	}
+	n := a.r
+	h := NewM64(n, n, append([]float64(nil), a.data...))
+	balance(h)
+	hessenberg(h)
+	vals, err := hqr(h)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(vals, func(i, j int) bool {
+		if real(vals[i]) != real(vals[j]) {
+			return real(vals[i]) < real(vals[j])
+		}
+		return imag(vals[i]) < imag(vals[j])
+	})
+	return vals, nil
+}
+
+//balance scales the rows and colomns of the square matrix a by powers of 2 to make their norms close, which does not change the eigenvalues but reduces rounding errors
+func balance(a *M64) {
+	n := a.r
+	done := false
+	for !done {
+		done = true
+		for i := 0; i < n; i++ {
+			r, c := 0.0, 0.0
+			for j := 0; j < n; j++ {
+				if j != i {
+					c += math.Abs(a.data[j*n+i])
+					r += math.Abs(a.data[i*n+j])
+				}
+			}
+			if c == 0 || r == 0 {
+				continue
+			}
+			g, f, s := r/2, 1.0, c+r
+			for c < g {
+				f *= 2
+				c *= 4
+			}
+			g = r * 2
+			for c > g {
+				f /= 2
+				c /= 4
+			}
+			if (c+r)/f < 0.95*s {
+				done = false
+				for j := 0; j < n; j++ {
+					a.data[i*n+j] /= f
+					a.data[j*n+i] *= f
+				}
+			}
+		}
+	}
+}
+
+//hessenberg reduces the square matrix a in place to upper Hessenberg form by similarity transforms (Gaussian elimination with pivoting)
+func hessenberg(a *M64) {
+	n := a.r
+	for m := 1; m < n-1; m++ {
+		x, p := 0.0, m
+		for j := m; j < n; j++ {
+			if math.Abs(a.data[j*n+m-1]) > math.Abs(x) {
+				x, p = a.data[j*n+m-1], j
+			}
+		}
+		if p != m {
+			for j := m - 1; j < n; j++ {
+				a.data[p*n+j], a.data[m*n+j] = a.data[m*n+j], a.data[p*n+j]
+			}
+			for j := 0; j < n; j++ {
+				a.data[j*n+p], a.data[j*n+m] = a.data[j*n+m], a.data[j*n+p]
+			}
+		}
+		if x == 0 {
+			continue
+		}
+		for i := m + 1; i < n; i++ {
+			y := a.data[i*n+m-1]
+			if y == 0 {
+				continue
+			}
+			y /= x
+			a.data[i*n+m-1] = 0
+			for j := m; j < n; j++ {
+				a.data[i*n+j] -= y * a.data[m*n+j]
+			}
+			for j := 0; j < n; j++ {
+				a.data[j*n+m] += y * a.data[j*n+i]
+			}
+		}
+	}
+}
+
+//hqr returns the eigenvalues of the upper Hessenberg matrix a with the Francis double shift QR algorithm. a is overwritten
+func hqr(a *M64) ([]complex128, error) {
+	n := a.r
+	at := func(i, j int) *float64 { return &a.data[i*n+j] }
+	vals := make([]complex128, n)
+	anorm := 0.0
+	for i := 0; i < n; i++ {
+		for j := i - 1; j < n; j++ {
+			if j >= 0 {
+				anorm += math.Abs(*at(i, j))
+			}
+		}
+	}
+	const eps = 2.220446049250313e-16
+	var p, q, r, s, t, w, x, y, z float64
+	nn := n - 1
+	for nn >= 0 {
+		its := 0
+		l := 0
+		for {
+			//look for a small subdiagonal value splitting the matrix
+			for l = nn; l > 0; l-- {
+				s = math.Abs(*at(l-1, l-1)) + math.Abs(*at(l, l))
+				if s == 0 {
+					s = anorm
+				}
+				if math.Abs(*at(l, l-1)) <= eps*s {
+					*at(l, l-1) = 0
+					break
+				}
+			}
+			x = *at(nn, nn)
+			if l == nn {
+				//one root found
+				vals[nn] = complex(x+t, 0)
+				nn--
+			} else {
+				y = *at(nn-1, nn-1)
+				w = *at(nn, nn-1) * *at(nn-1, nn)
+				if l == nn-1 {
+					//two roots found
+					p = (y - x) / 2
+					q = p*p + w
+					z = math.Sqrt(math.Abs(q))
+					x += t
+					if q >= 0 {
+						z = p + math.Copysign(z, p)
+						vals[nn-1], vals[nn] = complex(x+z, 0), complex(x+z, 0)
+						if z != 0 {
+							vals[nn] = complex(x-w/z, 0)
+						}
+					} else {
+						vals[nn-1], vals[nn] = complex(x+p, z), complex(x+p, -z)
+					}
+					nn -= 2
+				} else {
+					if its == 30 {
+						return nil, fmt.Errorf("eigen decomposition did not converge")
+					}
+					if its == 10 || its == 20 {
+						//exceptional shift
+						t += x
+						for i := 0; i <= nn; i++ {
+							*at(i, i) -= x
+						}
+						s = math.Abs(*at(nn, nn-1)) + math.Abs(*at(nn-1, nn-2))
+						x = 0.75 * s
+						y = x
+						w = -0.4375 * s * s
+					}
+					its++
+					m := nn - 2
+					for ; m >= l; m-- {
+						z = *at(m, m)
+						r = x - z
+						s = y - z
+						p = (r*s-w) / *at(m+1, m) + *at(m, m+1)
+						q = *at(m+1, m+1) - z - r - s
+						r = *at(m+2, m+1)
+						s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+						p /= s
+						q /= s
+						r /= s
+						if m == l {
+							break
+						}
+						u := math.Abs(*at(m, m-1)) * (math.Abs(q) + math.Abs(r))
+						v := math.Abs(p) * (math.Abs(*at(m-1, m-1)) + math.Abs(z) + math.Abs(*at(m+1, m+1)))
+						if u <= eps*v {
+							break
+						}
+					}
+					for i := m; i < nn-1; i++ {
+						*at(i+2, i) = 0
+						if i != m {
+							*at(i+2, i-1) = 0
+						}
+					}
+					//double QR step on rows l to nn and colomns m to nn
+					for k := m; k < nn; k++ {
+						if k != m {
+							p = *at(k, k-1)
+							q = *at(k+1, k-1)
+							r = 0
+							if k+1 != nn {
+								r = *at(k+2, k-1)
+							}
+							if x = math.Abs(p) + math.Abs(q) + math.Abs(r); x != 0 {
+								p /= x
+								q /= x
+								r /= x
+							}
+						}
+						if s = math.Copysign(math.Sqrt(p*p+q*q+r*r), p); s == 0 {
+							continue
+						}
+						if k == m {
+							if l != m {
+								*at(k, k-1) = -*at(k, k-1)
+							}
+						} else {
+							*at(k, k-1) = -s * x
+						}
+						p += s
+						x = p / s
+						y = q / s
+						z = r / s
+						q /= p
+						r /= p
+						for j := k; j <= nn; j++ {
+							p = *at(k, j) + q**at(k+1, j)
+							if k+1 != nn {
+								p += r * *at(k+2, j)
+								*at(k+2, j) -= p * z
+							}
+							*at(k+1, j) -= p * y
+							*at(k, j) -= p * x
+						}
+						mmin := k + 3
+						if nn < mmin {
+							mmin = nn
+						}
+						for i := l; i <= mmin; i++ {
+							p = x**at(i, k) + y**at(i, k+1)
+							if k+1 != nn {
+								p += z * *at(i, k+2)
+								*at(i, k+2) -= p * r
+							}
+							*at(i, k+1) -= p * q
+							*at(i, k) -= p
+						}
+					}
+				}
+			}
+			if l+1 >= nn {
+				break
+			}
+		}
+	}
+	return vals, nil
+}
@@ -0,0 +1,103 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestEigenSym(t *testing.T) {
+	te := tester.New(t)
+	vals, vecs, err := EigenSym(NewM64(2, 2, []float64{2, 1, 1, 2}))
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "vals", true, EqualApprox(NewM64(1, 2, []float64{1, 3}), NewM64(1, 2, vals), 1e-14))
+	av, _ := Mul(NewM64(2, 2, []float64{2, 1, 1, 2}), vecs)
+	vd, _ := Mul(vecs, Diag(vals))
+	te.DeepEqual(0, "a*v=v*d", true, EqualApprox(vd, av, 1e-14))
+
+	a := NewSPD(6, rand.NewSource(1))
+	vals, vecs, err = EigenSym(a)
+	te.CompareError(1, nil, err)
+	for i := 1; i < len(vals); i++ {
+		te.DeepEqual(1, "ascending", true, vals[i-1] <= vals[i])
+	}
+	vt, _ := Transpose(vecs)
+	p, _ := Mul(vt, vecs)
+	te.DeepEqual(1, "orthonormal", true, EqualApprox(Identity(6), p, 1e-12))
+	av, _ = Mul(a, vecs)
+	vd, _ = Mul(vecs, Diag(vals))
+	te.DeepEqual(1, "a*v=v*d", true, EqualApprox(vd, av, 1e-12))
+
+	vals, _, err = EigenSym(Identity(3))
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "identity", []float64{1, 1, 1}, vals)
+	_, _, err = EigenSym(NewM64(2, 3, nil))
+	te.CompareError(3, fmt.Errorf("a is not square"), err)
+}
+
+func TestEigenvalues(t *testing.T) {
+	te := tester.New(t)
+	near := func(exp, got []complex128, tol float64) bool {
+		if len(exp) != len(got) {
+			return false
+		}
+		for i := range exp {
+			if cmplx.Abs(exp[i]-got[i]) > tol {
+				return false
+			}
+		}
+		return true
+	}
+	tests := []struct {
+		a   *M64
+		exp []complex128
+		err error
+	}{
+		{NewM64(1, 1, []float64{-2}), []complex128{-2}, nil},
+		{NewM64(2, 2, []float64{2, 1, 1, 2}), []complex128{1, 3}, nil},
+		//rotation
+		{NewM64(2, 2, []float64{0, -1, 1, 0}), []complex128{-1i, 1i}, nil},
+		//upper triangular
+		{NewM64(3, 3, []float64{3, 5, 7, 0, -1, 2, 0, 0, 2}), []complex128{-1, 2, 3}, nil},
+		//companion matrix of (x-1)(x-2)(x-3)(x²+1)
+		{NewM64(5, 5, []float64{
+			0, 0, 0, 0, 6,
+			1, 0, 0, 0, -11,
+			0, 1, 0, 0, 12,
+			0, 0, 1, 0, -12,
+			0, 0, 0, 1, 6,
+		}), []complex128{-1i, 1i, 1, 2, 3}, nil},
+		{NewM64(2, 3, nil), nil, fmt.Errorf("a is not square")},
+		{nil, nil, fmt.Errorf("a is nil")},
+	}
+	for ind, test := range tests {
+		vals, err := Eigenvalues(test.a)
+		te.CompareError(ind, test.err, err)
+		if err == nil && !near(test.exp, vals, 1e-10) {
+			te.DeepEqual(ind, "vals", test.exp, vals)
+		}
+	}
+
+	//random matrices: the sum is the trace and the product is the determinant
+	for ind := 0; ind < 5; ind++ {
+		a := NewNormal(8, 8, rand.NewSource(int64(ind)), 0, 1)
+		vals, err := Eigenvalues(a)
+		te.CompareError(ind, nil, err)
+		sum, prod := complex(0, 0), complex(1, 0)
+		for _, v := range vals {
+			sum += v
+			prod *= v
+		}
+		trace := 0.0
+		for i := 0; i < 8; i++ {
+			trace += a.At(i, i)
+		}
+		det, _ := Det(a)
+		te.DeepEqual(ind, "trace", true, cmplx.Abs(sum-complex(trace, 0)) < 1e-10)
+		te.DeepEqual(ind, "det", true, cmplx.Abs(prod-complex(det, 0)) < 1e-9*math.Max(1, math.Abs(det)))
+	}
+}
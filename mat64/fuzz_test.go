@@ -0,0 +1,93 @@
+package mat
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+//floats decodes b as little endian float64s
+func floats(b []byte) []float64 {
+	res := make([]float64, len(b)/8)
+	for i := range res {
+		res[i] = math.Float64frombits(binary.LittleEndian.Uint64(b[8*i:]))
+	}
+	return res
+}
+
+func FuzzNewM64(f *testing.F) {
+	f.Add(3, 3, make([]byte, 72))
+	f.Add(0, -1, []byte{})
+	f.Add(2, 5, make([]byte, 16))
+	f.Fuzz(func(t *testing.T, r, c int, b []byte) {
+		if r > 64 || c > 64 {
+			t.Skip()
+		}
+		m := NewM64(r, c, floats(b))
+		mr, mc := m.Dims()
+		if mr < 1 || mc < 1 {
+			t.Fatalf("invalid dims %dx%d", mr, mc)
+		}
+		if m.Size() != len(m.data) {
+			t.Fatalf("size %d != len(data) %d", m.Size(), len(m.data))
+		}
+		if !m.Valid() {
+			t.Fatalf("new matrix is not valid")
+		}
+	})
+}
+
+func FuzzInnerOps(f *testing.F) {
+	f.Add(int64(1), uint8(3), uint8(3), uint8(3))
+	f.Add(int64(2), uint8(1), uint8(7), uint8(2))
+	f.Fuzz(func(t *testing.T, seed int64, r, k, c uint8) {
+		src := rand.NewSource(seed)
+		m := NewNormal(int(r%16), int(k%16), src, 0, 1)
+		mr, mk := m.Dims()
+		n := NewNormal(mr, mk, src, 0, 1)
+		p := NewNormal(mk, int(c%16), src, 0, 1)
+		_, pc := p.Dims()
+
+		dest := NewM64(mr, mk, nil)
+		if err := add(m, n, dest); err != nil {
+			t.Fatalf("add: %s", err.Error())
+		}
+		if err := sub(dest, n, dest); err != nil {
+			t.Fatalf("sub: %s", err.Error())
+		}
+		if !EqualApprox(m, dest, 1e-12) {
+			t.Fatalf("(m+n)-n != m")
+		}
+
+		mn := NewM64(mr, mk, nil)
+		nm := NewM64(mr, mk, nil)
+		if err := mulElem(m, n, mn); err != nil {
+			t.Fatalf("mulElem: %s", err.Error())
+		}
+		if err := mapElem(n, m, nm, func(x, y float64) float64 { return x * y }); err != nil {
+			t.Fatalf("mapElem: %s", err.Error())
+		}
+		if !EqualApprox(mn, nm, 0) {
+			t.Fatalf("m.*n != n.*m")
+		}
+
+		mp := NewM64(mr, pc, nil)
+		if err := mul(m, p, mp); err != nil {
+			t.Fatalf("mul: %s", err.Error())
+		}
+		mt := NewM64(mk, mr, nil)
+		pt := NewM64(pc, mk, nil)
+		transpose(m, mt)
+		transpose(p, pt)
+		ptmt := NewM64(pc, mr, nil)
+		if err := mul(pt, mt, ptmt); err != nil {
+			t.Fatalf("mul: %s", err.Error())
+		}
+		mpt := NewM64(pc, mr, nil)
+		transpose(mp, mpt)
+		if !EqualApprox(mpt, ptmt, 1e-12) {
+			t.Fatalf("(mp)ᵀ != pᵀmᵀ")
+		}
+	})
+}
@@ -1,12 +1,22 @@
 package mat
 
+import "github.com/twiggg/math/mat64/internal/kernel"
+
+//shares returns true if m and n use the same data
+func shares(m, n *M64) bool {
+	return len(m.data) > 0 && len(n.data) > 0 && &m.data[0] == &n.data[0]
+}
+
 func add(m, n, dest *M64) error {
 	if err := sameSize(m, n, dest); err != nil {
 		return err
 	}
-	for i := range m.data {
-		dest.data[i] = m.data[i] + n.data[i]
+	if shares(n, dest) {
+		kernel.Daxpy(1, m.data, dest.data)
+		return nil
 	}
+	copy(dest.data, m.data)
+	kernel.Daxpy(1, n.data, dest.data)
 	return nil
 }
 
@@ -14,9 +24,17 @@ func sub(m, n, dest *M64) error {
 	if err := sameSize(m, n, dest); err != nil {
 		return err
 	}
-	for i := range m.data {
-		dest.data[i] = m.data[i] - n.data[i]
+	if shares(m, n) {
+		kernel.Dscal(0, dest.data)
+		return nil
 	}
+	if shares(n, dest) {
+		kernel.Dscal(-1, dest.data)
+		kernel.Daxpy(1, m.data, dest.data)
+		return nil
+	}
+	copy(dest.data, m.data)
+	kernel.Daxpy(-1, n.data, dest.data)
 	return nil
 }
 
@@ -24,17 +42,13 @@ func mul(m, n, dest *M64) error {
 	if err := dotSize(m, n, dest); err != nil {
 		return err
 	}
-	sum := 0.0
-	i, j, k := 0, 0, 0
-	for i = 0; i < m.r; i++ {
-		for j = 0; j < n.c; j++ {
-			sum = 0.0
-			for k = 0; k < m.c; k++ {
-				sum += m.At(i, k) * n.At(k, j)
-			}
-			dest.Set(i, j, sum)
-		}
+	//the product can not be written over one of its operands
+	out := dest.data
+	if shares(m, dest) || shares(n, dest) {
+		out = make([]float64, len(dest.data))
 	}
+	kernel.Dgemm(false, false, m.r, n.c, m.c, 1, m.data, m.c, n.data, n.c, 0, out, dest.c)
+	copy(dest.data, out)
 	return nil
 }
 
@@ -43,12 +57,7 @@ func mulElem(m, n, dest *M64) error {
 	if err := sameSize(m, n, dest); err != nil {
 		return err
 	}
-	i, j := 0, 0
-	for i = 0; i < m.r; i++ {
-		for j = 0; j < n.c; j++ {
-			dest.Set(i, j, m.At(i, j)*n.At(i, j))
-		}
-	}
+	kernel.Dmul(m.data, n.data, dest.data)
 	return nil
 }
 
@@ -85,3 +94,21 @@ func mapElemVal(m, dest *M64, fn func(val float64) float64) error {
 	}
 	return nil
 }
+
+//transpose sets dest to the transpose of m. m and dest must not share data
+func transpose(m, dest *M64) error {
+	if err := transposeSize(m, dest); err != nil {
+		return err
+	}
+	kernel.Dtrans(m.r, m.c, m.data, m.c, dest.data, m.r)
+	return nil
+}
+
+//element wise division
+func divElem(m, n, dest *M64) error {
+	if err := sameSize(m, n, dest); err != nil {
+		return err
+	}
+	kernel.Ddiv(m.data, n.data, dest.data)
+	return nil
+}
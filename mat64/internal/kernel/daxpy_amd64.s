@@ -0,0 +1,48 @@
+//go:build !noasm
+// +build !noasm
+
+#include "textflag.h"
+
+// func daxpyAsm(alpha float64, x, y []float64)
+TEXT ·daxpyAsm(SB), NOSPLIT, $0-56
+	MOVSD    alpha+0(FP), X0
+	MOVQ     x_base+8(FP), SI
+	MOVQ     x_len+16(FP), CX
+	MOVQ     y_base+32(FP), DI
+	UNPCKLPD X0, X0
+	MOVQ     CX, BX
+	SHRQ     $2, BX
+	JZ       rest
+
+loop:
+	MOVUPD (SI), X2
+	MOVUPD 16(SI), X3
+	MULPD  X0, X2
+	MULPD  X0, X3
+	MOVUPD (DI), X4
+	MOVUPD 16(DI), X5
+	ADDPD  X4, X2
+	ADDPD  X5, X3
+	MOVUPD X2, (DI)
+	MOVUPD X3, 16(DI)
+	ADDQ   $32, SI
+	ADDQ   $32, DI
+	DECQ   BX
+	JNZ    loop
+
+rest:
+	ANDQ $3, CX
+	JZ   done
+
+tail:
+	MOVSD (SI), X2
+	MULSD X0, X2
+	ADDSD (DI), X2
+	MOVSD X2, (DI)
+	ADDQ  $8, SI
+	ADDQ  $8, DI
+	DECQ  CX
+	JNZ   tail
+
+done:
+	RET
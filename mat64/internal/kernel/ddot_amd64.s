@@ -0,0 +1,50 @@
+//go:build !noasm
+// +build !noasm
+
+#include "textflag.h"
+
+// func ddotAsm(x, y []float64) float64
+TEXT ·ddotAsm(SB), NOSPLIT, $0-56
+	MOVQ  x_base+0(FP), SI
+	MOVQ  x_len+8(FP), CX
+	MOVQ  y_base+24(FP), DI
+	XORPS X0, X0
+	XORPS X1, X1
+	MOVQ  CX, BX
+	SHRQ  $2, BX
+	JZ    reduce
+
+loop:
+	MOVUPD (SI), X2
+	MOVUPD 16(SI), X3
+	MOVUPD (DI), X4
+	MOVUPD 16(DI), X5
+	MULPD  X4, X2
+	MULPD  X5, X3
+	ADDPD  X2, X0
+	ADDPD  X3, X1
+	ADDQ   $32, SI
+	ADDQ   $32, DI
+	DECQ   BX
+	JNZ    loop
+
+reduce:
+	ADDPD    X1, X0
+	MOVAPD   X0, X1
+	UNPCKHPD X1, X1
+	ADDSD    X1, X0
+	ANDQ     $3, CX
+	JZ       done
+
+tail:
+	MOVSD (SI), X2
+	MULSD (DI), X2
+	ADDSD X2, X0
+	ADDQ  $8, SI
+	ADDQ  $8, DI
+	DECQ  CX
+	JNZ   tail
+
+done:
+	MOVSD X0, ret+48(FP)
+	RET
@@ -0,0 +1,6 @@
+//Package kernel provides the BLAS level 1 to 3 routines mat64 is built on, for row major dense storage.
+//
+//The level 1 routines Ddot and Daxpy carry the inner loops of all the others. They are written in Go assembly on amd64, and as loop unrolled pure Go elsewhere.
+//Dmul, Ddiv and Dtrans cover the element wise products and the transpose, which have no BLAS counterpart.
+//The noasm build tag forces the pure Go version on every platform.
+package kernel
@@ -0,0 +1,62 @@
+package kernel
+
+//Dmul sets z to the element wise product of x and y. panics if y or z is shorter than x
+func Dmul(x, y, z []float64) {
+	if len(y) < len(x) || len(z) < len(x) {
+		panic("kernel: y or z is shorter than x")
+	}
+	n := len(x) &^ 3
+	y, z = y[:len(x)], z[:len(x)]
+	for i := 0; i < n; i += 4 {
+		z[i] = x[i] * y[i]
+		z[i+1] = x[i+1] * y[i+1]
+		z[i+2] = x[i+2] * y[i+2]
+		z[i+3] = x[i+3] * y[i+3]
+	}
+	for i := n; i < len(x); i++ {
+		z[i] = x[i] * y[i]
+	}
+}
+
+//Ddiv sets z to the element wise quotient of x by y. panics if y or z is shorter than x
+func Ddiv(x, y, z []float64) {
+	if len(y) < len(x) || len(z) < len(x) {
+		panic("kernel: y or z is shorter than x")
+	}
+	n := len(x) &^ 3
+	y, z = y[:len(x)], z[:len(x)]
+	for i := 0; i < n; i += 4 {
+		z[i] = x[i] / y[i]
+		z[i+1] = x[i+1] / y[i+1]
+		z[i+2] = x[i+2] / y[i+2]
+		z[i+3] = x[i+3] / y[i+3]
+	}
+	for i := n; i < len(x); i++ {
+		z[i] = x[i] / y[i]
+	}
+}
+
+//transBlock is the tile size of Dtrans, small enough for a tile of a and b to stay in cache
+const transBlock = 32
+
+//Dtrans sets the n*m row major matrix b to the transpose of the m*n row major matrix a, tile by tile. a and b must not overlap
+func Dtrans(m, n int, a []float64, lda int, b []float64, ldb int) {
+	for i0 := 0; i0 < m; i0 += transBlock {
+		i1 := i0 + transBlock
+		if i1 > m {
+			i1 = m
+		}
+		for j0 := 0; j0 < n; j0 += transBlock {
+			j1 := j0 + transBlock
+			if j1 > n {
+				j1 = n
+			}
+			for i := i0; i < i1; i++ {
+				row := a[i*lda : i*lda+j1]
+				for j := j0; j < j1; j++ {
+					b[j*ldb+i] = row[j]
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package kernel
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randSlice(rnd *rand.Rand, n int) []float64 {
+	res := make([]float64, n)
+	for i := range res {
+		res[i] = rnd.NormFloat64()
+	}
+	return res
+}
+
+func near(x, y, tol float64) bool {
+	return math.Abs(x-y) <= tol*math.Max(1, math.Max(math.Abs(x), math.Abs(y)))
+}
+
+func TestLevel1(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 40; n++ {
+		x, y := randSlice(rnd, n), randSlice(rnd, n+3)
+		exp := 0.0
+		for i := range x {
+			exp += x[i] * y[i]
+		}
+		if got := Ddot(x, y); !near(exp, got, 1e-13) {
+			t.Errorf("n=%d: ddot expected %v, got %v", n, exp, got)
+		}
+		if got := ddotGo(x, y); !near(exp, got, 1e-13) {
+			t.Errorf("n=%d: ddotGo expected %v, got %v", n, exp, got)
+		}
+		y1 := append([]float64(nil), y...)
+		y2 := append([]float64(nil), y...)
+		Daxpy(2.5, x, y1)
+		daxpyGo(2.5, x, y2)
+		for i := range y {
+			exp := y[i]
+			if i < n {
+				exp += 2.5 * x[i]
+			}
+			if y1[i] != exp || y2[i] != exp {
+				t.Errorf("n=%d: daxpy[%d] expected %v, got %v and %v", n, i, exp, y1[i], y2[i])
+			}
+		}
+		if got, exp := Dnrm2(x), math.Sqrt(ddotGo(x, x)); !near(exp, got, 1e-14) {
+			t.Errorf("n=%d: dnrm2 expected %v, got %v", n, exp, got)
+		}
+	}
+	if got := Dnrm2([]float64{3e300, 4e300}); !near(5e300, got, 1e-15) {
+		t.Errorf("dnrm2 overflow: got %v", got)
+	}
+	x := []float64{1, 2, 3, 4, 5}
+	Dscal(-2, x)
+	if x[4] != -10 {
+		t.Errorf("dscal: got %v", x)
+	}
+}
+
+//naiveGemm returns op(A)*op(B) with A,B,C contiguous
+func naiveGemm(transA, transB bool, m, n, k int, a, b []float64) []float64 {
+	c := make([]float64, m*n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			for l := 0; l < k; l++ {
+				av, bv := a[i*k+l], b[l*n+j]
+				if transA {
+					av = a[l*m+i]
+				}
+				if transB {
+					bv = b[j*k+l]
+				}
+				c[i*n+j] += av * bv
+			}
+		}
+	}
+	return c
+}
+
+func TestDgemm(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	for _, tr := range [][2]bool{{false, false}, {true, false}, {false, true}, {true, true}} {
+		m, n, k := 7, 5, 130
+		a, b := randSlice(rnd, m*k), randSlice(rnd, k*n)
+		lda, ldb := k, n
+		if tr[0] {
+			lda = m
+		}
+		if tr[1] {
+			ldb = k
+		}
+		exp := naiveGemm(tr[0], tr[1], m, n, k, a, b)
+		c := randSlice(rnd, m*n)
+		c0 := append([]float64(nil), c...)
+		Dgemm(tr[0], tr[1], m, n, k, 2, a, lda, b, ldb, 0.5, c, n)
+		for i := range c {
+			if !near(2*exp[i]+0.5*c0[i], c[i], 1e-12) {
+				t.Errorf("trans %v: c[%d] expected %v, got %v", tr, i, 2*exp[i]+0.5*c0[i], c[i])
+			}
+		}
+	}
+}
+
+func TestDgemv(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	m, n := 6, 9
+	a, x := randSlice(rnd, m*n), randSlice(rnd, n)
+	exp := naiveGemm(false, false, m, 1, n, a, x)
+	y := make([]float64, m)
+	Dgemv(false, m, n, 1, a, n, x, 0, y)
+	xt := randSlice(rnd, m)
+	expT := naiveGemm(true, false, n, 1, m, a, xt)
+	yt := make([]float64, n)
+	Dgemv(true, m, n, 1, a, n, xt, 0, yt)
+	yg := make([]float64, m)
+	Dgemm(false, false, m, 1, n, 1, a, n, x, 1, 0, yg, 1)
+	for i := range y {
+		if !near(exp[i], yg[i], 1e-13) {
+			t.Errorf("gemm y[%d] expected %v, got %v", i, exp[i], yg[i])
+		}
+		if !near(exp[i], y[i], 1e-13) {
+			t.Errorf("y[%d] expected %v, got %v", i, exp[i], y[i])
+		}
+	}
+	for i := range yt {
+		if !near(expT[i], yt[i], 1e-13) {
+			t.Errorf("yᵀ[%d] expected %v, got %v", i, expT[i], yt[i])
+		}
+	}
+}
+
+func TestDtrsm(t *testing.T) {
+	rnd := rand.New(rand.NewSource(4))
+	m, n := 6, 3
+	for _, upper := range []bool{true, false} {
+		a := randSlice(rnd, m*m)
+		for i := 0; i < m; i++ {
+			a[i*m+i] += 4
+			for j := 0; j < m; j++ {
+				if upper && j < i || !upper && j > i {
+					a[i*m+j] = 0
+				}
+			}
+		}
+		x := randSlice(rnd, m*n)
+		b := naiveGemm(false, false, m, n, m, a, x)
+		bp := append([]float64(nil), b...)
+		Dtrsm(upper, false, m, n, 1, a, m, b, n)
+		for i := range b {
+			if !near(x[i], b[i], 1e-12) {
+				t.Errorf("upper %v: x[%d] expected %v, got %v", upper, i, x[i], b[i])
+			}
+		}
+		var ap []float64
+		for i := 0; i < m; i++ {
+			for j := 0; j < m; j++ {
+				if upper && j >= i || !upper && j <= i {
+					ap = append(ap, a[i*m+j])
+				}
+			}
+		}
+		Dtpsm(upper, m, n, ap, bp, n)
+		for i := range bp {
+			if !near(x[i], bp[i], 1e-12) {
+				t.Errorf("packed upper %v: x[%d] expected %v, got %v", upper, i, x[i], bp[i])
+			}
+		}
+	}
+}
+
+func TestElementwise(t *testing.T) {
+	rnd := rand.New(rand.NewSource(5))
+	for n := 0; n < 11; n++ {
+		x, y := randSlice(rnd, n), randSlice(rnd, n+2)
+		z, q := make([]float64, n), make([]float64, n)
+		Dmul(x, y, z)
+		Ddiv(x, y, q)
+		for i := range x {
+			if z[i] != x[i]*y[i] || q[i] != x[i]/y[i] {
+				t.Errorf("n=%d: [%d] expected %v and %v, got %v and %v", n, i, x[i]*y[i], x[i]/y[i], z[i], q[i])
+			}
+		}
+		//writing over an operand
+		x0 := append([]float64(nil), x...)
+		Dmul(x, x, x)
+		for i := range x {
+			if x[i] != x0[i]*x0[i] {
+				t.Errorf("n=%d: in place [%d] expected %v, got %v", n, i, x0[i]*x0[i], x[i])
+			}
+		}
+	}
+	for _, dims := range [][2]int{{1, 1}, {3, 70}, {33, 65}} {
+		m, n := dims[0], dims[1]
+		a, b := randSlice(rnd, m*n), make([]float64, m*n)
+		Dtrans(m, n, a, n, b, m)
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				if b[j*m+i] != a[i*n+j] {
+					t.Errorf("%dx%d: transpose [%d,%d] expected %v, got %v", m, n, i, j, a[i*n+j], b[j*m+i])
+				}
+			}
+		}
+	}
+}
+
+func TestDger(t *testing.T) {
+	rnd := rand.New(rand.NewSource(6))
+	m, n := 5, 7
+	x, y, a := randSlice(rnd, m), randSlice(rnd, n), randSlice(rnd, m*n)
+	exp := naiveGemm(false, false, m, n, 1, x, y)
+	a0 := append([]float64(nil), a...)
+	Dger(m, n, 2, x, y, a, n)
+	for i := range a {
+		if !near(2*exp[i]+a0[i], a[i], 1e-13) {
+			t.Errorf("a[%d] expected %v, got %v", i, 2*exp[i]+a0[i], a[i])
+		}
+	}
+}
+
+func BenchmarkDdot(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	x, y := randSlice(rnd, 1000), randSlice(rnd, 1000)
+	for k := 0; k < b.N; k++ {
+		Ddot(x, y)
+	}
+}
+
+func BenchmarkDdotGo(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	x, y := randSlice(rnd, 1000), randSlice(rnd, 1000)
+	for k := 0; k < b.N; k++ {
+		ddotGo(x, y)
+	}
+}
@@ -0,0 +1,101 @@
+package kernel
+
+import "math"
+
+//Ddot returns the dot product of x and y. panics if y is shorter than x
+func Ddot(x, y []float64) float64 {
+	if len(y) < len(x) {
+		panic("kernel: y is shorter than x")
+	}
+	if len(x) == 0 {
+		return 0
+	}
+	return ddot(x, y)
+}
+
+//Daxpy sets y to alpha*x+y. panics if y is shorter than x
+func Daxpy(alpha float64, x, y []float64) {
+	if len(y) < len(x) {
+		panic("kernel: y is shorter than x")
+	}
+	if len(x) == 0 || alpha == 0 {
+		return
+	}
+	daxpy(alpha, x, y)
+}
+
+//Dscal sets x to alpha*x
+func Dscal(alpha float64, x []float64) {
+	if alpha == 0 {
+		for i := range x {
+			x[i] = 0
+		}
+		return
+	}
+	n := len(x) &^ 3
+	for i := 0; i < n; i += 4 {
+		x[i] *= alpha
+		x[i+1] *= alpha
+		x[i+2] *= alpha
+		x[i+3] *= alpha
+	}
+	for i := n; i < len(x); i++ {
+		x[i] *= alpha
+	}
+}
+
+//Dnrm2 returns the euclidean norm of x, scaling the sum of squares to avoid overflow and underflow
+func Dnrm2(x []float64) float64 {
+	scale, ssq := 0.0, 1.0
+	for _, v := range x {
+		if v == 0 {
+			continue
+		}
+		if math.IsNaN(v) {
+			return math.NaN()
+		}
+		a := math.Abs(v)
+		if scale < a {
+			ssq = 1 + ssq*(scale/a)*(scale/a)
+			scale = a
+		} else {
+			ssq += (a / scale) * (a / scale)
+		}
+	}
+	if math.IsInf(scale, 1) {
+		return scale
+	}
+	return scale * math.Sqrt(ssq)
+}
+
+//ddotGo is the portable version of ddot, unrolled by 4 with independent accumulators
+func ddotGo(x, y []float64) float64 {
+	var s0, s1, s2, s3 float64
+	n := len(x) &^ 3
+	y = y[:len(x)]
+	for i := 0; i < n; i += 4 {
+		s0 += x[i] * y[i]
+		s1 += x[i+1] * y[i+1]
+		s2 += x[i+2] * y[i+2]
+		s3 += x[i+3] * y[i+3]
+	}
+	for i := n; i < len(x); i++ {
+		s0 += x[i] * y[i]
+	}
+	return (s0 + s2) + (s1 + s3)
+}
+
+//daxpyGo is the portable version of daxpy, unrolled by 4
+func daxpyGo(alpha float64, x, y []float64) {
+	n := len(x) &^ 3
+	y = y[:len(x)]
+	for i := 0; i < n; i += 4 {
+		y[i] += alpha * x[i]
+		y[i+1] += alpha * x[i+1]
+		y[i+2] += alpha * x[i+2]
+		y[i+3] += alpha * x[i+3]
+	}
+	for i := n; i < len(x); i++ {
+		y[i] += alpha * x[i]
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !noasm
+// +build !noasm
+
+package kernel
+
+//ddotAsm is implemented in ddot_amd64.s
+func ddotAsm(x, y []float64) float64
+
+//daxpyAsm is implemented in daxpy_amd64.s
+func daxpyAsm(alpha float64, x, y []float64)
+
+var (
+	ddot  = ddotAsm
+	daxpy = daxpyAsm
+)
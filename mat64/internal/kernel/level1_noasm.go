@@ -0,0 +1,9 @@
+//go:build !amd64 || noasm
+// +build !amd64 noasm
+
+package kernel
+
+var (
+	ddot  = ddotGo
+	daxpy = daxpyGo
+)
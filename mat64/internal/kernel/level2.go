@@ -0,0 +1,35 @@
+package kernel
+
+//Dgemv sets y to alpha*op(A)*x+beta*y, where A is a m*n row major matrix with leading dimension lda, and op(A) is A or Aᵀ if trans
+func Dgemv(trans bool, m, n int, alpha float64, a []float64, lda int, x []float64, beta float64, y []float64) {
+	ylen := m
+	if trans {
+		ylen = n
+	}
+	if beta != 1 {
+		Dscal(beta, y[:ylen])
+	}
+	if alpha == 0 {
+		return
+	}
+	if trans {
+		for i := 0; i < m; i++ {
+			Daxpy(alpha*x[i], a[i*lda:i*lda+n], y)
+		}
+		return
+	}
+	for i := 0; i < m; i++ {
+		y[i] += alpha * Ddot(a[i*lda:i*lda+n], x)
+	}
+}
+
+//Dger sets the m*n row major matrix A to alpha*x*yᵀ+A
+func Dger(m, n int, alpha float64, x, y []float64, a []float64, lda int) {
+	if alpha == 0 {
+		return
+	}
+	y = y[:n]
+	for i := 0; i < m; i++ {
+		Daxpy(alpha*x[i], y, a[i*lda:i*lda+n])
+	}
+}
@@ -0,0 +1,119 @@
+package kernel
+
+//Dgemm sets C to alpha*op(A)*op(B)+beta*C, where op(A) is m*k, op(B) is k*n and C is m*n. All matrices are row major with leading dimensions lda, ldb, ldc.
+//op(X) is X or Xᵀ if transX. C must not share memory with A or B
+func Dgemm(transA, transB bool, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	for i := 0; i < m; i++ {
+		if beta != 1 {
+			Dscal(beta, c[i*ldc:i*ldc+n])
+		}
+	}
+	if alpha == 0 {
+		return
+	}
+	at := func(i, l int) float64 {
+		if transA {
+			return a[l*lda+i]
+		}
+		return a[i*lda+l]
+	}
+	if transB {
+		//rows of C are dot products with the rows of B
+		if !transA {
+			for i := 0; i < m; i++ {
+				ai := a[i*lda : i*lda+k]
+				ci := c[i*ldc : i*ldc+n]
+				for j := range ci {
+					ci[j] += alpha * Ddot(ai, b[j*ldb:j*ldb+k])
+				}
+			}
+			return
+		}
+		for i := 0; i < m; i++ {
+			ci := c[i*ldc : i*ldc+n]
+			for j := range ci {
+				sum := 0.0
+				for l := 0; l < k; l++ {
+					sum += at(i, l) * b[j*ldb+l]
+				}
+				ci[j] += alpha * sum
+			}
+		}
+		return
+	}
+	if n == 1 && ldb == 1 && !transA {
+		//matrix vector product
+		for i := 0; i < m; i++ {
+			c[i*ldc] += alpha * Ddot(a[i*lda:i*lda+k], b[:k])
+		}
+		return
+	}
+	//rows of C are combinations of the rows of B, blocked on k to keep them in cache
+	const block = 64
+	for l0 := 0; l0 < k; l0 += block {
+		l1 := l0 + block
+		if l1 > k {
+			l1 = k
+		}
+		for i := 0; i < m; i++ {
+			ci := c[i*ldc : i*ldc+n]
+			for l := l0; l < l1; l++ {
+				Daxpy(alpha*at(i, l), b[l*ldb:l*ldb+n], ci)
+			}
+		}
+	}
+}
+
+//Dtrsm solves A*X=alpha*B, where A is a m*m upper or lower triangular matrix and B is m*n. X overwrites B.
+//If unit, the diagonal of A is taken as ones and not read. All matrices are row major with leading dimensions lda, ldb
+func Dtrsm(upper, unit bool, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	if alpha != 1 {
+		for i := 0; i < m; i++ {
+			Dscal(alpha, b[i*ldb:i*ldb+n])
+		}
+	}
+	if upper {
+		for i := m - 1; i >= 0; i-- {
+			bi := b[i*ldb : i*ldb+n]
+			for l := i + 1; l < m; l++ {
+				Daxpy(-a[i*lda+l], b[l*ldb:l*ldb+n], bi)
+			}
+			if !unit {
+				Dscal(1/a[i*lda+i], bi)
+			}
+		}
+		return
+	}
+	for i := 0; i < m; i++ {
+		bi := b[i*ldb : i*ldb+n]
+		for l := 0; l < i; l++ {
+			Daxpy(-a[i*lda+l], b[l*ldb:l*ldb+n], bi)
+		}
+		if !unit {
+			Dscal(1/a[i*lda+i], bi)
+		}
+	}
+}
+
+//Dtpsm solves A*X=B, where A is a m*m upper or lower triangular matrix packed row by row (the n(n+1)/2 values of its triangle) and B is m*n. X overwrites B
+func Dtpsm(upper bool, m, n int, ap []float64, b []float64, ldb int) {
+	if upper {
+		for i := m - 1; i >= 0; i-- {
+			row := ap[i*m-i*(i-1)/2:]
+			bi := b[i*ldb : i*ldb+n]
+			for l := i + 1; l < m; l++ {
+				Daxpy(-row[l-i], b[l*ldb:l*ldb+n], bi)
+			}
+			Dscal(1/row[0], bi)
+		}
+		return
+	}
+	for i := 0; i < m; i++ {
+		row := ap[i*(i+1)/2:]
+		bi := b[i*ldb : i*ldb+n]
+		for l := 0; l < i; l++ {
+			Daxpy(-row[l], b[l*ldb:l*ldb+n], bi)
+		}
+		Dscal(1/row[i], bi)
+	}
+}
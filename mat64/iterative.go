@@ -0,0 +1,348 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/twiggg/math/mat64/internal/kernel"
+)
+
+//LinearOperator is anything able to apply a linear map to a vector: M64, CSR or a matrix-free function
+type LinearOperator interface {
+	Dims() (int, int)
+	MulVec(dst, x []float64)
+}
+
+//MulVec sets dst to m*x
+func (m *M64) MulVec(dst, x []float64) {
+	kernel.Dgemv(false, m.r, m.c, 1, m.data, m.c, x, 0, dst)
+}
+
+type funcOperator struct {
+	n  int
+	fn func(dst, x []float64)
+}
+
+func (f *funcOperator) Dims() (int, int) {
+	return f.n, f.n
+}
+
+func (f *funcOperator) MulVec(dst, x []float64) {
+	f.fn(dst, x)
+}
+
+//NewOperator returns a matrix-free n*n LinearOperator: fn must set dst to A*x
+func NewOperator(n int, fn func(dst, x []float64)) LinearOperator {
+	return &funcOperator{n: n, fn: fn}
+}
+
+//IterSettings holds the parameters of an iterative solver. Zero values are replaced by defaults
+type IterSettings struct {
+	//Tol is the target relative residual ||b-Ax||/||b||, 1e-10 by default
+	Tol float64
+	//MaxIter is the maximum number of iterations, 10*n by default
+	MaxIter int
+	//Restart is the size of the Krylov subspace of GMRES before restarting, min(n,30) by default
+	Restart int
+	//Precond is an optional preconditioner
+	Precond Preconditioner
+	//X0 is an optional initial guess (n*1)
+	X0 *M64
+}
+
+//IterResult holds the outcome of an iterative solver
+type IterResult struct {
+	//X is the solution (n*1)
+	X *M64
+	//Iterations is the number of iterations done
+	Iterations int
+	//Residuals holds the relative residual after each iteration, starting with the initial guess
+	Residuals []float64
+	//Converged is true if the relative residual reached the tolerance
+	Converged bool
+}
+
+//iterState holds the data shared by all iterative solvers
+type iterState struct {
+	a       LinearOperator
+	n       int
+	b       []float64
+	x       []float64
+	bnorm   float64
+	tol     float64
+	maxIter int
+	restart int
+	precond Preconditioner
+	res     *IterResult
+}
+
+type identityPrecond struct{}
+
+func (identityPrecond) Apply(dst, r []float64) {
+	copy(dst, r)
+}
+
+func newIterState(a LinearOperator, b *M64, s *IterSettings) (*iterState, error) {
+	if a == nil {
+		return nil, fmt.Errorf("a is nil")
+	}
+	n, c := a.Dims()
+	if n != c {
+		return nil, fmt.Errorf("a is not square")
+	}
+	if !b.Valid() {
+		return nil, fmt.Errorf("b is nil")
+	}
+	if b.r != n || b.c != 1 {
+		return nil, fmt.Errorf("b should be a %dx1 vector", n)
+	}
+	if s == nil {
+		s = &IterSettings{}
+	}
+	st := &iterState{a: a, n: n, b: b.data, x: make([]float64, n), tol: s.Tol, maxIter: s.MaxIter, restart: s.Restart, precond: s.Precond}
+	if st.tol <= 0 {
+		st.tol = 1e-10
+	}
+	if st.maxIter <= 0 {
+		st.maxIter = 10 * n
+	}
+	if st.restart <= 0 {
+		st.restart = 30
+	}
+	if st.restart > n {
+		st.restart = n
+	}
+	if st.precond == nil {
+		st.precond = identityPrecond{}
+	}
+	if s.X0 != nil {
+		if !s.X0.Valid() || s.X0.r != n || s.X0.c != 1 {
+			return nil, fmt.Errorf("x0 should be a %dx1 vector", n)
+		}
+		copy(st.x, s.X0.data)
+	}
+	st.bnorm = kernel.Dnrm2(st.b)
+	if st.bnorm == 0 {
+		st.bnorm = 1
+	}
+	st.res = &IterResult{X: NewM64(n, 1, st.x)}
+	return st, nil
+}
+
+//residual sets r to b-Ax and returns its relative norm
+func (st *iterState) residual(r []float64) float64 {
+	st.a.MulVec(r, st.x)
+	for i := range r {
+		r[i] = st.b[i] - r[i]
+	}
+	return kernel.Dnrm2(r) / st.bnorm
+}
+
+//record appends the relative residual rel and returns true if converged
+func (st *iterState) record(rel float64) bool {
+	st.res.Residuals = append(st.res.Residuals, rel)
+	st.res.Converged = rel <= st.tol
+	return st.res.Converged
+}
+
+//CG solves a*x=b with the preconditioned Conjugate Gradient method. a and the preconditioner must be symmetric positive definite
+func CG(a LinearOperator, b *M64, s *IterSettings) (*IterResult, error) {
+	st, err := newIterState(a, b, s)
+	if err != nil {
+		return nil, err
+	}
+	n := st.n
+	r, z, p, ap := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	if st.record(st.residual(r)) {
+		return st.res, nil
+	}
+	st.precond.Apply(z, r)
+	copy(p, z)
+	rz := kernel.Ddot(r, z)
+	for it := 1; it <= st.maxIter; it++ {
+		st.res.Iterations = it
+		a.MulVec(ap, p)
+		pap := kernel.Ddot(p, ap)
+		if pap == 0 {
+			return st.res, fmt.Errorf("breakdown at iteration %d", it)
+		}
+		alpha := rz / pap
+		kernel.Daxpy(alpha, p, st.x)
+		kernel.Daxpy(-alpha, ap, r)
+		if st.record(kernel.Dnrm2(r) / st.bnorm) {
+			break
+		}
+		st.precond.Apply(z, r)
+		rzNew := kernel.Ddot(r, z)
+		beta := rzNew / rz
+		rz = rzNew
+		for i := range p {
+			p[i] = z[i] + beta*p[i]
+		}
+	}
+	return st.res, nil
+}
+
+//BiCGSTAB solves a*x=b with the right preconditioned Bi-Conjugate Gradient Stabilized method, for general square a
+func BiCGSTAB(a LinearOperator, b *M64, s *IterSettings) (*IterResult, error) {
+	st, err := newIterState(a, b, s)
+	if err != nil {
+		return nil, err
+	}
+	n := st.n
+	r, r0, p, v := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	ph, sv, sh, t := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	if st.record(st.residual(r)) {
+		return st.res, nil
+	}
+	copy(r0, r)
+	rho, alpha, omega := 1.0, 1.0, 1.0
+	for it := 1; it <= st.maxIter; it++ {
+		st.res.Iterations = it
+		rhoNew := kernel.Ddot(r0, r)
+		if rhoNew == 0 {
+			return st.res, fmt.Errorf("breakdown at iteration %d", it)
+		}
+		if it == 1 {
+			copy(p, r)
+		} else {
+			beta := (rhoNew / rho) * (alpha / omega)
+			for i := range p {
+				p[i] = r[i] + beta*(p[i]-omega*v[i])
+			}
+		}
+		rho = rhoNew
+		st.precond.Apply(ph, p)
+		a.MulVec(v, ph)
+		r0v := kernel.Ddot(r0, v)
+		if r0v == 0 {
+			return st.res, fmt.Errorf("breakdown at iteration %d", it)
+		}
+		alpha = rho / r0v
+		for i := range sv {
+			sv[i] = r[i] - alpha*v[i]
+		}
+		if rel := kernel.Dnrm2(sv) / st.bnorm; rel <= st.tol {
+			for i := range st.x {
+				st.x[i] += alpha * ph[i]
+			}
+			st.record(rel)
+			break
+		}
+		st.precond.Apply(sh, sv)
+		a.MulVec(t, sh)
+		tt := kernel.Ddot(t, t)
+		if tt == 0 {
+			return st.res, fmt.Errorf("breakdown at iteration %d", it)
+		}
+		omega = kernel.Ddot(t, sv) / tt
+		for i := range st.x {
+			st.x[i] += alpha*ph[i] + omega*sh[i]
+			r[i] = sv[i] - omega*t[i]
+		}
+		if st.record(kernel.Dnrm2(r) / st.bnorm) {
+			break
+		}
+		if omega == 0 {
+			return st.res, fmt.Errorf("breakdown at iteration %d", it)
+		}
+	}
+	return st.res, nil
+}
+
+//GMRES solves a*x=b with the right preconditioned restarted Generalized Minimal Residual method, for general square a. Iterations counts the inner steps of all the restart cycles
+func GMRES(a LinearOperator, b *M64, s *IterSettings) (*IterResult, error) {
+	st, err := newIterState(a, b, s)
+	if err != nil {
+		return nil, err
+	}
+	n, m := st.n, st.restart
+	r, w, z := make([]float64, n), make([]float64, n), make([]float64, n)
+	//krylov basis, hessenberg matrix (stored by colomns) and givens rotations
+	v := make([][]float64, m+1)
+	for i := range v {
+		v[i] = make([]float64, n)
+	}
+	h := make([][]float64, m)
+	for i := range h {
+		h[i] = make([]float64, m+1)
+	}
+	cs, sn, g, y := make([]float64, m), make([]float64, m), make([]float64, m+1), make([]float64, m)
+	rel := st.residual(r)
+	if st.record(rel) {
+		return st.res, nil
+	}
+	it := 0
+	for it < st.maxIter {
+		beta := kernel.Dnrm2(r)
+		for i := range r {
+			v[0][i] = r[i] / beta
+		}
+		for i := range g {
+			g[i] = 0
+		}
+		g[0] = beta
+		k := 0
+		for k < m && it < st.maxIter {
+			it++
+			st.res.Iterations = it
+			st.precond.Apply(z, v[k])
+			a.MulVec(w, z)
+			//modified Gram-Schmidt
+			for i := 0; i <= k; i++ {
+				h[k][i] = kernel.Ddot(w, v[i])
+				kernel.Daxpy(-h[k][i], v[i], w)
+			}
+			h[k][k+1] = kernel.Dnrm2(w)
+			if h[k][k+1] != 0 {
+				for l := range w {
+					v[k+1][l] = w[l] / h[k][k+1]
+				}
+			}
+			//apply previous rotations then compute the new one
+			for i := 0; i < k; i++ {
+				tmp := cs[i]*h[k][i] + sn[i]*h[k][i+1]
+				h[k][i+1] = -sn[i]*h[k][i] + cs[i]*h[k][i+1]
+				h[k][i] = tmp
+			}
+			d := math.Hypot(h[k][k], h[k][k+1])
+			if d == 0 {
+				return st.res, fmt.Errorf("breakdown at iteration %d", it)
+			}
+			cs[k], sn[k] = h[k][k]/d, h[k][k+1]/d
+			h[k][k] = d
+			h[k][k+1] = 0
+			g[k+1] = -sn[k] * g[k]
+			g[k] = cs[k] * g[k]
+			k++
+			rel = math.Abs(g[k]) / st.bnorm
+			st.res.Residuals = append(st.res.Residuals, rel)
+			if rel <= st.tol {
+				break
+			}
+		}
+		//solve the k*k upper triangular system, then update x=x+M⁻¹*V*y
+		for i := k - 1; i >= 0; i-- {
+			sum := g[i]
+			for j := i + 1; j < k; j++ {
+				sum -= h[j][i] * y[j]
+			}
+			y[i] = sum / h[i][i]
+		}
+		for l := range w {
+			w[l] = 0
+		}
+		for i := 0; i < k; i++ {
+			kernel.Daxpy(y[i], v[i], w)
+		}
+		st.precond.Apply(z, w)
+		kernel.Daxpy(1, z, st.x)
+		//the true residual replaces the estimate at the end of each cycle
+		rel = st.residual(r)
+		st.res.Residuals[len(st.res.Residuals)-1] = rel
+		if st.res.Converged = rel <= st.tol; st.res.Converged {
+			break
+		}
+	}
+	return st.res, nil
+}
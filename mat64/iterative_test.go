@@ -0,0 +1,96 @@
+package mat
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+type iterSolver func(a LinearOperator, b *M64, s *IterSettings) (*IterResult, error)
+
+func TestIterativeSolvers(t *testing.T) {
+	te := tester.New(t)
+	src := rand.NewSource(1)
+	spd := NewSPD(20, src)
+	nonsym := NewNormal(20, 20, src, 0, 1)
+	for i := 0; i < 20; i++ {
+		nonsym.Set(i, i, nonsym.At(i, i)+10)
+	}
+	lap := laplacian(50)
+	ilu, _ := NewILU0(lap)
+	ic, _ := NewIC0(lap)
+	jac, _ := NewJacobi(spd)
+	matFree := NewOperator(50, lap.MulVec)
+
+	tests := []struct {
+		name  string
+		solve iterSolver
+		a     LinearOperator
+		s     *IterSettings
+	}{
+		{"cg dense", CG, spd, nil},
+		{"cg jacobi", CG, spd, &IterSettings{Precond: jac}},
+		{"cg sparse ic0", CG, lap, &IterSettings{Precond: ic}},
+		{"cg matrix free", CG, matFree, nil},
+		{"bicgstab dense", BiCGSTAB, nonsym, nil},
+		{"bicgstab ilu0", BiCGSTAB, lap, &IterSettings{Precond: ilu}},
+		{"gmres dense", GMRES, nonsym, nil},
+		{"gmres restarted", GMRES, lap, &IterSettings{Restart: 10, MaxIter: 5000}},
+		{"gmres ilu0", GMRES, lap, &IterSettings{Precond: ilu}},
+	}
+	for ind, test := range tests {
+		n, _ := test.a.Dims()
+		x := NewNormal(n, 1, src, 0, 1)
+		b := NewM64(n, 1, nil)
+		test.a.MulVec(b.data, x.data)
+		res, err := test.solve(test.a, b, test.s)
+		te.CompareError(ind, nil, err)
+		if err != nil {
+			continue
+		}
+		te.DeepEqual(ind, test.name+" converged", true, res.Converged)
+		te.DeepEqual(ind, test.name+" x", true, EqualApprox(x, res.X, 1e-7))
+		te.DeepEqual(ind, test.name+" history", res.Iterations+1, len(res.Residuals))
+	}
+}
+
+func TestIterativePreconditionedIsFaster(t *testing.T) {
+	te := tester.New(t)
+	lap := laplacian(50)
+	ic, _ := NewIC0(lap)
+	b := NewM64(50, 1, nil)
+	for i := range b.data {
+		b.data[i] = 1
+	}
+	plain, _ := CG(lap, b, nil)
+	pre, _ := CG(lap, b, &IterSettings{Precond: ic})
+	te.DeepEqual(0, "exact preconditioner", 1, pre.Iterations)
+	te.DeepEqual(0, "faster", true, pre.Iterations < plain.Iterations)
+}
+
+func TestIterativeErrors(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		a   LinearOperator
+		b   *M64
+		s   *IterSettings
+		err error
+	}{
+		{nil, NewM64(2, 1, nil), nil, fmt.Errorf("a is nil")},
+		{NewM64(2, 3, nil), NewM64(2, 1, nil), nil, fmt.Errorf("a is not square")},
+		{NewM64(2, 2, nil), nil, nil, fmt.Errorf("b is nil")},
+		{NewM64(2, 2, nil), NewM64(3, 1, nil), nil, fmt.Errorf("b should be a 2x1 vector")},
+		{NewM64(2, 2, nil), NewM64(2, 1, nil), &IterSettings{X0: NewM64(1, 1, nil)}, fmt.Errorf("x0 should be a 2x1 vector")},
+	}
+	for ind, test := range tests {
+		_, err := CG(test.a, test.b, test.s)
+		te.CompareError(ind, test.err, err)
+	}
+	//not enough iterations
+	res, err := CG(laplacian(50), NewM64(50, 1, NewUniform(50, 1, rand.NewSource(1), 0, 1).data), &IterSettings{MaxIter: 3})
+	te.CompareError(5, nil, err)
+	te.DeepEqual(5, "converged", false, res.Converged)
+	te.DeepEqual(5, "iterations", 3, res.Iterations)
+}
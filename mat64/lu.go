@@ -0,0 +1,115 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/twiggg/math/mat64/internal/kernel"
+)
+
+//LU holds the LU decomposition with partial pivoting of a square matrix: P*A=L*U
+type LU struct {
+	n    int
+	lu   []float64
+	piv  []int
+	sign float64
+}
+
+//NewLU returns the LU decomposition of the square matrix a. a is not modified
+func NewLU(a *M64) (*LU, error) {
+	if !a.Valid() {
+		return nil, fmt.Errorf("a is nil")
+	}
+	if a.r != a.c {
+		return nil, fmt.Errorf("a is not square")
+	}
+	n := a.r
+	f := &LU{n: n, lu: append([]float64(nil), a.data...), piv: make([]int, n), sign: 1}
+	lu := f.lu
+	for i := range f.piv {
+		f.piv[i] = i
+	}
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu[i*n+k]) > math.Abs(lu[p*n+k]) {
+				p = i
+			}
+		}
+		if lu[p*n+k] == 0 {
+			return nil, fmt.Errorf("matrix is singular")
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				lu[k*n+j], lu[p*n+j] = lu[p*n+j], lu[k*n+j]
+			}
+			f.piv[k], f.piv[p] = f.piv[p], f.piv[k]
+			f.sign = -f.sign
+		}
+		for i := k + 1; i < n; i++ {
+			lu[i*n+k] /= lu[k*n+k]
+			kernel.Daxpy(-lu[i*n+k], lu[k*n+k+1:(k+1)*n], lu[i*n+k+1:(i+1)*n])
+		}
+	}
+	return f, nil
+}
+
+//Det returns the determinant of the decomposed matrix
+func (f *LU) Det() float64 {
+	det := f.sign
+	for i := 0; i < f.n; i++ {
+		det *= f.lu[i*f.n+i]
+	}
+	return det
+}
+
+//Solve returns x such that a*x=b
+func (f *LU) Solve(b *M64) (*M64, error) {
+	if f == nil {
+		return nil, fmt.Errorf("decomposition is nil")
+	}
+	if !b.Valid() {
+		return nil, fmt.Errorf("b is nil")
+	}
+	if b.r != f.n {
+		return nil, fmt.Errorf("a,b rows not equal")
+	}
+	n, nb := f.n, b.c
+	x := NewM64(n, nb, nil)
+	for i, p := range f.piv {
+		copy(x.data[i*nb:(i+1)*nb], b.data[p*nb:(p+1)*nb])
+	}
+	kernel.Dtrsm(false, true, n, nb, 1, f.lu, n, x.data, nb)
+	kernel.Dtrsm(true, false, n, nb, 1, f.lu, n, x.data, nb)
+	return x, nil
+}
+
+//Solve returns x such that a*x=b, using the LU decomposition of a
+func Solve(a, b *M64) (*M64, error) {
+	f, err := NewLU(a)
+	if err != nil {
+		return nil, err
+	}
+	return f.Solve(b)
+}
+
+//Inverse returns a new matrix as the inverse of a
+func Inverse(a *M64) (*M64, error) {
+	f, err := NewLU(a)
+	if err != nil {
+		return nil, err
+	}
+	return f.Solve(Identity(a.r))
+}
+
+//Det returns the determinant of the square matrix a, 0 if it is singular
+func Det(a *M64) (float64, error) {
+	f, err := NewLU(a)
+	if err != nil {
+		if a.Valid() && a.r == a.c {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return f.Det(), nil
+}
@@ -0,0 +1,37 @@
+package mat
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestLU(t *testing.T) {
+	te := tester.New(t)
+	a := NewM64(3, 3, []float64{0, 2, 1, 1, 1, 1, 2, 1, 3})
+	det, err := Det(a)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "det", true, EqualApprox(NewM64(1, 1, []float64{-3}), NewM64(1, 1, []float64{det}), 1e-14))
+	x, err := Solve(a, NewM64(3, 1, []float64{3, 3, 6}))
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "x", true, EqualApprox(NewM64(3, 1, []float64{1, 1, 1}), x, 1e-14))
+
+	src := rand.NewSource(1)
+	a = NewNormal(8, 8, src, 0, 1)
+	inv, err := Inverse(a)
+	te.CompareError(2, nil, err)
+	p, _ := Mul(a, inv)
+	te.DeepEqual(2, "a*inv(a)", true, EqualApprox(Identity(8), p, 1e-12))
+
+	det, err = Det(NewM64(2, 2, []float64{1, 2, 2, 4}))
+	te.CompareError(3, nil, err)
+	te.DeepEqual(3, "singular det", 0.0, det)
+	_, err = Solve(NewM64(2, 2, []float64{1, 2, 2, 4}), NewM64(2, 1, nil))
+	te.CompareError(4, fmt.Errorf("matrix is singular"), err)
+	_, err = Solve(NewM64(2, 3, nil), NewM64(2, 1, nil))
+	te.CompareError(5, fmt.Errorf("a is not square"), err)
+	_, err = Solve(Identity(2), NewM64(3, 1, nil))
+	te.CompareError(6, fmt.Errorf("a,b rows not equal"), err)
+}
@@ -1,5 +1,7 @@
 package mat
 
+import "fmt"
+
 //mat "gonum.org/v1/gonum/mat"
 
 /*
@@ -31,7 +33,7 @@ func (m *M64) Size() int {
 	return m.r * m.c
 }
 
-//NewM64 returns a new M64 instance, initialized with data if len==r*c
+//NewM64 returns a new M64 instance, initialized with data if len==r*c, with zeros otherwise. Use FromData to get an error instead, and Reshape to change the dims of existing data
 func NewM64(r, c int, data []float64) *M64 {
 	if r <= 0 {
 		r = 1
@@ -48,6 +50,17 @@ func NewM64(r, c int, data []float64) *M64 {
 	return m
 }
 
+//FromData returns a new r*c matrix using data, which is not copied. Unlike NewM64, an error is returned if the dims are not positive or len(data)!=r*c
+func FromData(r, c int, data []float64) (*M64, error) {
+	if r < 1 || c < 1 {
+		return nil, fmt.Errorf("dims must be positive, got %d*%d", r, c)
+	}
+	if len(data) != r*c {
+		return nil, fmt.Errorf("data has %d values, expected %d*%d", len(data), r, c)
+	}
+	return &M64{r: r, c: c, data: data}, nil
+}
+
 //Valid returns false if m is nil, and initiates with empty data of size=r*c if invalid size
 func (m *M64) Valid() bool {
 	if m == nil {
@@ -106,6 +119,11 @@ func (m *M64) MulElem(n *M64) error {
 	return mulElem(m, n, m)
 }
 
+//DivElem divides m by n (element by element)
+func (m *M64) DivElem(n *M64) error {
+	return divElem(m, n, m)
+}
+
 //MapElem applies fn to each element of the matrix
 func (m *M64) MapElem(fn func(x float64) float64) error {
 	return mapElemVal(m, m, fn)
@@ -1,6 +1,7 @@
 package mat
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/twiggg/tester"
@@ -20,3 +21,44 @@ func TestIndex(t *testing.T) {
 		te.DeepEqual(ind, "ind", test.ind, test.m.index(test.i, test.j))
 	}
 }
+
+func TestFromData(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		r, c int
+		data []float64
+		res  *M64
+		err  error
+	}{
+		{2, 1, []float64{1, 2}, NewM64(2, 1, []float64{1, 2}), nil},
+		{2, 2, []float64{1, 2}, nil, fmt.Errorf("data has 2 values, expected 2*2")},
+		{0, 2, nil, nil, fmt.Errorf("dims must be positive, got 0*2")},
+		{1, -1, []float64{1}, nil, fmt.Errorf("dims must be positive, got 1*-1")},
+	}
+	for ind, test := range tests {
+		res, err := FromData(test.r, test.c, test.data)
+		te.CompareError(ind, test.err, err)
+		te.DeepEqual(ind, "res", test.res, res)
+	}
+}
+
+func TestInPlaceOps(t *testing.T) {
+	te := tester.New(t)
+	m := NewM64(2, 2, []float64{1, 2, 3, 4})
+	err := m.Mul(NewM64(2, 2, []float64{0, 1, 1, 0}))
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "mul", NewM64(2, 2, []float64{2, 1, 4, 3}), m)
+	err = m.Sub(NewM64(2, 2, []float64{1, 1, 1, 1}))
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "sub", NewM64(2, 2, []float64{1, 0, 3, 2}), m)
+	err = m.Add(m)
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "add", NewM64(2, 2, []float64{2, 0, 6, 4}), m)
+	err = m.Sub(m)
+	te.CompareError(3, nil, err)
+	te.DeepEqual(3, "sub itself", NewM64(2, 2, nil), m)
+	n := NewM64(2, 2, []float64{1, 2, 3, 4})
+	err = SubTo(n, NewM64(2, 2, []float64{4, 3, 2, 1}), n)
+	te.CompareError(4, nil, err)
+	te.DeepEqual(4, "sub into n", NewM64(2, 2, []float64{3, 1, -1, -3}), n)
+}
@@ -0,0 +1,201 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/twiggg/math/mat64/internal/kernel"
+)
+
+//norm1 returns the maximum absolute colomn sum of m
+func norm1(m *M64) float64 {
+	max := 0.0
+	for j := 0; j < m.c; j++ {
+		sum := 0.0
+		for i := 0; i < m.r; i++ {
+			sum += math.Abs(m.data[i*m.c+j])
+		}
+		if sum > max {
+			max = sum
+		}
+	}
+	return max
+}
+
+func checkSquare(a *M64) error {
+	if !a.Valid() {
+		return fmt.Errorf("a is nil")
+	}
+	if a.r != a.c {
+		return fmt.Errorf("a is not square")
+	}
+	return nil
+}
+
+//axpy returns a new matrix as alpha*x+y
+func axpy(alpha float64, x, y *M64) *M64 {
+	res := NewM64(y.r, y.c, append([]float64(nil), y.data...))
+	kernel.Daxpy(alpha, x.data, res.data)
+	return res
+}
+
+//Expm returns the matrix exponential exp(a), using a [6/6] Padé approximant with scaling and squaring
+func Expm(a *M64) (*M64, error) {
+	if err := checkSquare(a); err != nil {
+		return nil, err
+	}
+	n := a.r
+	//scale a so that its norm is below 1/2. The norm being finite, s is at most 1025 and 2^-s is representable
+	for _, v := range a.data {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, fmt.Errorf("a has an infinite or NaN value")
+		}
+	}
+	nrm := norm1(a)
+	if math.IsInf(nrm, 0) {
+		return nil, fmt.Errorf("exp(a) overflows")
+	}
+	s := 0
+	if nrm > 0.5 {
+		s = int(math.Ceil(math.Log2(nrm) + 1))
+	}
+	x, _ := Scale(math.Ldexp(1, -s), a)
+	const q = 6
+	c := 0.5
+	p := x
+	num := axpy(0.5, x, Identity(n))
+	den := axpy(-0.5, x, Identity(n))
+	for k := 2; k <= q; k++ {
+		c *= float64(q-k+1) / float64(k*(2*q-k+1))
+		p, _ = Mul(x, p)
+		num = axpy(c, p, num)
+		if k%2 == 0 {
+			den = axpy(c, p, den)
+		} else {
+			den = axpy(-c, p, den)
+		}
+	}
+	e, err := Solve(den, num)
+	if err != nil {
+		return nil, fmt.Errorf("padé denominator: %s", err.Error())
+	}
+	for k := 0; k < s; k++ {
+		e, _ = Mul(e, e)
+	}
+	for _, v := range e.data {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, fmt.Errorf("exp(a) overflows")
+		}
+	}
+	return e, nil
+}
+
+//Sqrtm returns the principal square root of a, using the Denman-Beavers iteration. a must have no eigenvalue on the closed negative real axis
+func Sqrtm(a *M64) (*M64, error) {
+	if err := checkSquare(a); err != nil {
+		return nil, err
+	}
+	y := NewM64(a.r, a.c, append([]float64(nil), a.data...))
+	z := Identity(a.r)
+	for it := 0; it < 100; it++ {
+		yi, err := Inverse(y)
+		if err != nil {
+			return nil, fmt.Errorf("iteration %d: %s", it, err.Error())
+		}
+		zi, err := Inverse(z)
+		if err != nil {
+			return nil, fmt.Errorf("iteration %d: %s", it, err.Error())
+		}
+		yn := axpy(1, y, zi)
+		yn, _ = Scale(0.5, yn)
+		zn := axpy(1, z, yi)
+		zn, _ = Scale(0.5, zn)
+		diff, _ := Sub(yn, y)
+		y, z = yn, zn
+		if norm1(diff) <= 1e-14*norm1(y) {
+			return y, nil
+		}
+	}
+	return nil, fmt.Errorf("square root did not converge")
+}
+
+//Logm returns the principal logarithm of a, using inverse scaling and squaring: square roots are taken until a is close to I, then a series is summed. a must have no eigenvalue on the closed negative real axis
+func Logm(a *M64) (*M64, error) {
+	if err := checkSquare(a); err != nil {
+		return nil, err
+	}
+	n := a.r
+	id := Identity(n)
+	x := a
+	k := 0
+	for {
+		d, _ := Sub(x, id)
+		if norm1(d) < 0.25 {
+			break
+		}
+		if k == 64 {
+			return nil, fmt.Errorf("logarithm did not converge")
+		}
+		var err error
+		if x, err = Sqrtm(x); err != nil {
+			return nil, err
+		}
+		k++
+	}
+	//log(I+d) = 2*atanh(z) = 2*(z + z³/3 + z⁵/5 + ...) with z = (2I+d)⁻¹*d
+	d, _ := Sub(x, id)
+	z, err := Solve(axpy(2, id, d), d)
+	if err != nil {
+		return nil, err
+	}
+	z2, _ := Mul(z, z)
+	res := z
+	p := z
+	for j := 3; j < 100; j += 2 {
+		p, _ = Mul(p, z2)
+		res = axpy(1/float64(j), p, res)
+		if norm1(p)/float64(j) <= 1e-17*norm1(res) {
+			break
+		}
+	}
+	return Scale(math.Ldexp(2, k), res)
+}
+
+//Powm returns the integer power a^k by binary powering. Negative powers use the inverse of a
+func Powm(a *M64, k int) (*M64, error) {
+	if err := checkSquare(a); err != nil {
+		return nil, err
+	}
+	base := a
+	if k < 0 {
+		var err error
+		if base, err = Inverse(a); err != nil {
+			return nil, err
+		}
+		k = -k
+	}
+	res := Identity(a.r)
+	for k > 0 {
+		if k&1 == 1 {
+			res, _ = Mul(res, base)
+		}
+		k >>= 1
+		if k > 0 {
+			base, _ = Mul(base, base)
+		}
+	}
+	return res, nil
+}
+
+//PowmReal returns the real power a^p = exp(p*log(a)). Integer values of p use Powm
+func PowmReal(a *M64, p float64) (*M64, error) {
+	if p == math.Trunc(p) && math.Abs(p) < 1<<31 {
+		return Powm(a, int(p))
+	}
+	l, err := Logm(a)
+	if err != nil {
+		return nil, err
+	}
+	l, _ = Scale(p, l)
+	return Expm(l)
+}
@@ -0,0 +1,86 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestExpm(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		a   *M64
+		res *M64
+	}{
+		{NewM64(2, 2, nil), Identity(2)},
+		{Diag([]float64{1, -2}), Diag([]float64{math.E, math.Exp(-2)})},
+		//rotation generator
+		{NewM64(2, 2, []float64{0, -3, 3, 0}), NewM64(2, 2, []float64{math.Cos(3), -math.Sin(3), math.Sin(3), math.Cos(3)})},
+		//nilpotent
+		{NewM64(2, 2, []float64{0, 50, 0, 0}), NewM64(2, 2, []float64{1, 50, 0, 1})},
+		{Diag([]float64{20, 0.1}), Diag([]float64{math.Exp(20), math.Exp(0.1)})},
+		//huge negative values underflow to 0
+		{Diag([]float64{-1e308, 0}), Diag([]float64{0, 1})},
+	}
+	for ind, test := range tests {
+		res, err := Expm(test.a)
+		te.CompareError(ind, nil, err)
+		te.DeepEqual(ind, "exp", true, EqualApprox(test.res, res, 1e-12))
+	}
+	errs := []struct {
+		a   *M64
+		err error
+	}{
+		{Diag([]float64{math.Inf(1), 1}), fmt.Errorf("a has an infinite or NaN value")},
+		{Diag([]float64{math.NaN(), 1}), fmt.Errorf("a has an infinite or NaN value")},
+		{NewM64(1, 1, []float64{1e308}), fmt.Errorf("exp(a) overflows")},
+		{Diag([]float64{800, 1}), fmt.Errorf("exp(a) overflows")},
+		{NewM64(2, 3, nil), fmt.Errorf("a is not square")},
+	}
+	for ind, test := range errs {
+		res, err := Expm(test.a)
+		te.CompareError(ind, test.err, err)
+		te.DeepEqual(ind, "res", (*M64)(nil), res)
+	}
+}
+
+func TestLogmSqrtm(t *testing.T) {
+	te := tester.New(t)
+	src := rand.NewSource(1)
+	for ind := 0; ind < 4; ind++ {
+		a := NewSPD(5, src)
+		s, err := Sqrtm(a)
+		te.CompareError(ind, nil, err)
+		ss, _ := Mul(s, s)
+		te.DeepEqual(ind, "sqrt²", true, EqualApprox(a, ss, 1e-10))
+		l, err := Logm(a)
+		te.CompareError(ind, nil, err)
+		e, _ := Expm(l)
+		te.DeepEqual(ind, "exp(log)", true, EqualApprox(a, e, 1e-10))
+	}
+	l, err := Logm(Diag([]float64{math.E, 1}))
+	te.CompareError(4, nil, err)
+	te.DeepEqual(4, "log", true, EqualApprox(Diag([]float64{1, 0}), l, 1e-13))
+}
+
+func TestPowm(t *testing.T) {
+	te := tester.New(t)
+	a := NewM64(2, 2, []float64{1, 1, 0, 1})
+	tests := []struct {
+		p   float64
+		res *M64
+	}{
+		{0, Identity(2)},
+		{5, NewM64(2, 2, []float64{1, 5, 0, 1})},
+		{-3, NewM64(2, 2, []float64{1, -3, 0, 1})},
+		{0.5, NewM64(2, 2, []float64{1, 0.5, 0, 1})},
+	}
+	for ind, test := range tests {
+		res, err := PowmReal(a, test.p)
+		te.CompareError(ind, nil, err)
+		te.DeepEqual(ind, "pow", true, EqualApprox(test.res, res, 1e-10))
+	}
+}
@@ -0,0 +1,158 @@
+//Package mattest provides helpers for property-based tests of mat64: random shapes and matrices, and invariant checks
+package mattest
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Property is an invariant checked against matrices generated from rnd. It returns a non nil error if the invariant does not hold
+type Property func(rnd *rand.Rand) error
+
+//InverseFunc computes the inverse of a square matrix
+type InverseFunc func(m *mat.M64) (*mat.M64, error)
+
+//Check runs prop n times, each with a new generator seeded from seed, and reports every failure to t
+func Check(t testing.TB, n int, seed int64, prop Property) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		s := seed + int64(i)
+		if err := prop(rand.New(rand.NewSource(s))); err != nil {
+			t.Errorf("seed %d: %s", s, err.Error())
+		}
+	}
+}
+
+//RandDims returns random dimensions in [1,max]
+func RandDims(rnd *rand.Rand, max int) (int, int) {
+	if max < 1 {
+		max = 1
+	}
+	return 1 + rnd.Intn(max), 1 + rnd.Intn(max)
+}
+
+//RandM64 returns a r*c matrix with standard normal values
+func RandM64(rnd *rand.Rand, r, c int) *mat.M64 {
+	return mat.NewNormal(r, c, rnd, 0, 1)
+}
+
+//RandShape returns a matrix of random dimensions in [1,max], with standard normal values
+func RandShape(rnd *rand.Rand, max int) *mat.M64 {
+	r, c := RandDims(rnd, max)
+	return RandM64(rnd, r, c)
+}
+
+//RandSquare returns a n*n matrix with n in [1,max], with standard normal values
+func RandSquare(rnd *rand.Rand, max int) *mat.M64 {
+	n, _ := RandDims(rnd, max)
+	return RandM64(rnd, n, n)
+}
+
+//RandMulPair returns two random matrices a,b with compatible dims for the product a*b
+func RandMulPair(rnd *rand.Rand, max int) (*mat.M64, *mat.M64) {
+	r, k := RandDims(rnd, max)
+	_, c := RandDims(rnd, max)
+	return RandM64(rnd, r, k), RandM64(rnd, k, c)
+}
+
+//TransposeProduct checks that (AB)ᵀ = BᵀAᵀ
+func TransposeProduct(a, b *mat.M64, tol float64) error {
+	ab, err := mat.Mul(a, b)
+	if err != nil {
+		return fmt.Errorf("a*b: %s", err.Error())
+	}
+	left, err := mat.Transpose(ab)
+	if err != nil {
+		return fmt.Errorf("(ab)ᵀ: %s", err.Error())
+	}
+	at, err := mat.Transpose(a)
+	if err != nil {
+		return fmt.Errorf("aᵀ: %s", err.Error())
+	}
+	bt, err := mat.Transpose(b)
+	if err != nil {
+		return fmt.Errorf("bᵀ: %s", err.Error())
+	}
+	right, err := mat.Mul(bt, at)
+	if err != nil {
+		return fmt.Errorf("bᵀ*aᵀ: %s", err.Error())
+	}
+	if !mat.EqualApprox(left, right, tol) {
+		return fmt.Errorf("(ab)ᵀ != bᵀaᵀ")
+	}
+	return nil
+}
+
+//AddSub checks that (A+B)-B = A
+func AddSub(a, b *mat.M64, tol float64) error {
+	s, err := mat.Add(a, b)
+	if err != nil {
+		return fmt.Errorf("a+b: %s", err.Error())
+	}
+	s, err = mat.Sub(s, b)
+	if err != nil {
+		return fmt.Errorf("(a+b)-b: %s", err.Error())
+	}
+	if !mat.EqualApprox(a, s, tol) {
+		return fmt.Errorf("(a+b)-b != a")
+	}
+	return nil
+}
+
+//MulAssociative checks that (AB)C = A(BC)
+func MulAssociative(a, b, c *mat.M64, tol float64) error {
+	ab, err := mat.Mul(a, b)
+	if err != nil {
+		return fmt.Errorf("a*b: %s", err.Error())
+	}
+	left, err := mat.Mul(ab, c)
+	if err != nil {
+		return fmt.Errorf("(ab)*c: %s", err.Error())
+	}
+	bc, err := mat.Mul(b, c)
+	if err != nil {
+		return fmt.Errorf("b*c: %s", err.Error())
+	}
+	right, err := mat.Mul(a, bc)
+	if err != nil {
+		return fmt.Errorf("a*(bc): %s", err.Error())
+	}
+	if !mat.EqualApprox(left, right, tol) {
+		return fmt.Errorf("(ab)c != a(bc)")
+	}
+	return nil
+}
+
+//Inverse checks that A*inv(A) = I and inv(A)*A = I
+func Inverse(a *mat.M64, inv InverseFunc, tol float64) error {
+	if inv == nil {
+		return fmt.Errorf("inverse function is nil")
+	}
+	r, c := a.Dims()
+	if r != c {
+		return fmt.Errorf("a is not square")
+	}
+	ai, err := inv(a)
+	if err != nil {
+		return fmt.Errorf("inv(a): %s", err.Error())
+	}
+	id := mat.Identity(r)
+	p, err := mat.Mul(a, ai)
+	if err != nil {
+		return fmt.Errorf("a*inv(a): %s", err.Error())
+	}
+	if !mat.EqualApprox(p, id, tol) {
+		return fmt.Errorf("a*inv(a) != I")
+	}
+	p, err = mat.Mul(ai, a)
+	if err != nil {
+		return fmt.Errorf("inv(a)*a: %s", err.Error())
+	}
+	if !mat.EqualApprox(p, id, tol) {
+		return fmt.Errorf("inv(a)*a != I")
+	}
+	return nil
+}
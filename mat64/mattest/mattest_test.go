@@ -0,0 +1,73 @@
+package mattest
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestProperties(t *testing.T) {
+	Check(t, 50, 1, func(rnd *rand.Rand) error {
+		a, b := RandMulPair(rnd, 6)
+		return TransposeProduct(a, b, 1e-12)
+	})
+	Check(t, 50, 2, func(rnd *rand.Rand) error {
+		a := RandShape(rnd, 6)
+		r, c := a.Dims()
+		return AddSub(a, RandM64(rnd, r, c), 1e-12)
+	})
+	Check(t, 50, 3, func(rnd *rand.Rand) error {
+		a, b := RandMulPair(rnd, 6)
+		_, k := b.Dims()
+		_, c := RandDims(rnd, 6)
+		return MulAssociative(a, b, RandM64(rnd, k, c), 1e-10)
+	})
+}
+
+func TestInverse(t *testing.T) {
+	te := tester.New(t)
+	diagInv := func(m *mat.M64) (*mat.M64, error) {
+		n, _ := m.Dims()
+		res := mat.NewM64(n, n, nil)
+		for i := 0; i < n; i++ {
+			res.Set(i, i, 1/m.At(i, i))
+		}
+		return res, nil
+	}
+	tests := []struct {
+		a   *mat.M64
+		inv InverseFunc
+		err error
+	}{
+		{mat.NewM64(2, 2, []float64{2, 0, 0, 4}), diagInv, nil},
+		{mat.NewM64(2, 2, []float64{2, 1, 0, 4}), diagInv, fmt.Errorf("a*inv(a) != I")},
+		{mat.NewM64(2, 3, nil), diagInv, fmt.Errorf("a is not square")},
+		{mat.NewM64(2, 2, nil), nil, fmt.Errorf("inverse function is nil")},
+	}
+	for ind, test := range tests {
+		te.CompareError(ind, test.err, Inverse(test.a, test.inv, 1e-12))
+	}
+}
+
+func TestRandDims(t *testing.T) {
+	te := tester.New(t)
+	rnd := rand.New(rand.NewSource(1))
+	ok := true
+	for i := 0; i < 100; i++ {
+		r, c := RandDims(rnd, 4)
+		if r < 1 || r > 4 || c < 1 || c > 4 {
+			ok = false
+		}
+	}
+	te.DeepEqual(0, "in range", true, ok)
+}
+
+func TestInverseProperty(t *testing.T) {
+	Check(t, 30, 4, func(rnd *rand.Rand) error {
+		n, _ := RandDims(rnd, 8)
+		return Inverse(mat.NewSPD(n, rnd), mat.Inverse, 1e-10)
+	})
+}
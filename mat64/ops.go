@@ -1,5 +1,12 @@
 package mat
 
+import (
+	"fmt"
+	"math"
+
+	"github.com/twiggg/math/mat64/internal/kernel"
+)
+
 //Add returns a new matrix as m+n (element by element)
 func Add(m, n *M64) (*M64, error) {
 	r, c := m.Dims()
@@ -41,11 +48,124 @@ func MulElem(m, n *M64) (*M64, error) {
 	return res, nil
 }
 
+//DivElem returns a new matrix as m./n (element by element)
+func DivElem(m, n *M64) (*M64, error) {
+	r, c := m.Dims()
+	res := NewM64(r, c, nil)
+	if err := divElem(m, n, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+//PowElem returns a new matrix with each elem of m raised to the power p
+func PowElem(m *M64, p float64) (*M64, error) {
+	return MapElem(m, func(x float64) float64 { return math.Pow(x, p) })
+}
+
 //MapElem applies function fn to each elem of m
 func MapElem(m *M64, fn func(x float64) float64) (*M64, error) {
-	res := NewM64(m.r, m.c, nil)
+	r, c := m.Dims()
+	res := NewM64(r, c, nil)
 	if err := mapElemVal(m, res, fn); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
+
+//AddTo sets dest to m+n (element by element)
+func AddTo(dest, m, n *M64) error {
+	return add(m, n, dest)
+}
+
+//SubTo sets dest to m-n (element by element)
+func SubTo(dest, m, n *M64) error {
+	return sub(m, n, dest)
+}
+
+//MulTo sets dest to the dot product of m and n
+func MulTo(dest, m, n *M64) error {
+	return mul(m, n, dest)
+}
+
+//MulTransTo sets dest to the dot product of mᵀ and n, without building mᵀ
+func MulTransTo(dest, m, n *M64) error {
+	if !m.Valid() {
+		return fmt.Errorf("m is nil")
+	}
+	if !n.Valid() {
+		return fmt.Errorf("n is nil")
+	}
+	if !dest.Valid() {
+		return fmt.Errorf("dest is nil")
+	}
+	if m.r != n.r {
+		return fmt.Errorf("m,n rows not equal")
+	}
+	if dest.r != m.c {
+		return fmt.Errorf("m colomns != dest rows")
+	}
+	if dest.c != n.c {
+		return fmt.Errorf("n,dest colomns not equal")
+	}
+	out := dest.data
+	if shares(m, dest) || shares(n, dest) {
+		out = make([]float64, len(dest.data))
+	}
+	kernel.Dgemm(true, false, m.c, n.c, m.r, 1, m.data, m.c, n.data, n.c, 0, out, dest.c)
+	copy(dest.data, out)
+	return nil
+}
+
+//MulElemTo sets dest to m.*n (element by element)
+func MulElemTo(dest, m, n *M64) error {
+	return mulElem(m, n, dest)
+}
+
+//MapElemTo sets dest to fn applied to each elem of m
+func MapElemTo(dest, m *M64, fn func(x float64) float64) error {
+	return mapElemVal(m, dest, fn)
+}
+
+//Transpose returns a new matrix as the transpose of m
+func Transpose(m *M64) (*M64, error) {
+	r, c := m.Dims()
+	res := NewM64(c, r, nil)
+	if err := transpose(m, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+//EqualApprox returns true if m and n have the same dims and every pair of elements x,y satisfies |x-y| <= tol*max(1,|x|,|y|):
+//tol is an absolute tolerance for values smaller than 1 and a relative one above
+func EqualApprox(m, n *M64, tol float64) bool {
+	if m == nil || n == nil {
+		return m == n
+	}
+	if m.r != n.r || m.c != n.c || len(m.data) != len(n.data) {
+		return false
+	}
+	tol = math.Abs(tol)
+	for i, x := range m.data {
+		y := n.data[i]
+		if x == y {
+			continue
+		}
+		scale := math.Max(1, math.Max(math.Abs(x), math.Abs(y)))
+		if !(math.Abs(x-y) <= tol*scale) {
+			return false
+		}
+	}
+	return true
+}
+
+//Scale returns a new matrix as f*m
+func Scale(f float64, m *M64) (*M64, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	res := NewM64(m.r, m.c, append([]float64(nil), m.data...))
+	kernel.Dscal(f, res.data)
+	return res, nil
+}
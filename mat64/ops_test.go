@@ -2,6 +2,7 @@ package mat
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/twiggg/tester"
@@ -31,3 +32,58 @@ func TestDotProd(t *testing.T) {
 		}
 	}
 }
+
+func TestTranspose(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		m   *M64
+		res *M64
+		err error
+	}{
+		{nil, nil, fmt.Errorf("m is nil")},
+		{NewM64(1, 1, []float64{3}), NewM64(1, 1, []float64{3}), nil},
+		{NewM64(2, 3, []float64{1, 2, 3, 4, 5, 6}), NewM64(3, 2, []float64{1, 4, 2, 5, 3, 6}), nil},
+		{NewM64(3, 1, []float64{1, 2, 3}), NewM64(1, 3, []float64{1, 2, 3}), nil},
+	}
+	for ind, test := range tests {
+		res, err := Transpose(test.m)
+		te.CompareError(ind, test.err, err)
+		if err == nil {
+			te.DeepEqual(ind, "res", test.res, res)
+		}
+	}
+}
+
+func TestEqualApprox(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		m   *M64
+		n   *M64
+		tol float64
+		res bool
+	}{
+		{nil, nil, 0, true},
+		{NewM64(1, 2, nil), nil, 1, false},
+		{NewM64(1, 2, nil), NewM64(2, 1, nil), 1, false},
+		{NewM64(1, 2, []float64{1, 2}), NewM64(1, 2, []float64{1, 2}), 0, true},
+		{NewM64(1, 2, []float64{0, 1e-10}), NewM64(1, 2, []float64{1e-10, 0}), 1e-9, true},
+		{NewM64(1, 2, []float64{0, 1e-8}), NewM64(1, 2, []float64{1e-8, 0}), 1e-9, false},
+		{NewM64(1, 1, []float64{1e6}), NewM64(1, 1, []float64{1e6 + 1e-4}), 1e-9, true},
+		{NewM64(1, 1, []float64{1e6}), NewM64(1, 1, []float64{1e6 + 1e-2}), 1e-9, false},
+		{NewM64(1, 1, []float64{math.NaN()}), NewM64(1, 1, []float64{math.NaN()}), 1, false},
+	}
+	for ind, test := range tests {
+		te.DeepEqual(ind, "res", test.res, EqualApprox(test.m, test.n, test.tol))
+	}
+}
+
+func TestMulTransTo(t *testing.T) {
+	te := tester.New(t)
+	m := NewM64(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	n := NewM64(3, 1, []float64{1, 1, 1})
+	dest := NewM64(2, 1, nil)
+	te.CompareError(0, nil, MulTransTo(dest, m, n))
+	te.DeepEqual(0, "res", NewM64(2, 1, []float64{9, 12}), dest)
+	te.CompareError(1, fmt.Errorf("m colomns != dest rows"), MulTransTo(NewM64(3, 1, nil), m, n))
+	te.CompareError(2, fmt.Errorf("m,n rows not equal"), MulTransTo(dest, m, NewM64(2, 1, nil)))
+}
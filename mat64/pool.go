@@ -0,0 +1,47 @@
+package mat
+
+import (
+	"math/bits"
+	"sync"
+)
+
+//Pool recycles matrices to avoid allocating temporaries. Data is kept in buckets of power of two capacities. Safe for concurrent use
+type Pool struct {
+	buckets [64]sync.Pool
+}
+
+//NewPool returns a new empty Pool
+func NewPool() *Pool {
+	return &Pool{}
+}
+
+//Get returns a r*c matrix filled with zeros, reusing the data of a matrix put back in the pool if possible
+func (p *Pool) Get(r, c int) *M64 {
+	if r <= 0 {
+		r = 1
+	}
+	if c <= 0 {
+		c = 1
+	}
+	size := r * c
+	k := bits.Len(uint(size - 1))
+	if m, ok := p.buckets[k].Get().(*M64); ok {
+		m.r, m.c = r, c
+		m.data = m.data[:size]
+		for i := range m.data {
+			m.data[i] = 0
+		}
+		return m
+	}
+	return &M64{r: r, c: c, data: make([]float64, size, 1<<uint(k))}
+}
+
+//Put gives m back to the pool. m must not be used afterwards
+func (p *Pool) Put(m *M64) {
+	if m == nil || cap(m.data) == 0 {
+		return
+	}
+	//bucket k only holds capacities >= 1<<k
+	k := bits.Len(uint(cap(m.data))) - 1
+	p.buckets[k].Put(m)
+}
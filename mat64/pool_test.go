@@ -0,0 +1,56 @@
+package mat
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestPool(t *testing.T) {
+	te := tester.New(t)
+	p := NewPool()
+	m := p.Get(3, 5)
+	te.DeepEqual(0, "new", NewM64(3, 5, nil).data, m.data)
+	te.DeepEqual(0, "cap", 16, cap(m.data))
+	m.Set(1, 1, 4)
+	p.Put(m)
+	//any size of the same bucket can reuse it, zeroed
+	n := p.Get(2, 6)
+	r, c := n.Dims()
+	te.DeepEqual(1, "dims", []int{2, 6}, []int{r, c})
+	te.DeepEqual(1, "zeroed", make([]float64, 12), n.data)
+	//matrices not allocated by the pool are accepted too
+	p.Put(NewM64(3, 3, nil))
+	p.Put(nil)
+	te.DeepEqual(2, "small", 4, p.Get(2, 2).Size())
+}
+
+func TestPoolConcurrent(t *testing.T) {
+	p := NewPool()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				m := p.Get(g+1, i%7+1)
+				m.Set(0, 0, float64(g))
+				p.Put(m)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func BenchmarkPoolMulTo(b *testing.B) {
+	p := NewPool()
+	w := NewM64(64, 64, nil)
+	x := NewM64(64, 1, nil)
+	b.ReportAllocs()
+	for k := 0; k < b.N; k++ {
+		dest := p.Get(64, 1)
+		MulTo(dest, w, x)
+		p.Put(dest)
+	}
+}
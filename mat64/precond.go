@@ -0,0 +1,195 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+)
+
+//Preconditioner approximates the inverse of an operator A: Apply sets dst to z such that M*z=r, with M close to A
+type Preconditioner interface {
+	Apply(dst, r []float64)
+}
+
+//Jacobi is the diagonal preconditioner M=diag(A)
+type Jacobi struct {
+	inv []float64
+}
+
+//NewJacobi returns the Jacobi preconditioner of the square matrix a. The diagonal must have no zero
+func NewJacobi(a Matrix) (*Jacobi, error) {
+	if a == nil {
+		return nil, fmt.Errorf("a is nil")
+	}
+	r, c := a.Dims()
+	if r != c {
+		return nil, fmt.Errorf("a is not square")
+	}
+	p := &Jacobi{inv: make([]float64, r)}
+	for i := range p.inv {
+		d := a.At(i, i)
+		if d == 0 {
+			return nil, fmt.Errorf("zero on the diagonal at %d", i)
+		}
+		p.inv[i] = 1 / d
+	}
+	return p, nil
+}
+
+//Apply sets dst to diag(A)⁻¹*r
+func (p *Jacobi) Apply(dst, r []float64) {
+	for i, v := range r {
+		dst[i] = v * p.inv[i]
+	}
+}
+
+//ILU is the incomplete LU factorization with no fill-in, ILU(0): L and U keep the sparsity pattern of A
+type ILU struct {
+	lu   *CSR
+	diag []int
+}
+
+//NewILU0 returns the ILU(0) preconditioner of the square sparse matrix a. Every diagonal value must be stored
+func NewILU0(a *CSR) (*ILU, error) {
+	if a == nil {
+		return nil, fmt.Errorf("a is nil")
+	}
+	if a.r != a.c {
+		return nil, fmt.Errorf("a is not square")
+	}
+	n := a.r
+	lu := &CSR{r: n, c: n, rowPtr: a.rowPtr, colInd: a.colInd, vals: append([]float64(nil), a.vals...)}
+	diag := make([]int, n)
+	for i := 0; i < n; i++ {
+		if diag[i] = lu.find(i, i); diag[i] < 0 {
+			return nil, fmt.Errorf("missing diagonal value at %d", i)
+		}
+	}
+	pos := make([]int, n)
+	for i := range pos {
+		pos[i] = -1
+	}
+	for i := 1; i < n; i++ {
+		for k := lu.rowPtr[i]; k < lu.rowPtr[i+1]; k++ {
+			pos[lu.colInd[k]] = k
+		}
+		for k := lu.rowPtr[i]; k < diag[i]; k++ {
+			col := lu.colInd[k]
+			piv := lu.vals[diag[col]]
+			if piv == 0 {
+				return nil, fmt.Errorf("zero pivot at %d", col)
+			}
+			lu.vals[k] /= piv
+			for kk := diag[col] + 1; kk < lu.rowPtr[col+1]; kk++ {
+				if p := pos[lu.colInd[kk]]; p >= 0 {
+					lu.vals[p] -= lu.vals[k] * lu.vals[kk]
+				}
+			}
+		}
+		for k := lu.rowPtr[i]; k < lu.rowPtr[i+1]; k++ {
+			pos[lu.colInd[k]] = -1
+		}
+	}
+	for i := 0; i < n; i++ {
+		if lu.vals[diag[i]] == 0 {
+			return nil, fmt.Errorf("zero pivot at %d", i)
+		}
+	}
+	return &ILU{lu: lu, diag: diag}, nil
+}
+
+//Apply sets dst to (LU)⁻¹*r, by forward and back substitution
+func (p *ILU) Apply(dst, r []float64) {
+	lu := p.lu
+	for i := 0; i < lu.r; i++ {
+		sum := r[i]
+		for k := lu.rowPtr[i]; k < p.diag[i]; k++ {
+			sum -= lu.vals[k] * dst[lu.colInd[k]]
+		}
+		dst[i] = sum
+	}
+	for i := lu.r - 1; i >= 0; i-- {
+		sum := dst[i]
+		for k := p.diag[i] + 1; k < lu.rowPtr[i+1]; k++ {
+			sum -= lu.vals[k] * dst[lu.colInd[k]]
+		}
+		dst[i] = sum / lu.vals[p.diag[i]]
+	}
+}
+
+//IC is the incomplete Cholesky factorization with no fill-in, IC(0): L keeps the sparsity pattern of the lower triangle of A
+type IC struct {
+	l *CSR
+}
+
+//NewIC0 returns the IC(0) preconditioner of the symmetric positive definite sparse matrix a. Only its lower triangle is read
+func NewIC0(a *CSR) (*IC, error) {
+	if a == nil {
+		return nil, fmt.Errorf("a is nil")
+	}
+	if a.r != a.c {
+		return nil, fmt.Errorf("a is not square")
+	}
+	n := a.r
+	l := &CSR{r: n, c: n, rowPtr: make([]int, n+1)}
+	for i := 0; i < n; i++ {
+		for k := a.rowPtr[i]; k < a.rowPtr[i+1] && a.colInd[k] <= i; k++ {
+			l.colInd = append(l.colInd, a.colInd[k])
+			l.vals = append(l.vals, a.vals[k])
+		}
+		l.rowPtr[i+1] = len(l.vals)
+		if last := l.rowPtr[i+1] - 1; last < l.rowPtr[i] || l.colInd[last] != i {
+			return nil, fmt.Errorf("missing diagonal value at %d", i)
+		}
+	}
+	for i := 0; i < n; i++ {
+		for k := l.rowPtr[i]; k < l.rowPtr[i+1]; k++ {
+			j := l.colInd[k]
+			//sum of L[i][m]*L[j][m] for m<j, merging the sorted rows i and j
+			sum := 0.0
+			ki, kj := l.rowPtr[i], l.rowPtr[j]
+			for ki < k && kj < l.rowPtr[j+1]-1 {
+				ci, cj := l.colInd[ki], l.colInd[kj]
+				switch {
+				case ci == cj:
+					sum += l.vals[ki] * l.vals[kj]
+					ki++
+					kj++
+				case ci < cj:
+					ki++
+				default:
+					kj++
+				}
+			}
+			if j < i {
+				l.vals[k] = (l.vals[k] - sum) / l.vals[l.rowPtr[j+1]-1]
+				continue
+			}
+			d := l.vals[k] - sum
+			if d <= 0 {
+				return nil, fmt.Errorf("non positive pivot at %d", i)
+			}
+			l.vals[k] = math.Sqrt(d)
+		}
+	}
+	return &IC{l: l}, nil
+}
+
+//Apply sets dst to (LLᵀ)⁻¹*r, by forward and back substitution
+func (p *IC) Apply(dst, r []float64) {
+	l := p.l
+	for i := 0; i < l.r; i++ {
+		sum := r[i]
+		last := l.rowPtr[i+1] - 1
+		for k := l.rowPtr[i]; k < last; k++ {
+			sum -= l.vals[k] * dst[l.colInd[k]]
+		}
+		dst[i] = sum / l.vals[last]
+	}
+	for i := l.r - 1; i >= 0; i-- {
+		last := l.rowPtr[i+1] - 1
+		dst[i] /= l.vals[last]
+		for k := l.rowPtr[i]; k < last; k++ {
+			dst[l.colInd[k]] -= l.vals[k] * dst[i]
+		}
+	}
+}
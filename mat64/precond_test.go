@@ -0,0 +1,58 @@
+package mat
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+//laplacian returns the n*n tridiagonal matrix of the 1-D discrete laplacian
+func laplacian(n int) *CSR {
+	var rows, cols []int
+	var vals []float64
+	for i := 0; i < n; i++ {
+		rows, cols, vals = append(rows, i), append(cols, i), append(vals, 2)
+		if i > 0 {
+			rows, cols, vals = append(rows, i), append(cols, i-1), append(vals, -1)
+		}
+		if i < n-1 {
+			rows, cols, vals = append(rows, i), append(cols, i+1), append(vals, -1)
+		}
+	}
+	s, _ := NewCSR(n, n, rows, cols, vals)
+	return s
+}
+
+func TestPreconditioners(t *testing.T) {
+	te := tester.New(t)
+	//on a tridiagonal matrix, ILU(0) and IC(0) are exact factorizations
+	a := laplacian(6)
+	ilu, err := NewILU0(a)
+	te.CompareError(0, nil, err)
+	ic, err := NewIC0(a)
+	te.CompareError(1, nil, err)
+	jac, err := NewJacobi(a)
+	te.CompareError(2, nil, err)
+
+	x := []float64{1, -2, 3, 0.5, 4, -1}
+	b := make([]float64, 6)
+	a.MulVec(b, x)
+	for ind, p := range []Preconditioner{ilu, ic} {
+		res := make([]float64, 6)
+		p.Apply(res, b)
+		te.DeepEqual(ind, "exact", true, EqualApprox(NewM64(6, 1, x), NewM64(6, 1, res), 1e-12))
+	}
+	res := make([]float64, 6)
+	jac.Apply(res, []float64{2, 4, 6, 8, 10, 12})
+	te.DeepEqual(2, "jacobi", []float64{1, 2, 3, 4, 5, 6}, res)
+
+	_, err = NewJacobi(NewM64(2, 2, []float64{0, 1, 1, 0}))
+	te.CompareError(3, fmt.Errorf("zero on the diagonal at 0"), err)
+	s, _ := CSRFrom(NewM64(2, 2, []float64{0, 1, 1, 1}))
+	_, err = NewILU0(s)
+	te.CompareError(4, fmt.Errorf("missing diagonal value at 0"), err)
+	s, _ = CSRFrom(NewM64(2, 2, []float64{1, 2, 2, 1}))
+	_, err = NewIC0(s)
+	te.CompareError(5, fmt.Errorf("non positive pivot at 1"), err)
+}
@@ -0,0 +1,87 @@
+package mat
+
+import (
+	"fmt"
+
+	"github.com/twiggg/math/mat64/internal/kernel"
+)
+
+//Kron returns a new matrix as the Kronecker product of a and b: each a[i,j] is replaced by the block a[i,j]*b
+func Kron(a, b *M64) (*M64, error) {
+	if !a.Valid() {
+		return nil, fmt.Errorf("a is nil")
+	}
+	if !b.Valid() {
+		return nil, fmt.Errorf("b is nil")
+	}
+	c := a.c * b.c
+	res := NewM64(a.r*b.r, c, nil)
+	for i := 0; i < a.r; i++ {
+		for j := 0; j < a.c; j++ {
+			v := a.data[i*a.c+j]
+			for k := 0; k < b.r; k++ {
+				row := res.data[(i*b.r+k)*c+j*b.c:]
+				kernel.Daxpy(v, b.data[k*b.c:(k+1)*b.c], row[:b.c])
+			}
+		}
+	}
+	return res, nil
+}
+
+//Outer returns a new matrix as the outer product u*vᵀ. u and v are read as vectors, whatever their dims: the result has u.Size() rows and v.Size() colomns
+func Outer(u, v *M64) (*M64, error) {
+	res := NewM64(u.Size(), v.Size(), nil)
+	if err := OuterTo(res, u, v); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+//OuterTo sets dest to the outer product u*vᵀ. dest must have u.Size() rows and v.Size() colomns. dest may share data with u or v
+func OuterTo(dest, u, v *M64) error {
+	if !u.Valid() {
+		return fmt.Errorf("u is nil")
+	}
+	if !v.Valid() {
+		return fmt.Errorf("v is nil")
+	}
+	if !dest.Valid() {
+		return fmt.Errorf("dest is nil")
+	}
+	c := len(v.data)
+	if dest.r != len(u.data) || dest.c != c {
+		return fmt.Errorf("dest should be %dx%d", len(u.data), c)
+	}
+	//dest is zeroed before u and v are read, they are copied if they share its data
+	x, y := u.data, v.data
+	if shares(u, dest) {
+		x = append([]float64(nil), x...)
+	}
+	if shares(v, dest) {
+		y = append([]float64(nil), y...)
+	}
+	kernel.Dscal(0, dest.data)
+	kernel.Dger(len(x), c, 1, x, y, dest.data, c)
+	return nil
+}
+
+//KhatriRao returns a new matrix as the colomn-wise Kronecker product of a and b, which must have the same number of colomns
+func KhatriRao(a, b *M64) (*M64, error) {
+	if !a.Valid() {
+		return nil, fmt.Errorf("a is nil")
+	}
+	if !b.Valid() {
+		return nil, fmt.Errorf("b is nil")
+	}
+	if a.c != b.c {
+		return nil, fmt.Errorf("a,b colomns not equal")
+	}
+	c := a.c
+	res := NewM64(a.r*b.r, c, nil)
+	for i := 0; i < a.r; i++ {
+		for k := 0; k < b.r; k++ {
+			kernel.Dmul(a.data[i*c:(i+1)*c], b.data[k*c:(k+1)*c], res.data[(i*b.r+k)*c:(i*b.r+k+1)*c])
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,84 @@
+package mat
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestKron(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		a   *M64
+		b   *M64
+		res *M64
+		err error
+	}{
+		{
+			NewM64(2, 2, []float64{1, 2, 3, 4}), NewM64(1, 2, []float64{1, 10}),
+			NewM64(2, 4, []float64{1, 10, 2, 20, 3, 30, 4, 40}), nil,
+		},
+		{
+			NewM64(1, 2, []float64{1, 2}), NewM64(2, 1, []float64{1, 10}),
+			NewM64(2, 2, []float64{1, 2, 10, 20}), nil,
+		},
+		{nil, NewM64(1, 1, nil), nil, fmt.Errorf("a is nil")},
+	}
+	for ind, test := range tests {
+		res, err := Kron(test.a, test.b)
+		te.CompareError(ind, test.err, err)
+		if err == nil {
+			te.DeepEqual(ind, "res", test.res, res)
+		}
+	}
+}
+
+func TestOuter(t *testing.T) {
+	te := tester.New(t)
+	res, err := Outer(NewM64(2, 1, []float64{1, 2}), NewM64(3, 1, []float64{1, 2, 3}))
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "res", NewM64(2, 3, []float64{1, 2, 3, 2, 4, 6}), res)
+	_, err = Outer(NewM64(2, 1, nil), nil)
+	te.CompareError(1, fmt.Errorf("v is nil"), err)
+	//dest sharing the data of the operands
+	u := NewM64(1, 1, []float64{3})
+	te.CompareError(2, nil, OuterTo(u, u, u))
+	te.DeepEqual(2, "u*uᵀ", NewM64(1, 1, []float64{9}), u)
+	m := NewM64(2, 2, []float64{1, 2, 3, 4})
+	w, _ := Reshape(m, 4, 1)
+	v := NewM64(1, 1, []float64{2})
+	te.CompareError(3, nil, OuterTo(w, m, v))
+	te.DeepEqual(3, "aliased u", NewM64(4, 1, []float64{2, 4, 6, 8}), w)
+	d := NewM64(1, 4, []float64{1, 2, 3, 4})
+	e, _ := Reshape(d, 4, 1)
+	te.CompareError(4, nil, OuterTo(d, NewM64(1, 1, []float64{-1}), e))
+	te.DeepEqual(4, "aliased v", NewM64(1, 4, []float64{-1, -2, -3, -4}), d)
+}
+
+func TestKhatriRao(t *testing.T) {
+	te := tester.New(t)
+	a := NewM64(2, 2, []float64{1, 2, 3, 4})
+	b := NewM64(2, 2, []float64{1, 10, 100, 1000})
+	res, err := KhatriRao(a, b)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "res", NewM64(4, 2, []float64{1, 20, 100, 2000, 3, 40, 300, 4000}), res)
+	_, err = KhatriRao(a, NewM64(2, 3, nil))
+	te.CompareError(1, fmt.Errorf("a,b colomns not equal"), err)
+}
+
+func TestDivPowElem(t *testing.T) {
+	te := tester.New(t)
+	res, err := DivElem(NewM64(1, 3, []float64{2, 9, 1}), NewM64(1, 3, []float64{2, 3, 4}))
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "div", NewM64(1, 3, []float64{1, 3, 0.25}), res)
+	res, err = PowElem(NewM64(1, 3, []float64{2, 9, 4}), 0.5)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "pow", true, EqualApprox(NewM64(1, 3, []float64{1.4142135623730951, 3, 2}), res, 1e-15))
+	m := NewM64(1, 2, []float64{4, 6})
+	err = m.DivElem(NewM64(1, 2, []float64{2, 3}))
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "in place", NewM64(1, 2, []float64{2, 2}), m)
+	_, err = DivElem(nil, m)
+	te.CompareError(3, fmt.Errorf("m is nil"), err)
+}
@@ -0,0 +1,160 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+)
+
+//QR holds the QR decomposition of a r*c matrix with r>=c, by Householder reflections: A=Q*R, Q being r*c with orthonormal colomns and R c*c upper triangular
+type QR struct {
+	r, c int
+	//qr holds the Householder vectors on and below the diagonal, and R above it
+	qr    []float64
+	rdiag []float64
+}
+
+//NewQR returns the QR decomposition of a, which must have at least as many rows as colomns. a is not modified
+func NewQR(a *M64) (*QR, error) {
+	if !a.Valid() {
+		return nil, fmt.Errorf("a is nil")
+	}
+	if a.r < a.c {
+		return nil, fmt.Errorf("a has more colomns than rows")
+	}
+	r, c := a.r, a.c
+	f := &QR{r: r, c: c, qr: append([]float64(nil), a.data...), rdiag: make([]float64, c)}
+	qr := f.qr
+	for k := 0; k < c; k++ {
+		nrm := 0.0
+		for i := k; i < r; i++ {
+			nrm = math.Hypot(nrm, qr[i*c+k])
+		}
+		if nrm != 0 {
+			//reflect colomn k onto -nrm*e_k, the sign avoiding cancellation
+			if qr[k*c+k] < 0 {
+				nrm = -nrm
+			}
+			for i := k; i < r; i++ {
+				qr[i*c+k] /= nrm
+			}
+			qr[k*c+k]++
+			for j := k + 1; j < c; j++ {
+				s := 0.0
+				for i := k; i < r; i++ {
+					s += qr[i*c+k] * qr[i*c+j]
+				}
+				s = -s / qr[k*c+k]
+				for i := k; i < r; i++ {
+					qr[i*c+j] += s * qr[i*c+k]
+				}
+			}
+		}
+		f.rdiag[k] = -nrm
+	}
+	return f, nil
+}
+
+//FullRank returns true if R has no zero on its diagonal, relatively to rounding errors
+func (f *QR) FullRank() bool {
+	max := 0.0
+	for _, d := range f.rdiag {
+		max = math.Max(max, math.Abs(d))
+	}
+	tol := float64(f.r) * 2.220446049250313e-16 * max
+	for _, d := range f.rdiag {
+		if math.Abs(d) <= tol {
+			return false
+		}
+	}
+	return true
+}
+
+//R returns a new c*c matrix with the upper triangular factor
+func (f *QR) R() *M64 {
+	c := f.c
+	res := NewM64(c, c, nil)
+	for i := 0; i < c; i++ {
+		res.data[i*c+i] = f.rdiag[i]
+		for j := i + 1; j < c; j++ {
+			res.data[i*c+j] = f.qr[i*c+j]
+		}
+	}
+	return res
+}
+
+//Q returns a new r*c matrix with the orthonormal factor
+func (f *QR) Q() *M64 {
+	r, c := f.r, f.c
+	q := NewM64(r, c, nil)
+	qr := f.qr
+	for k := c - 1; k >= 0; k-- {
+		q.data[k*c+k] = 1
+		for j := k; j < c; j++ {
+			if qr[k*c+k] == 0 {
+				continue
+			}
+			s := 0.0
+			for i := k; i < r; i++ {
+				s += qr[i*c+k] * q.data[i*c+j]
+			}
+			s = -s / qr[k*c+k]
+			for i := k; i < r; i++ {
+				q.data[i*c+j] += s * qr[i*c+k]
+			}
+		}
+	}
+	return q
+}
+
+//Solve returns the c*b.c matrix x minimizing ||a*x-b|| (the solution of a*x=b if a is square). a must have full rank
+func (f *QR) Solve(b *M64) (*M64, error) {
+	if f == nil {
+		return nil, fmt.Errorf("decomposition is nil")
+	}
+	if !b.Valid() {
+		return nil, fmt.Errorf("b is nil")
+	}
+	if b.r != f.r {
+		return nil, fmt.Errorf("a,b rows not equal")
+	}
+	if !f.FullRank() {
+		return nil, fmt.Errorf("matrix is rank deficient")
+	}
+	r, c, nb := f.r, f.c, b.c
+	qr := f.qr
+	x := append([]float64(nil), b.data...)
+	//x = Qᵀ*b
+	for k := 0; k < c; k++ {
+		for j := 0; j < nb; j++ {
+			s := 0.0
+			for i := k; i < r; i++ {
+				s += qr[i*c+k] * x[i*nb+j]
+			}
+			s = -s / qr[k*c+k]
+			for i := k; i < r; i++ {
+				x[i*nb+j] += s * qr[i*c+k]
+			}
+		}
+	}
+	//R*x = Qᵀ*b, by back substitution
+	for k := c - 1; k >= 0; k-- {
+		for j := 0; j < nb; j++ {
+			x[k*nb+j] /= f.rdiag[k]
+		}
+		for i := 0; i < k; i++ {
+			for j := 0; j < nb; j++ {
+				x[i*nb+j] -= x[k*nb+j] * qr[i*c+k]
+			}
+		}
+	}
+	return NewM64(c, nb, x[:c*nb]), nil
+}
+
+//LeastSquares returns x minimizing ||a*x-b||, using the QR decomposition of a
+func LeastSquares(a, b *M64) (*M64, error) {
+	f, err := NewQR(a)
+	if err != nil {
+		return nil, err
+	}
+	return f.Solve(b)
+}
@@ -0,0 +1,56 @@
+package mat
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestQR(t *testing.T) {
+	te := tester.New(t)
+	src := rand.NewSource(3)
+	a := NewNormal(6, 4, src, 0, 1)
+	f, err := NewQR(a)
+	te.CompareError(0, nil, err)
+	q, r := f.Q(), f.R()
+	qr, _ := Mul(q, r)
+	te.DeepEqual(0, "q*r", true, EqualApprox(a, qr, 1e-13))
+	qt, _ := Transpose(q)
+	qtq, _ := Mul(qt, q)
+	te.DeepEqual(0, "orthonormal", true, EqualApprox(Identity(4), qtq, 1e-13))
+	for i := 0; i < 4; i++ {
+		for j := 0; j < i; j++ {
+			te.DeepEqual(0, "upper", 0.0, r.At(i, j))
+		}
+	}
+
+	//square system
+	sq := NewNormal(5, 5, src, 0, 1)
+	b := NewNormal(5, 2, src, 0, 1)
+	x, err := LeastSquares(sq, b)
+	te.CompareError(1, nil, err)
+	exp, _ := Solve(sq, b)
+	te.DeepEqual(1, "square", true, EqualApprox(exp, x, 1e-12))
+
+	//line fit through y=2x+1 with symmetric noise
+	design := NewM64(4, 2, []float64{1, 0, 1, 1, 1, 2, 1, 3})
+	y := NewM64(4, 1, []float64{1.1, 2.9, 5.1, 6.9})
+	x, err = LeastSquares(design, y)
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "line", true, EqualApprox(NewM64(2, 1, []float64{1.06, 1.96}), x, 1e-13))
+	//the residual is orthogonal to the colomns
+	ax, _ := Mul(design, x)
+	res, _ := Sub(y, ax)
+	dt, _ := Transpose(design)
+	ortho, _ := Mul(dt, res)
+	te.DeepEqual(2, "normal equations", true, EqualApprox(NewM64(2, 1, nil), ortho, 1e-13))
+
+	_, err = NewQR(NewM64(2, 3, nil))
+	te.CompareError(3, fmt.Errorf("a has more colomns than rows"), err)
+	_, err = LeastSquares(NewM64(3, 2, []float64{1, 2, 2, 4, 3, 6}), NewM64(3, 1, nil))
+	te.CompareError(4, fmt.Errorf("matrix is rank deficient"), err)
+	_, err = LeastSquares(design, NewM64(3, 1, nil))
+	te.CompareError(5, fmt.Errorf("a,b rows not equal"), err)
+}
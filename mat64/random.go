@@ -0,0 +1,143 @@
+package mat
+
+import (
+	"math"
+	"math/rand"
+)
+
+//Sampler draws a single value from a distribution, using rnd as the source of randomness
+type Sampler func(rnd *rand.Rand) float64
+
+//Uniform returns a Sampler of the uniform distribution on [min,max)
+func Uniform(min, max float64) Sampler {
+	return func(rnd *rand.Rand) float64 {
+		return min + (max-min)*rnd.Float64()
+	}
+}
+
+//Normal returns a Sampler of the normal distribution N(mean,std²)
+func Normal(mean, std float64) Sampler {
+	return func(rnd *rand.Rand) float64 {
+		return mean + std*rnd.NormFloat64()
+	}
+}
+
+//TruncNormal returns a Sampler of the normal distribution N(mean,std²), truncated to [mean-k*std,mean+k*std]. Values out of bounds are redrawn
+func TruncNormal(mean, std, k float64) Sampler {
+	k = math.Abs(k)
+	if k == 0 {
+		return func(rnd *rand.Rand) float64 { return mean }
+	}
+	return func(rnd *rand.Rand) float64 {
+		x := rnd.NormFloat64()
+		for math.Abs(x) > k {
+			x = rnd.NormFloat64()
+		}
+		return mean + std*x
+	}
+}
+
+//Bernoulli returns a Sampler yielding 1 with probability p and 0 otherwise
+func Bernoulli(p float64) Sampler {
+	return func(rnd *rand.Rand) float64 {
+		if rnd.Float64() < p {
+			return 1
+		}
+		return 0
+	}
+}
+
+//NewRandom returns a new r*c matrix filled with values drawn from sample, using src as the source of randomness. Returns nil if src or sample is nil
+func NewRandom(r, c int, src rand.Source, sample Sampler) *M64 {
+	if src == nil || sample == nil {
+		return nil
+	}
+	rnd := rand.New(src)
+	m := NewM64(r, c, nil)
+	for i := range m.data {
+		m.data[i] = sample(rnd)
+	}
+	return m
+}
+
+//NewUniform returns a new r*c matrix with values drawn uniformly from [min,max)
+func NewUniform(r, c int, src rand.Source, min, max float64) *M64 {
+	return NewRandom(r, c, src, Uniform(min, max))
+}
+
+//NewNormal returns a new r*c matrix with values drawn from N(mean,std²)
+func NewNormal(r, c int, src rand.Source, mean, std float64) *M64 {
+	return NewRandom(r, c, src, Normal(mean, std))
+}
+
+//NewTruncNormal returns a new r*c matrix with values drawn from N(mean,std²), truncated at k standard deviations from the mean
+func NewTruncNormal(r, c int, src rand.Source, mean, std, k float64) *M64 {
+	return NewRandom(r, c, src, TruncNormal(mean, std, k))
+}
+
+//NewBernoulli returns a new r*c mask: each value is 1 with probability p and 0 otherwise
+func NewBernoulli(r, c int, src rand.Source, p float64) *M64 {
+	return NewRandom(r, c, src, Bernoulli(p))
+}
+
+//NewOrthogonal returns a random n*n orthogonal matrix, distributed uniformly (Haar measure) over the orthogonal group
+func NewOrthogonal(n int, src rand.Source) *M64 {
+	//the Q factor of a gaussian matrix, with R having a positive diagonal, is Haar distributed.
+	//modified Gram-Schmidt gives exactly that factor
+	q := NewNormal(n, n, src, 0, 1)
+	if q == nil {
+		return nil
+	}
+	n = q.r
+	col := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			col[i] = q.At(i, j)
+		}
+		//orthogonalize twice to keep the loss of orthogonality at machine precision
+		for pass := 0; pass < 2; pass++ {
+			for k := 0; k < j; k++ {
+				dot := 0.0
+				for i := 0; i < n; i++ {
+					dot += q.At(i, k) * col[i]
+				}
+				for i := 0; i < n; i++ {
+					col[i] -= dot * q.At(i, k)
+				}
+			}
+		}
+		norm := 0.0
+		for i := 0; i < n; i++ {
+			norm += col[i] * col[i]
+		}
+		norm = math.Sqrt(norm)
+		for i := 0; i < n; i++ {
+			q.Set(i, j, col[i]/norm)
+		}
+	}
+	return q
+}
+
+//NewSPD returns a random n*n symmetric positive definite matrix, computed as A*Aᵀ+n*I with A gaussian
+func NewSPD(n int, src rand.Source) *M64 {
+	a := NewNormal(n, n, src, 0, 1)
+	if a == nil {
+		return nil
+	}
+	n = a.r
+	res := NewM64(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			sum := 0.0
+			for k := 0; k < n; k++ {
+				sum += a.At(i, k) * a.At(j, k)
+			}
+			if i == j {
+				sum += float64(n)
+			}
+			res.Set(i, j, sum)
+			res.Set(j, i, sum)
+		}
+	}
+	return res
+}
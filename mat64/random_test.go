@@ -0,0 +1,112 @@
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestNewRandomBounds(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		m   *M64
+		min float64
+		max float64
+	}{
+		{NewUniform(4, 5, rand.NewSource(1), -2, 3), -2, 3},
+		{NewTruncNormal(10, 10, rand.NewSource(2), 1, 0.5, 2), 0, 2},
+		{NewBernoulli(6, 6, rand.NewSource(3), 0.3), 0, 1},
+	}
+	for ind, test := range tests {
+		ok := true
+		for _, v := range test.m.data {
+			if v < test.min || v > test.max {
+				ok = false
+			}
+		}
+		te.DeepEqual(ind, "in bounds", true, ok)
+	}
+}
+
+func TestNewRandomNil(t *testing.T) {
+	te := tester.New(t)
+	te.DeepEqual(0, "nil source", (*M64)(nil), NewNormal(2, 2, nil, 0, 1))
+	te.DeepEqual(1, "nil sampler", (*M64)(nil), NewRandom(2, 2, rand.NewSource(1), nil))
+}
+
+func TestNewBernoulliMask(t *testing.T) {
+	te := tester.New(t)
+	m := NewBernoulli(3, 4, rand.NewSource(7), 0.5)
+	ok := true
+	for _, v := range m.data {
+		if v != 0 && v != 1 {
+			ok = false
+		}
+	}
+	te.DeepEqual(0, "binary", true, ok)
+	te.DeepEqual(1, "all ones", NewM64(2, 2, []float64{1, 1, 1, 1}), NewBernoulli(2, 2, rand.NewSource(7), 1))
+}
+
+func TestNewOrthogonal(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		n int
+	}{
+		{1}, {3}, {8},
+	}
+	for ind, test := range tests {
+		q := NewOrthogonal(test.n, rand.NewSource(int64(ind)))
+		ok := true
+		for i := 0; i < test.n; i++ {
+			for j := 0; j < test.n; j++ {
+				dot := 0.0
+				for k := 0; k < test.n; k++ {
+					dot += q.At(k, i) * q.At(k, j)
+				}
+				exp := 0.0
+				if i == j {
+					exp = 1
+				}
+				if math.Abs(dot-exp) > 1e-12 {
+					ok = false
+				}
+			}
+		}
+		te.DeepEqual(ind, "QᵀQ=I", true, ok)
+	}
+}
+
+func TestNewSPD(t *testing.T) {
+	te := tester.New(t)
+	for ind, n := range []int{1, 4, 7} {
+		m := NewSPD(n, rand.NewSource(int64(ind)))
+		ok := true
+		for i := 0; i < n; i++ {
+			if m.At(i, i) <= 0 {
+				ok = false
+			}
+			for j := 0; j < n; j++ {
+				if m.At(i, j) != m.At(j, i) {
+					ok = false
+				}
+			}
+		}
+		te.DeepEqual(ind, "symmetric", true, ok)
+		//positive definite: every pivot of a gaussian elimination is >0
+		a := NewM64(n, n, append([]float64(nil), m.data...))
+		for k := 0; k < n; k++ {
+			if a.At(k, k) <= 0 {
+				ok = false
+			}
+			for i := k + 1; i < n; i++ {
+				f := a.At(i, k) / a.At(k, k)
+				for j := k; j < n; j++ {
+					a.Set(i, j, a.At(i, j)-f*a.At(k, j))
+				}
+			}
+		}
+		te.DeepEqual(ind, "positive pivots", true, ok)
+	}
+}
@@ -0,0 +1,190 @@
+package mat
+
+import "fmt"
+
+//axis values for Concat, Repeat and Split
+const (
+	//AxisRows stacks, repeats or splits along the rows (vertically)
+	AxisRows = 0
+	//AxisCols stacks, repeats or splits along the colomns (horizontally)
+	AxisCols = 1
+)
+
+func checkAxis(axis int) error {
+	if axis != AxisRows && axis != AxisCols {
+		return fmt.Errorf("axis must be %d or %d", AxisRows, AxisCols)
+	}
+	return nil
+}
+
+//Concat joins ms along axis: AxisRows stacks them vertically, AxisCols horizontally
+func Concat(axis int, ms ...*M64) (*M64, error) {
+	if axis == AxisCols {
+		return HStack(ms...)
+	}
+	if err := checkAxis(axis); err != nil {
+		return nil, err
+	}
+	return VStack(ms...)
+}
+
+//VStack returns a new matrix with the rows of ms, one after the other. All matrices must have the same number of colomns
+func VStack(ms ...*M64) (*M64, error) {
+	if len(ms) == 0 {
+		return nil, fmt.Errorf("nothing to stack")
+	}
+	r, c := 0, 0
+	for i, m := range ms {
+		if !m.Valid() {
+			return nil, fmt.Errorf("ms[%d] is nil", i)
+		}
+		if i == 0 {
+			c = m.c
+		}
+		if m.c != c {
+			return nil, fmt.Errorf("ms[%d] has %d colomns, expected %d", i, m.c, c)
+		}
+		r += m.r
+	}
+	data := make([]float64, 0, r*c)
+	for _, m := range ms {
+		data = append(data, m.data...)
+	}
+	return NewM64(r, c, data), nil
+}
+
+//HStack returns a new matrix with the colomns of ms, one after the other. All matrices must have the same number of rows
+func HStack(ms ...*M64) (*M64, error) {
+	if len(ms) == 0 {
+		return nil, fmt.Errorf("nothing to stack")
+	}
+	r, c := 0, 0
+	for i, m := range ms {
+		if !m.Valid() {
+			return nil, fmt.Errorf("ms[%d] is nil", i)
+		}
+		if i == 0 {
+			r = m.r
+		}
+		if m.r != r {
+			return nil, fmt.Errorf("ms[%d] has %d rows, expected %d", i, m.r, r)
+		}
+		c += m.c
+	}
+	data := make([]float64, 0, r*c)
+	for i := 0; i < r; i++ {
+		for _, m := range ms {
+			data = append(data, m.data[i*m.c:(i+1)*m.c]...)
+		}
+	}
+	return NewM64(r, c, data), nil
+}
+
+//Reshape returns a r*c matrix sharing the data of m, read row by row. r*c must equal the size of m
+func Reshape(m *M64, r, c int) (*M64, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	if r <= 0 || c <= 0 {
+		return nil, fmt.Errorf("dims must be >0")
+	}
+	if r*c != len(m.data) {
+		return nil, fmt.Errorf("cannot reshape %dx%d into %dx%d", m.r, m.c, r, c)
+	}
+	return &M64{r: r, c: c, data: m.data}, nil
+}
+
+//Flatten returns a colomn vector sharing the data of m, read row by row
+func Flatten(m *M64) (*M64, error) {
+	return Reshape(m, m.Size(), 1)
+}
+
+//Tile returns a new matrix made of nr*nc copies of m, nr vertically and nc horizontally
+func Tile(m *M64, nr, nc int) (*M64, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	if nr <= 0 || nc <= 0 {
+		return nil, fmt.Errorf("repetitions must be >0")
+	}
+	r, c := m.r*nr, m.c*nc
+	data := make([]float64, 0, r*c)
+	for i := 0; i < r; i++ {
+		row := m.data[(i%m.r)*m.c : (i%m.r+1)*m.c]
+		for k := 0; k < nc; k++ {
+			data = append(data, row...)
+		}
+	}
+	return NewM64(r, c, data), nil
+}
+
+//Repeat returns a new matrix where each row (AxisRows) or colomn (AxisCols) of m is repeated k times in a row
+func Repeat(m *M64, axis, k int) (*M64, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	if err := checkAxis(axis); err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return nil, fmt.Errorf("repetitions must be >0")
+	}
+	r, c := m.r, m.c
+	if axis == AxisRows {
+		r *= k
+	} else {
+		c *= k
+	}
+	res := NewM64(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if axis == AxisRows {
+				res.Set(i, j, m.At(i/k, j))
+			} else {
+				res.Set(i, j, m.At(i, j/k))
+			}
+		}
+	}
+	return res, nil
+}
+
+//Split cuts m along axis into parts of the given sizes, which must add up to the number of rows (AxisRows) or colomns (AxisCols). Parts are new matrices
+func Split(m *M64, axis int, sizes ...int) ([]*M64, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	if err := checkAxis(axis); err != nil {
+		return nil, err
+	}
+	total := m.r
+	if axis == AxisCols {
+		total = m.c
+	}
+	sum := 0
+	for i, s := range sizes {
+		if s <= 0 {
+			return nil, fmt.Errorf("sizes[%d] must be >0", i)
+		}
+		sum += s
+	}
+	if sum != total {
+		return nil, fmt.Errorf("sizes add up to %d, expected %d", sum, total)
+	}
+	res := make([]*M64, len(sizes))
+	start := 0
+	for p, s := range sizes {
+		if axis == AxisRows {
+			data := make([]float64, s*m.c)
+			copy(data, m.data[start*m.c:(start+s)*m.c])
+			res[p] = NewM64(s, m.c, data)
+		} else {
+			data := make([]float64, 0, m.r*s)
+			for i := 0; i < m.r; i++ {
+				data = append(data, m.data[i*m.c+start:i*m.c+start+s]...)
+			}
+			res[p] = NewM64(m.r, s, data)
+		}
+		start += s
+	}
+	return res, nil
+}
@@ -0,0 +1,110 @@
+package mat
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestConcat(t *testing.T) {
+	te := tester.New(t)
+	a := NewM64(2, 2, []float64{1, 2, 3, 4})
+	b := NewM64(2, 1, []float64{5, 6})
+	c := NewM64(1, 2, []float64{7, 8})
+	tests := []struct {
+		axis int
+		ms   []*M64
+		res  *M64
+		err  error
+	}{
+		{AxisCols, []*M64{a, b}, NewM64(2, 3, []float64{1, 2, 5, 3, 4, 6}), nil},
+		{AxisRows, []*M64{a, c}, NewM64(3, 2, []float64{1, 2, 3, 4, 7, 8}), nil},
+		{AxisRows, []*M64{c}, NewM64(1, 2, []float64{7, 8}), nil},
+		{AxisCols, []*M64{a, c}, nil, fmt.Errorf("ms[1] has 1 rows, expected 2")},
+		{AxisRows, []*M64{a, b}, nil, fmt.Errorf("ms[1] has 1 colomns, expected 2")},
+		{AxisRows, []*M64{a, nil}, nil, fmt.Errorf("ms[1] is nil")},
+		{AxisRows, nil, nil, fmt.Errorf("nothing to stack")},
+		{2, []*M64{a}, nil, fmt.Errorf("axis must be 0 or 1")},
+	}
+	for ind, test := range tests {
+		res, err := Concat(test.axis, test.ms...)
+		te.CompareError(ind, test.err, err)
+		if err == nil {
+			te.DeepEqual(ind, "res", test.res, res)
+		}
+	}
+}
+
+func TestReshape(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		m   *M64
+		r   int
+		c   int
+		res *M64
+		err error
+	}{
+		{NewM64(2, 3, []float64{1, 2, 3, 4, 5, 6}), 3, 2, NewM64(3, 2, []float64{1, 2, 3, 4, 5, 6}), nil},
+		{NewM64(2, 3, []float64{1, 2, 3, 4, 5, 6}), 6, 1, NewM64(6, 1, []float64{1, 2, 3, 4, 5, 6}), nil},
+		{NewM64(2, 3, nil), 4, 2, nil, fmt.Errorf("cannot reshape 2x3 into 4x2")},
+		{NewM64(2, 3, nil), 0, 6, nil, fmt.Errorf("dims must be >0")},
+		{nil, 1, 1, nil, fmt.Errorf("m is nil")},
+	}
+	for ind, test := range tests {
+		res, err := Reshape(test.m, test.r, test.c)
+		te.CompareError(ind, test.err, err)
+		if err == nil {
+			te.DeepEqual(ind, "res", test.res, res)
+			//data is shared
+			res.Set(0, 0, -1)
+			te.DeepEqual(ind, "shared", -1.0, test.m.At(0, 0))
+		}
+	}
+	f, err := Flatten(NewM64(2, 2, []float64{1, 2, 3, 4}))
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "flatten", NewM64(4, 1, []float64{1, 2, 3, 4}), f)
+}
+
+func TestTileRepeat(t *testing.T) {
+	te := tester.New(t)
+	m := NewM64(2, 2, []float64{1, 2, 3, 4})
+	res, err := Tile(m, 2, 1)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "tile", NewM64(4, 2, []float64{1, 2, 3, 4, 1, 2, 3, 4}), res)
+	res, err = Tile(m, 1, 2)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "tile", NewM64(2, 4, []float64{1, 2, 1, 2, 3, 4, 3, 4}), res)
+	_, err = Tile(m, 0, 2)
+	te.CompareError(2, fmt.Errorf("repetitions must be >0"), err)
+
+	res, err = Repeat(m, AxisRows, 2)
+	te.CompareError(3, nil, err)
+	te.DeepEqual(3, "repeat", NewM64(4, 2, []float64{1, 2, 1, 2, 3, 4, 3, 4}), res)
+	res, err = Repeat(m, AxisCols, 2)
+	te.CompareError(4, nil, err)
+	te.DeepEqual(4, "repeat", NewM64(2, 4, []float64{1, 1, 2, 2, 3, 3, 4, 4}), res)
+}
+
+func TestSplit(t *testing.T) {
+	te := tester.New(t)
+	m := NewM64(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	tests := []struct {
+		axis  int
+		sizes []int
+		res   []*M64
+		err   error
+	}{
+		{AxisCols, []int{2, 1}, []*M64{NewM64(2, 2, []float64{1, 2, 4, 5}), NewM64(2, 1, []float64{3, 6})}, nil},
+		{AxisRows, []int{1, 1}, []*M64{NewM64(1, 3, []float64{1, 2, 3}), NewM64(1, 3, []float64{4, 5, 6})}, nil},
+		{AxisRows, []int{1, 2}, nil, fmt.Errorf("sizes add up to 3, expected 2")},
+		{AxisCols, []int{3, 0}, nil, fmt.Errorf("sizes[1] must be >0")},
+	}
+	for ind, test := range tests {
+		res, err := Split(m, test.axis, test.sizes...)
+		te.CompareError(ind, test.err, err)
+		if err == nil {
+			te.DeepEqual(ind, "res", test.res, res)
+		}
+	}
+}
@@ -64,3 +64,30 @@ func sameSize2(m, dest *M64) error {
 	}
 	return nil
 }
+
+func transposeSize(m, dest *M64) error {
+	if !m.Valid() {
+		return fmt.Errorf("m is nil")
+	}
+	if !dest.Valid() {
+		return fmt.Errorf("dest is nil")
+	}
+	if m.r != dest.c {
+		return fmt.Errorf("m rows != dest colomns")
+	}
+	if m.c != dest.r {
+		return fmt.Errorf("m colomns != dest rows")
+	}
+	return nil
+}
+
+//structSize checks that a structured matrix with c colomns can be multiplied by b
+func structSize(c int, b *M64) error {
+	if !b.Valid() {
+		return fmt.Errorf("b is nil")
+	}
+	if b.r != c {
+		return fmt.Errorf("m colomns != b rows")
+	}
+	return nil
+}
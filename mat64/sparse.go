@@ -0,0 +1,126 @@
+package mat
+
+import (
+	"fmt"
+	"sort"
+)
+
+//CSR represents a r*c sparse matrix in compressed sparse row format: the values of row i are vals[rowPtr[i]:rowPtr[i+1]], at colomns colInd[rowPtr[i]:rowPtr[i+1]], sorted
+type CSR struct {
+	r      int
+	c      int
+	rowPtr []int
+	colInd []int
+	vals   []float64
+}
+
+//NewCSR returns a new r*c sparse matrix from triplets (rows[k],cols[k],vals[k]). Duplicated positions are summed
+func NewCSR(r, c int, rows, cols []int, vals []float64) (*CSR, error) {
+	if r <= 0 || c <= 0 {
+		return nil, fmt.Errorf("dims must be >0")
+	}
+	if len(rows) != len(vals) || len(cols) != len(vals) {
+		return nil, fmt.Errorf("rows, cols and vals must have the same length")
+	}
+	for k := range vals {
+		if rows[k] < 0 || rows[k] >= r || cols[k] < 0 || cols[k] >= c {
+			return nil, fmt.Errorf("triplet[%d]: index out of range", k)
+		}
+	}
+	order := make([]int, len(vals))
+	for k := range order {
+		order[k] = k
+	}
+	sort.Slice(order, func(a, b int) bool {
+		ka, kb := order[a], order[b]
+		if rows[ka] != rows[kb] {
+			return rows[ka] < rows[kb]
+		}
+		return cols[ka] < cols[kb]
+	})
+	s := &CSR{r: r, c: c, rowPtr: make([]int, r+1)}
+	for n, k := range order {
+		if n > 0 && rows[k] == rows[order[n-1]] && cols[k] == cols[order[n-1]] {
+			s.vals[len(s.vals)-1] += vals[k]
+			continue
+		}
+		s.colInd = append(s.colInd, cols[k])
+		s.vals = append(s.vals, vals[k])
+		s.rowPtr[rows[k]+1]++
+	}
+	for i := 0; i < r; i++ {
+		s.rowPtr[i+1] += s.rowPtr[i]
+	}
+	return s, nil
+}
+
+//CSRFrom returns a new sparse matrix with the non zero values of m
+func CSRFrom(m *M64) (*CSR, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	s := &CSR{r: m.r, c: m.c, rowPtr: make([]int, m.r+1)}
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			if v := m.data[i*m.c+j]; v != 0 {
+				s.colInd = append(s.colInd, j)
+				s.vals = append(s.vals, v)
+			}
+		}
+		s.rowPtr[i+1] = len(s.vals)
+	}
+	return s, nil
+}
+
+//Dims returns the number of rows and colomns
+func (s *CSR) Dims() (int, int) {
+	if s == nil {
+		return 0, 0
+	}
+	return s.r, s.c
+}
+
+//NNZ returns the number of stored values
+func (s *CSR) NNZ() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.vals)
+}
+
+//find returns the position of (i,j) in vals, or -1 if it is not stored
+func (s *CSR) find(i, j int) int {
+	from, to := s.rowPtr[i], s.rowPtr[i+1]
+	k := from + sort.SearchInts(s.colInd[from:to], j)
+	if k < to && s.colInd[k] == j {
+		return k
+	}
+	return -1
+}
+
+//At returns the value at position row=i,col=j. panics if s is nil or index out of range
+func (s *CSR) At(i, j int) float64 {
+	if i < 0 || j < 0 || i >= s.r || j >= s.c {
+		panic("index out of range")
+	}
+	if k := s.find(i, j); k >= 0 {
+		return s.vals[k]
+	}
+	return 0
+}
+
+//Dense returns a new M64 with the values of s
+func (s *CSR) Dense() *M64 {
+	return NewDense(s)
+}
+
+//MulVec sets dst to s*x
+func (s *CSR) MulVec(dst, x []float64) {
+	for i := 0; i < s.r; i++ {
+		sum := 0.0
+		for k := s.rowPtr[i]; k < s.rowPtr[i+1]; k++ {
+			sum += s.vals[k] * x[s.colInd[k]]
+		}
+		dst[i] = sum
+	}
+}
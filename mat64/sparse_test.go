@@ -0,0 +1,47 @@
+package mat
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestNewCSR(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		r    int
+		c    int
+		rows []int
+		cols []int
+		vals []float64
+		res  *M64
+		err  error
+	}{
+		{2, 3, []int{1, 0, 0, 1}, []int{2, 1, 0, 2}, []float64{1, 2, 3, 4}, NewM64(2, 3, []float64{3, 2, 0, 0, 0, 5}), nil},
+		{2, 2, nil, nil, nil, NewM64(2, 2, nil), nil},
+		{2, 2, []int{2}, []int{0}, []float64{1}, nil, fmt.Errorf("triplet[0]: index out of range")},
+		{2, 2, []int{0}, []int{0}, nil, nil, fmt.Errorf("rows, cols and vals must have the same length")},
+		{0, 2, nil, nil, nil, nil, fmt.Errorf("dims must be >0")},
+	}
+	for ind, test := range tests {
+		s, err := NewCSR(test.r, test.c, test.rows, test.cols, test.vals)
+		te.CompareError(ind, test.err, err)
+		if err == nil {
+			te.DeepEqual(ind, "res", test.res, s.Dense())
+		}
+	}
+}
+
+func TestCSRMulVec(t *testing.T) {
+	te := tester.New(t)
+	m := NewM64(3, 3, []float64{1, 0, 2, 0, 0, 3, 4, 5, 0})
+	s, err := CSRFrom(m)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "nnz", 5, s.NNZ())
+	x := []float64{1, 2, 3}
+	exp, res := make([]float64, 3), make([]float64, 3)
+	m.MulVec(exp, x)
+	s.MulVec(res, x)
+	te.DeepEqual(0, "mulvec", exp, res)
+}
@@ -0,0 +1,50 @@
+package mat
+
+//Matrix is anything with dims and readable elements. M64 and the structured types implement it
+type Matrix interface {
+	Dims() (int, int)
+	At(i, j int) float64
+}
+
+//NewDense returns a new M64 holding a copy of the elements of a
+func NewDense(a Matrix) *M64 {
+	if a == nil {
+		return nil
+	}
+	r, c := a.Dims()
+	res := NewM64(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			res.data[i*c+j] = a.At(i, j)
+		}
+	}
+	return res
+}
+
+//Identity returns the n*n identity matrix
+func Identity(n int) *M64 {
+	return Eye(n, n, 0)
+}
+
+//Diag returns a square matrix with v on its diagonal
+func Diag(v []float64) *M64 {
+	n := len(v)
+	res := NewM64(n, n, nil)
+	for i, x := range v {
+		res.data[i*n+i] = x
+	}
+	return res
+}
+
+//Eye returns a r*c matrix with ones on its k-th diagonal: k=0 is the main diagonal, k>0 above and k<0 below
+func Eye(r, c, k int) *M64 {
+	res := NewM64(r, c, nil)
+	r, c = res.Dims()
+	for i := 0; i < r; i++ {
+		j := i + k
+		if j >= 0 && j < c {
+			res.data[i*c+j] = 1
+		}
+	}
+	return res
+}
@@ -0,0 +1,25 @@
+package mat
+
+import (
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestSpecial(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		m   *M64
+		res *M64
+	}{
+		{Identity(2), NewM64(2, 2, []float64{1, 0, 0, 1})},
+		{Diag([]float64{1, 2, 3}), NewM64(3, 3, []float64{1, 0, 0, 0, 2, 0, 0, 0, 3})},
+		{Eye(2, 3, 1), NewM64(2, 3, []float64{0, 1, 0, 0, 0, 1})},
+		{Eye(3, 2, -1), NewM64(3, 2, []float64{0, 0, 1, 0, 0, 1})},
+		{Eye(2, 2, 5), NewM64(2, 2, nil)},
+		{NewDense(Diag([]float64{4})), NewM64(1, 1, []float64{4})},
+	}
+	for ind, test := range tests {
+		te.DeepEqual(ind, "res", test.res, test.m)
+	}
+}
@@ -0,0 +1,103 @@
+package mat
+
+import (
+	"fmt"
+
+	"github.com/twiggg/math/mat64/internal/kernel"
+)
+
+//Symmetric represents a n*n symmetric matrix. Only the upper triangle is stored, packed row by row
+type Symmetric struct {
+	n    int
+	data []float64
+}
+
+//NewSymmetric returns a new n*n symmetric matrix. data holds the n*(n+1)/2 values of the upper triangle row by row, zeros are used if its length does not match
+func NewSymmetric(n int, data []float64) *Symmetric {
+	if n <= 0 {
+		n = 1
+	}
+	s := &Symmetric{n: n}
+	if len(data) == n*(n+1)/2 {
+		s.data = data
+	} else {
+		s.data = make([]float64, n*(n+1)/2)
+	}
+	return s
+}
+
+//SymmetricFrom returns a new symmetric matrix with the upper triangle of the square matrix m
+func SymmetricFrom(m *M64) (*Symmetric, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	if m.r != m.c {
+		return nil, fmt.Errorf("m is not square")
+	}
+	s := NewSymmetric(m.r, nil)
+	for i := 0; i < s.n; i++ {
+		for j := i; j < s.n; j++ {
+			s.data[s.index(i, j)] = m.At(i, j)
+		}
+	}
+	return s, nil
+}
+
+//Dims returns the number of rows and colomns
+func (s *Symmetric) Dims() (int, int) {
+	if s == nil {
+		return 0, 0
+	}
+	return s.n, s.n
+}
+
+func (s *Symmetric) index(i, j int) int {
+	if i > j {
+		i, j = j, i
+	}
+	return i*s.n - i*(i-1)/2 + j - i
+}
+
+//At returns the value at position row=i,col=j. panics if s is nil or index out of range
+func (s *Symmetric) At(i, j int) float64 {
+	if i < 0 || j < 0 || i >= s.n || j >= s.n {
+		panic("index out of range")
+	}
+	return s.data[s.index(i, j)]
+}
+
+//Set sets val at positions (i,j) and (j,i). panics if s is nil or index out of range
+func (s *Symmetric) Set(i, j int, val float64) {
+	if i < 0 || j < 0 || i >= s.n || j >= s.n {
+		panic("index out of range")
+	}
+	s.data[s.index(i, j)] = val
+}
+
+//Dense returns a new M64 with the values of s
+func (s *Symmetric) Dense() *M64 {
+	return NewDense(s)
+}
+
+//Mul returns a new matrix as the dot product s*b, reading each stored value once
+func (s *Symmetric) Mul(b *M64) (*M64, error) {
+	if s == nil {
+		return nil, fmt.Errorf("m is nil")
+	}
+	if err := structSize(s.n, b); err != nil {
+		return nil, err
+	}
+	res := NewM64(s.n, b.c, nil)
+	ind := 0
+	for i := 0; i < s.n; i++ {
+		for k := i; k < s.n; k++ {
+			v := s.data[ind]
+			ind++
+			kernel.Daxpy(v, b.data[k*b.c:(k+1)*b.c], res.data[i*b.c:(i+1)*b.c])
+			if k != i {
+				kernel.Daxpy(v, b.data[i*b.c:(i+1)*b.c], res.data[k*b.c:(k+1)*b.c])
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,26 @@
+package mat
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestSymmetric(t *testing.T) {
+	te := tester.New(t)
+	s := NewSymmetric(3, []float64{1, 2, 3, 4, 5, 6})
+	te.DeepEqual(0, "dense", NewM64(3, 3, []float64{1, 2, 3, 2, 4, 5, 3, 5, 6}), s.Dense())
+	s.Set(2, 0, 7)
+	te.DeepEqual(1, "set", 7.0, s.At(0, 2))
+
+	src := rand.NewSource(2)
+	a := NewSPD(6, src)
+	sym, err := SymmetricFrom(a)
+	te.CompareError(2, nil, err)
+	b := NewNormal(6, 3, src, 0, 1)
+	exp, _ := Mul(a, b)
+	res, err := sym.Mul(b)
+	te.CompareError(3, nil, err)
+	te.DeepEqual(3, "mul", true, EqualApprox(exp, res, 1e-12))
+}
@@ -0,0 +1,419 @@
+package mat
+
+import (
+	"fmt"
+
+	"github.com/twiggg/math/mat64/internal/kernel"
+)
+
+//Tensor represents a n-dimensional float64 array. Elements are found in data through strides, so views (reshape, permute, slice) share the data of their parent
+type Tensor struct {
+	shape   []int
+	strides []int
+	offset  int
+	data    []float64
+}
+
+//rowMajor returns the strides of a contiguous array of the given shape
+func rowMajor(shape []int) []int {
+	strides := make([]int, len(shape))
+	s := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		strides[i] = s
+		s *= shape[i]
+	}
+	return strides
+}
+
+func shapeSize(shape []int) int {
+	s := 1
+	for _, d := range shape {
+		s *= d
+	}
+	return s
+}
+
+//NewTensor returns a new tensor of the given shape, initialized with data if its length matches, with zeros otherwise
+func NewTensor(data []float64, shape ...int) (*Tensor, error) {
+	if len(shape) == 0 {
+		return nil, fmt.Errorf("shape is empty")
+	}
+	for i, d := range shape {
+		if d <= 0 {
+			return nil, fmt.Errorf("shape[%d] must be >0", i)
+		}
+	}
+	s := shapeSize(shape)
+	if len(data) != s {
+		data = make([]float64, s)
+	}
+	shape = append([]int(nil), shape...)
+	return &Tensor{shape: shape, strides: rowMajor(shape), data: data}, nil
+}
+
+//TensorFrom returns a rank 2 tensor sharing the data of m
+func TensorFrom(m *M64) (*Tensor, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	return &Tensor{shape: []int{m.r, m.c}, strides: []int{m.c, 1}, data: m.data}, nil
+}
+
+//Shape returns a copy of the dims of t
+func (t *Tensor) Shape() []int {
+	if t == nil {
+		return nil
+	}
+	return append([]int(nil), t.shape...)
+}
+
+//Rank returns the number of dims of t
+func (t *Tensor) Rank() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.shape)
+}
+
+//Size returns the number of elements of t
+func (t *Tensor) Size() int {
+	if t == nil {
+		return 0
+	}
+	return shapeSize(t.shape)
+}
+
+func (t *Tensor) index(idx []int) int {
+	if len(idx) != len(t.shape) {
+		panic(fmt.Sprintf("expected %d indices", len(t.shape)))
+	}
+	pos := t.offset
+	for i, k := range idx {
+		if k < 0 || k >= t.shape[i] {
+			panic("index out of range")
+		}
+		pos += k * t.strides[i]
+	}
+	return pos
+}
+
+//At returns the value at position idx. panics if t is nil or index out of range
+func (t *Tensor) At(idx ...int) float64 {
+	return t.data[t.index(idx)]
+}
+
+//Set sets val at position idx. panics if t is nil or index out of range
+func (t *Tensor) Set(val float64, idx ...int) {
+	t.data[t.index(idx)] = val
+}
+
+//IsContiguous returns true if the elements of t are stored row by row without gaps
+func (t *Tensor) IsContiguous() bool {
+	s := 1
+	for i := len(t.shape) - 1; i >= 0; i-- {
+		if t.shape[i] != 1 && t.strides[i] != s {
+			return false
+		}
+		s *= t.shape[i]
+	}
+	return true
+}
+
+//each calls fn with the position in data of every element of t, in row major order
+func (t *Tensor) each(fn func(pos int)) {
+	idx := make([]int, len(t.shape))
+	pos := t.offset
+	for n := t.Size(); n > 0; n-- {
+		fn(pos)
+		for d := len(idx) - 1; d >= 0; d-- {
+			idx[d]++
+			pos += t.strides[d]
+			if idx[d] < t.shape[d] {
+				break
+			}
+			pos -= idx[d] * t.strides[d]
+			idx[d] = 0
+		}
+	}
+}
+
+//Contiguous returns t if it is contiguous, a contiguous copy otherwise
+func (t *Tensor) Contiguous() *Tensor {
+	if t.IsContiguous() {
+		return t
+	}
+	return t.Clone()
+}
+
+//Clone returns a contiguous copy of t
+func (t *Tensor) Clone() *Tensor {
+	data := make([]float64, 0, t.Size())
+	t.each(func(pos int) {
+		data = append(data, t.data[pos])
+	})
+	shape := t.Shape()
+	return &Tensor{shape: shape, strides: rowMajor(shape), data: data}
+}
+
+//Data returns the elements of t in row major order, sharing the data of t if it is contiguous
+func (t *Tensor) Data() []float64 {
+	c := t.Contiguous()
+	return c.data[c.offset : c.offset+c.Size()]
+}
+
+//Reshape returns a tensor of the given shape with the elements of t. It is a view if t is contiguous, a copy otherwise. One dim may be -1 to be inferred
+func (t *Tensor) Reshape(shape ...int) (*Tensor, error) {
+	if t == nil {
+		return nil, fmt.Errorf("t is nil")
+	}
+	shape = append([]int(nil), shape...)
+	infer := -1
+	s := 1
+	for i, d := range shape {
+		switch {
+		case d == -1 && infer < 0:
+			infer = i
+		case d <= 0:
+			return nil, fmt.Errorf("shape[%d] must be >0", i)
+		default:
+			s *= d
+		}
+	}
+	if infer >= 0 {
+		if s == 0 || t.Size()%s != 0 {
+			return nil, fmt.Errorf("cannot infer shape[%d]", infer)
+		}
+		shape[infer] = t.Size() / s
+		s *= shape[infer]
+	}
+	if s != t.Size() {
+		return nil, fmt.Errorf("cannot reshape %v into %v", t.shape, shape)
+	}
+	c := t.Contiguous()
+	return &Tensor{shape: shape, strides: rowMajor(shape), offset: c.offset, data: c.data}, nil
+}
+
+//Permute returns a view of t with its dims reordered: dim i of the result is dim axes[i] of t
+func (t *Tensor) Permute(axes ...int) (*Tensor, error) {
+	if t == nil {
+		return nil, fmt.Errorf("t is nil")
+	}
+	if len(axes) != len(t.shape) {
+		return nil, fmt.Errorf("expected %d axes", len(t.shape))
+	}
+	seen := make([]bool, len(axes))
+	v := &Tensor{shape: make([]int, len(axes)), strides: make([]int, len(axes)), offset: t.offset, data: t.data}
+	for i, a := range axes {
+		if a < 0 || a >= len(axes) || seen[a] {
+			return nil, fmt.Errorf("axes must be a permutation of 0..%d", len(axes)-1)
+		}
+		seen[a] = true
+		v.shape[i] = t.shape[a]
+		v.strides[i] = t.strides[a]
+	}
+	return v, nil
+}
+
+//Transpose returns a view of t with its last two dims swapped
+func (t *Tensor) Transpose() (*Tensor, error) {
+	if t.Rank() < 2 {
+		return nil, fmt.Errorf("rank must be >=2")
+	}
+	axes := make([]int, len(t.shape))
+	for i := range axes {
+		axes[i] = i
+	}
+	n := len(axes)
+	axes[n-2], axes[n-1] = axes[n-1], axes[n-2]
+	return t.Permute(axes...)
+}
+
+//Slice returns a view of t restricted to [from,to) along axis
+func (t *Tensor) Slice(axis, from, to int) (*Tensor, error) {
+	if t == nil {
+		return nil, fmt.Errorf("t is nil")
+	}
+	if axis < 0 || axis >= len(t.shape) {
+		return nil, fmt.Errorf("axis out of range")
+	}
+	if from < 0 || to > t.shape[axis] || from >= to {
+		return nil, fmt.Errorf("invalid range [%d,%d) for dim %d", from, to, t.shape[axis])
+	}
+	v := &Tensor{shape: t.Shape(), strides: append([]int(nil), t.strides...), offset: t.offset + from*t.strides[axis], data: t.data}
+	v.shape[axis] = to - from
+	return v, nil
+}
+
+//Index returns a view of t at position i along axis, with one dim less: the i-th sample of a batch for axis 0
+func (t *Tensor) Index(axis, i int) (*Tensor, error) {
+	if t.Rank() < 2 {
+		return nil, fmt.Errorf("rank must be >=2")
+	}
+	v, err := t.Slice(axis, i, i+1)
+	if err != nil {
+		return nil, err
+	}
+	v.shape = append(v.shape[:axis], v.shape[axis+1:]...)
+	v.strides = append(v.strides[:axis], v.strides[axis+1:]...)
+	return v, nil
+}
+
+//M64 returns t as a matrix. t must have rank 2. The data is shared if t is contiguous, copied otherwise
+func (t *Tensor) M64() (*M64, error) {
+	if t.Rank() != 2 {
+		return nil, fmt.Errorf("rank must be 2")
+	}
+	return &M64{r: t.shape[0], c: t.shape[1], data: t.Data()}, nil
+}
+
+//broadcastShape returns the shape of a op b, numpy style: dims are aligned on the right, and dims of size 1 are stretched
+func broadcastShape(a, b []int) ([]int, error) {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	shape := make([]int, n)
+	for i := 1; i <= n; i++ {
+		da, db := 1, 1
+		if i <= len(a) {
+			da = a[len(a)-i]
+		}
+		if i <= len(b) {
+			db = b[len(b)-i]
+		}
+		switch {
+		case da == db || db == 1:
+			shape[n-i] = da
+		case da == 1:
+			shape[n-i] = db
+		default:
+			return nil, fmt.Errorf("shapes %v and %v are not broadcastable", a, b)
+		}
+	}
+	return shape, nil
+}
+
+//expand returns a view of t with the given (broadcast) shape: stretched dims get a 0 stride
+func (t *Tensor) expand(shape []int) *Tensor {
+	n := len(shape)
+	v := &Tensor{shape: shape, strides: make([]int, n), offset: t.offset, data: t.data}
+	for i := 1; i <= len(t.shape); i++ {
+		if t.shape[len(t.shape)-i] != 1 {
+			v.strides[n-i] = t.strides[len(t.shape)-i]
+		}
+	}
+	return v
+}
+
+//MapElemTensor returns a new tensor with fn applied to each pair of elements of a and b, broadcast to a common shape
+func MapElemTensor(a, b *Tensor, fn func(x, y float64) float64) (*Tensor, error) {
+	if a == nil {
+		return nil, fmt.Errorf("a is nil")
+	}
+	if b == nil {
+		return nil, fmt.Errorf("b is nil")
+	}
+	shape, err := broadcastShape(a.shape, b.shape)
+	if err != nil {
+		return nil, err
+	}
+	ea, eb := a.expand(shape), b.expand(shape)
+	res := &Tensor{shape: shape, strides: rowMajor(shape), data: make([]float64, shapeSize(shape))}
+	i := 0
+	ea.each(func(pos int) {
+		res.data[i] = a.data[pos]
+		i++
+	})
+	i = 0
+	eb.each(func(pos int) {
+		res.data[i] = fn(res.data[i], b.data[pos])
+		i++
+	})
+	return res, nil
+}
+
+//AddTensor returns a new tensor as a+b (element by element, broadcast)
+func AddTensor(a, b *Tensor) (*Tensor, error) {
+	return MapElemTensor(a, b, func(x, y float64) float64 { return x + y })
+}
+
+//SubTensor returns a new tensor as a-b (element by element, broadcast)
+func SubTensor(a, b *Tensor) (*Tensor, error) {
+	return MapElemTensor(a, b, func(x, y float64) float64 { return x - y })
+}
+
+//MulElemTensor returns a new tensor as a.*b (element by element, broadcast)
+func MulElemTensor(a, b *Tensor) (*Tensor, error) {
+	return MapElemTensor(a, b, func(x, y float64) float64 { return x * y })
+}
+
+//DivElemTensor returns a new tensor as a./b (element by element, broadcast)
+func DivElemTensor(a, b *Tensor) (*Tensor, error) {
+	return MapElemTensor(a, b, func(x, y float64) float64 { return x / y })
+}
+
+//BatchMul returns the matrix products of the last two dims of a (...,m,k) and b (...,k,n). Batch dims are broadcast
+func BatchMul(a, b *Tensor) (*Tensor, error) {
+	if a.Rank() < 2 {
+		return nil, fmt.Errorf("a: rank must be >=2")
+	}
+	if b.Rank() < 2 {
+		return nil, fmt.Errorf("b: rank must be >=2")
+	}
+	ra, rb := len(a.shape), len(b.shape)
+	m, k, k2, n := a.shape[ra-2], a.shape[ra-1], b.shape[rb-2], b.shape[rb-1]
+	if k != k2 {
+		return nil, fmt.Errorf("a colomns != b rows")
+	}
+	batch, err := broadcastShape(a.shape[:ra-2], b.shape[:rb-2])
+	if err != nil {
+		return nil, err
+	}
+	ea := a.expand(append(append([]int(nil), batch...), m, k))
+	eb := b.expand(append(append([]int(nil), batch...), k, n))
+	shape := append(append([]int(nil), batch...), m, n)
+	res := &Tensor{shape: shape, strides: rowMajor(shape), data: make([]float64, shapeSize(shape))}
+	//walk the batch dims of both operands at once, through a tensor of their offsets
+	nb := len(batch)
+	offA := &Tensor{shape: append(append([]int(nil), batch...), 1), strides: append(ea.strides[:nb:nb], 0), offset: ea.offset}
+	offB := &Tensor{shape: offA.shape, strides: append(eb.strides[:nb:nb], 0), offset: eb.offset}
+	var posB []int
+	offB.each(func(pos int) { posB = append(posB, pos) })
+	sa0, sa1 := ea.strides[nb], ea.strides[nb+1]
+	sb0, sb1 := eb.strides[nb], eb.strides[nb+1]
+	bufA, bufB := make([]float64, m*k), make([]float64, k*n)
+	out := 0
+	p := 0
+	offA.each(func(pa int) {
+		pb := posB[p]
+		p++
+		ma, lda, ta := gemmOperand(a.data, pa, sa0, sa1, m, k, bufA)
+		mb, ldb, tb := gemmOperand(b.data, pb, sb0, sb1, k, n, bufB)
+		kernel.Dgemm(ta, tb, m, n, k, 1, ma, lda, mb, ldb, 0, res.data[out:out+m*n], n)
+		out += m * n
+	})
+	return res, nil
+}
+
+//gemmOperand returns the r*c matrix at offset off of data with strides s0,s1 as a Dgemm operand: the data itself if it is row or colomn major, a copy in buf otherwise
+func gemmOperand(data []float64, off, s0, s1, r, c int, buf []float64) ([]float64, int, bool) {
+	//a single row or colomn may have any stride across it, the leading dimension is then never used
+	if s1 == 1 && s0 >= c {
+		return data[off:], s0, false
+	}
+	if s1 == 1 && r == 1 {
+		return data[off:], c, false
+	}
+	if s0 == 1 && s1 >= r {
+		return data[off:], s1, true
+	}
+	if s0 == 1 && c == 1 {
+		return data[off:], r, true
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			buf[i*c+j] = data[off+i*s0+j*s1]
+		}
+	}
+	return buf, c, false
+}
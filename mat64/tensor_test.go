@@ -0,0 +1,158 @@
+package mat
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func seq(n int) []float64 {
+	res := make([]float64, n)
+	for i := range res {
+		res[i] = float64(i)
+	}
+	return res
+}
+
+func TestNewTensor(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		shape []int
+		err   error
+	}{
+		{[]int{2, 3, 4}, nil},
+		{nil, fmt.Errorf("shape is empty")},
+		{[]int{2, 0}, fmt.Errorf("shape[1] must be >0")},
+	}
+	for ind, test := range tests {
+		tt, err := NewTensor(nil, test.shape...)
+		te.CompareError(ind, test.err, err)
+		if err == nil {
+			te.DeepEqual(ind, "shape", test.shape, tt.Shape())
+			te.DeepEqual(ind, "size", 24, tt.Size())
+		}
+	}
+	tt, _ := NewTensor(seq(24), 2, 3, 4)
+	te.DeepEqual(3, "at", 23.0, tt.At(1, 2, 3))
+	tt.Set(-1, 0, 1, 2)
+	te.DeepEqual(3, "set", -1.0, tt.data[6])
+}
+
+func TestTensorViews(t *testing.T) {
+	te := tester.New(t)
+	tt, _ := NewTensor(seq(24), 2, 3, 4)
+
+	r, err := tt.Reshape(4, -1)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "shape", []int{4, 6}, r.Shape())
+	r.Set(100, 0, 0)
+	te.DeepEqual(0, "shared", 100.0, tt.At(0, 0, 0))
+	tt.Set(0, 0, 0, 0)
+	_, err = tt.Reshape(5, -1)
+	te.CompareError(1, fmt.Errorf("cannot infer shape[1]"), err)
+
+	p, err := tt.Permute(2, 0, 1)
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "shape", []int{4, 2, 3}, p.Shape())
+	te.DeepEqual(2, "at", tt.At(1, 2, 3), p.At(3, 1, 2))
+	te.DeepEqual(2, "contiguous", false, p.IsContiguous())
+	_, err = tt.Permute(0, 0, 1)
+	te.CompareError(3, fmt.Errorf("axes must be a permutation of 0..2"), err)
+
+	//reshaping a non contiguous view copies it in row major order
+	tr, _ := tt.Transpose()
+	flat, err := tr.Reshape(-1)
+	te.CompareError(4, nil, err)
+	te.DeepEqual(4, "order", []float64{0, 4, 8, 1, 5, 9}, flat.Data()[:6])
+
+	s, err := tt.Slice(2, 1, 3)
+	te.CompareError(5, nil, err)
+	te.DeepEqual(5, "slice", []float64{1, 2, 5, 6, 9, 10, 13, 14, 17, 18, 21, 22}, s.Data())
+	_, err = tt.Slice(2, 3, 3)
+	te.CompareError(6, fmt.Errorf("invalid range [3,3) for dim 4"), err)
+
+	sample, err := tt.Index(0, 1)
+	te.CompareError(7, nil, err)
+	m, err := sample.M64()
+	te.CompareError(7, nil, err)
+	te.DeepEqual(7, "m64", NewM64(3, 4, seq(24)[12:]), m)
+	m.Set(0, 0, -5)
+	te.DeepEqual(7, "zero copy", -5.0, tt.At(1, 0, 0))
+	_, err = tt.M64()
+	te.CompareError(8, fmt.Errorf("rank must be 2"), err)
+
+	from, err := TensorFrom(m)
+	te.CompareError(9, nil, err)
+	te.DeepEqual(9, "from", []int{3, 4}, from.Shape())
+}
+
+func TestTensorBroadcast(t *testing.T) {
+	te := tester.New(t)
+	a, _ := NewTensor(seq(6), 2, 3)
+	row, _ := NewTensor([]float64{10, 20, 30}, 3)
+	col, _ := NewTensor([]float64{1, 2}, 2, 1)
+	res, err := AddTensor(a, row)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "row", []float64{10, 21, 32, 13, 24, 35}, res.Data())
+	res, err = MulElemTensor(col, a)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "col", []float64{0, 1, 2, 6, 8, 10}, res.Data())
+	res, err = SubTensor(col, row)
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "outer", []int{2, 3}, res.Shape())
+	te.DeepEqual(2, "outer", []float64{-9, -19, -29, -8, -18, -28}, res.Data())
+	bad, _ := NewTensor(nil, 2)
+	_, err = DivElemTensor(a, bad)
+	te.CompareError(3, fmt.Errorf("shapes [2 3] and [2] are not broadcastable"), err)
+}
+
+func TestBatchMul(t *testing.T) {
+	te := tester.New(t)
+	a, _ := NewTensor(seq(12), 2, 2, 3)
+	b, _ := NewTensor(seq(6), 3, 2)
+	res, err := BatchMul(a, b)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "shape", []int{2, 2, 2}, res.Shape())
+	for i := 0; i < 2; i++ {
+		ai, _ := a.Index(0, i)
+		am, _ := ai.M64()
+		bm, _ := b.M64()
+		exp, _ := Mul(am, bm)
+		ri, _ := res.Index(0, i)
+		rm, _ := ri.M64()
+		te.DeepEqual(i, "batch", exp, rm)
+	}
+	//transposed view as right operand
+	bt, _ := NewTensor([]float64{0, 2, 4, 1, 3, 5}, 2, 3)
+	btt, _ := bt.Transpose()
+	res2, err := BatchMul(a, btt)
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "view", res.Data(), res2.Data())
+	_, err = BatchMul(a, a)
+	te.CompareError(3, fmt.Errorf("a colomns != b rows"), err)
+	//colomn major batches
+	p, _ := a.Permute(2, 0, 1)
+	q, _ := p.Contiguous().Permute(1, 2, 0)
+	res4, err := BatchMul(q, b)
+	te.CompareError(4, nil, err)
+	te.DeepEqual(4, "colomn major", res.Data(), res4.Data())
+	//rows with a leading dimension, and strided views which are copied
+	wide, _ := NewTensor(seq(24), 2, 2, 6)
+	wide, _ = wide.Slice(2, 0, 3)
+	every, _ := NewTensor(seq(24), 2, 2, 3, 2)
+	every, _ = every.Index(3, 0)
+	for ind, v := range []*Tensor{wide, every} {
+		res, err := BatchMul(v, b)
+		te.CompareError(5+ind, nil, err)
+		for i := 0; i < 2; i++ {
+			vi, _ := v.Index(0, i)
+			vm, _ := vi.M64()
+			bm, _ := b.M64()
+			exp, _ := Mul(vm, bm)
+			ri, _ := res.Index(0, i)
+			rm, _ := ri.M64()
+			te.DeepEqual(5+ind, "view", exp, rm)
+		}
+	}
+}
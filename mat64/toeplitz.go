@@ -0,0 +1,72 @@
+package mat
+
+import (
+	"fmt"
+
+	"github.com/twiggg/math/mat64/internal/kernel"
+)
+
+//Toeplitz represents a matrix with constant diagonals, defined by its first colomn and first row
+type Toeplitz struct {
+	col []float64
+	row []float64
+}
+
+//NewToeplitz returns a new len(col)*len(row) Toeplitz matrix. col[0] and row[0] are both the main diagonal and must be equal
+func NewToeplitz(col, row []float64) (*Toeplitz, error) {
+	if len(col) == 0 || len(row) == 0 {
+		return nil, fmt.Errorf("col and row must not be empty")
+	}
+	if col[0] != row[0] {
+		return nil, fmt.Errorf("col[0] != row[0]")
+	}
+	return &Toeplitz{col: col, row: row}, nil
+}
+
+//Dims returns the number of rows and colomns
+func (t *Toeplitz) Dims() (int, int) {
+	if t == nil {
+		return 0, 0
+	}
+	return len(t.col), len(t.row)
+}
+
+//At returns the value at position row=i,col=j. panics if t is nil or index out of range
+func (t *Toeplitz) At(i, j int) float64 {
+	if i < 0 || j < 0 || i >= len(t.col) || j >= len(t.row) {
+		panic("index out of range")
+	}
+	if i >= j {
+		return t.col[i-j]
+	}
+	return t.row[j-i]
+}
+
+//Dense returns a new M64 with the values of t
+func (t *Toeplitz) Dense() *M64 {
+	return NewDense(t)
+}
+
+//Mul returns a new matrix as the dot product t*b, without building t
+func (t *Toeplitz) Mul(b *M64) (*M64, error) {
+	if t == nil {
+		return nil, fmt.Errorf("m is nil")
+	}
+	r, c := t.Dims()
+	if err := structSize(c, b); err != nil {
+		return nil, err
+	}
+	res := NewM64(r, b.c, nil)
+	for i := 0; i < r; i++ {
+		for k := 0; k < c; k++ {
+			v := t.row[0]
+			if i > k {
+				v = t.col[i-k]
+			} else if k > i {
+				v = t.row[k-i]
+			}
+			kernel.Daxpy(v, b.data[k*b.c:(k+1)*b.c], res.data[i*b.c:(i+1)*b.c])
+		}
+	}
+	return res, nil
+}
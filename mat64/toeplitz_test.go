@@ -0,0 +1,26 @@
+package mat
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestToeplitz(t *testing.T) {
+	te := tester.New(t)
+	tp, err := NewToeplitz([]float64{1, 2, 3}, []float64{1, 4})
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "dense", NewM64(3, 2, []float64{1, 4, 2, 1, 3, 2}), tp.Dense())
+	_, err = NewToeplitz([]float64{1, 2}, []float64{2, 4})
+	te.CompareError(1, fmt.Errorf("col[0] != row[0]"), err)
+
+	src := rand.NewSource(4)
+	tp, _ = NewToeplitz([]float64{1, -1, 0.5, 2}, []float64{1, 3, -2})
+	b := NewNormal(3, 2, src, 0, 1)
+	exp, _ := Mul(tp.Dense(), b)
+	res, err := tp.Mul(b)
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "mul", true, EqualApprox(exp, res, 1e-12))
+}
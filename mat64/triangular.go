@@ -0,0 +1,142 @@
+package mat
+
+import (
+	"fmt"
+
+	"github.com/twiggg/math/mat64/internal/kernel"
+)
+
+//Triangular represents a n*n upper or lower triangular matrix. Only the triangle is stored, packed row by row
+type Triangular struct {
+	n     int
+	upper bool
+	data  []float64
+}
+
+//NewTriangular returns a new n*n triangular matrix. data holds the n*(n+1)/2 values of the triangle row by row, zeros are used if its length does not match
+func NewTriangular(n int, upper bool, data []float64) *Triangular {
+	if n <= 0 {
+		n = 1
+	}
+	t := &Triangular{n: n, upper: upper}
+	if len(data) == n*(n+1)/2 {
+		t.data = data
+	} else {
+		t.data = make([]float64, n*(n+1)/2)
+	}
+	return t
+}
+
+//TriangularFrom returns a new triangular matrix with the upper or lower triangle of the square matrix m
+func TriangularFrom(m *M64, upper bool) (*Triangular, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	if m.r != m.c {
+		return nil, fmt.Errorf("m is not square")
+	}
+	t := NewTriangular(m.r, upper, nil)
+	for i := 0; i < t.n; i++ {
+		from, to := t.span(i)
+		for j := from; j < to; j++ {
+			t.data[t.index(i, j)] = m.At(i, j)
+		}
+	}
+	return t, nil
+}
+
+//Dims returns the number of rows and colomns
+func (t *Triangular) Dims() (int, int) {
+	if t == nil {
+		return 0, 0
+	}
+	return t.n, t.n
+}
+
+//IsUpper returns true if t is upper triangular
+func (t *Triangular) IsUpper() bool {
+	return t.upper
+}
+
+//span returns the colomns [from,to) of row i inside the triangle
+func (t *Triangular) span(i int) (int, int) {
+	if t.upper {
+		return i, t.n
+	}
+	return 0, i + 1
+}
+
+func (t *Triangular) inside(i, j int) bool {
+	if t.upper {
+		return j >= i
+	}
+	return j <= i
+}
+
+func (t *Triangular) index(i, j int) int {
+	if t.upper {
+		return i*t.n - i*(i-1)/2 + j - i
+	}
+	return i*(i+1)/2 + j
+}
+
+//At returns the value at position row=i,col=j, 0 outside the triangle. panics if t is nil or index out of range
+func (t *Triangular) At(i, j int) float64 {
+	if i < 0 || j < 0 || i >= t.n || j >= t.n {
+		panic("index out of range")
+	}
+	if !t.inside(i, j) {
+		return 0
+	}
+	return t.data[t.index(i, j)]
+}
+
+//Set sets val at position row=i,col=j. panics if t is nil or the position is outside the triangle
+func (t *Triangular) Set(i, j int, val float64) {
+	if i < 0 || j < 0 || i >= t.n || j >= t.n || !t.inside(i, j) {
+		panic("index out of triangle")
+	}
+	t.data[t.index(i, j)] = val
+}
+
+//Dense returns a new M64 with the values of t
+func (t *Triangular) Dense() *M64 {
+	return NewDense(t)
+}
+
+//Mul returns a new matrix as the dot product t*b, skipping the zeros of t
+func (t *Triangular) Mul(b *M64) (*M64, error) {
+	if t == nil {
+		return nil, fmt.Errorf("m is nil")
+	}
+	if err := structSize(t.n, b); err != nil {
+		return nil, err
+	}
+	res := NewM64(t.n, b.c, nil)
+	for i := 0; i < t.n; i++ {
+		from, to := t.span(i)
+		row := t.data[t.index(i, from):]
+		for k := from; k < to; k++ {
+			kernel.Daxpy(row[k-from], b.data[k*b.c:(k+1)*b.c], res.data[i*b.c:(i+1)*b.c])
+		}
+	}
+	return res, nil
+}
+
+//Solve returns x such that t*x=b, by forward (lower) or back (upper) substitution, in O(n²) per colomn of b
+func (t *Triangular) Solve(b *M64) (*M64, error) {
+	if t == nil {
+		return nil, fmt.Errorf("m is nil")
+	}
+	if err := structSize(t.n, b); err != nil {
+		return nil, err
+	}
+	for i := 0; i < t.n; i++ {
+		if t.data[t.index(i, i)] == 0 {
+			return nil, fmt.Errorf("matrix is singular")
+		}
+	}
+	x := NewM64(b.r, b.c, append([]float64(nil), b.data...))
+	kernel.Dtpsm(t.upper, t.n, b.c, t.data, x.data, b.c)
+	return x, nil
+}
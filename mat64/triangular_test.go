@@ -0,0 +1,46 @@
+package mat
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestTriangularAt(t *testing.T) {
+	te := tester.New(t)
+	up := NewTriangular(3, true, []float64{1, 2, 3, 4, 5, 6})
+	low := NewTriangular(3, false, []float64{1, 2, 3, 4, 5, 6})
+	te.DeepEqual(0, "upper", NewM64(3, 3, []float64{1, 2, 3, 0, 4, 5, 0, 0, 6}), up.Dense())
+	te.DeepEqual(1, "lower", NewM64(3, 3, []float64{1, 0, 0, 2, 3, 0, 4, 5, 6}), low.Dense())
+	from, err := TriangularFrom(NewM64(3, 3, []float64{1, 2, 3, 9, 4, 5, 9, 9, 6}), true)
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "from", up, from)
+	_, err = TriangularFrom(NewM64(2, 3, nil), true)
+	te.CompareError(3, fmt.Errorf("m is not square"), err)
+}
+
+func TestTriangularMulSolve(t *testing.T) {
+	te := tester.New(t)
+	src := rand.NewSource(1)
+	for ind, upper := range []bool{true, false} {
+		a := NewNormal(5, 5, src, 0, 1)
+		for i := 0; i < 5; i++ {
+			a.Set(i, i, 3+a.At(i, i))
+		}
+		tr, _ := TriangularFrom(a, upper)
+		b := NewNormal(5, 2, src, 0, 1)
+		exp, _ := Mul(tr.Dense(), b)
+		res, err := tr.Mul(b)
+		te.CompareError(ind, nil, err)
+		te.DeepEqual(ind, "mul", true, EqualApprox(exp, res, 1e-12))
+		x, err := tr.Solve(res)
+		te.CompareError(ind, nil, err)
+		te.DeepEqual(ind, "solve", true, EqualApprox(b, x, 1e-10))
+	}
+	_, err := NewTriangular(2, true, []float64{1, 1, 0}).Solve(NewM64(2, 1, nil))
+	te.CompareError(2, fmt.Errorf("matrix is singular"), err)
+	_, err = NewTriangular(2, true, nil).Solve(NewM64(3, 1, nil))
+	te.CompareError(3, fmt.Errorf("m colomns != b rows"), err)
+}
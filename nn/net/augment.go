@@ -0,0 +1,191 @@
+package nn
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/twiggg/math/dist"
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/math/stat"
+)
+
+//FitScaler fits sc on the inputs of a pass over d, as the rows of a matrix. d is reset before and after
+func FitScaler(d Dataset, sc stat.Scaler) error {
+	if d == nil {
+		return fmt.Errorf("dataset is nil")
+	}
+	if sc == nil {
+		return fmt.Errorf("scaler is nil")
+	}
+	d.Reset()
+	defer d.Reset()
+	var rows []float64
+	n, size := 0, 0
+	for p := d.Next(); p != nil; p = d.Next() {
+		r, c := p.Inp.Dims()
+		if n == 0 {
+			size = r * c
+		} else if r*c != size {
+			return fmt.Errorf("datapoint[%d]: input has %d values, expected %d", n, r*c, size)
+		}
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				rows = append(rows, p.Inp.At(i, j))
+			}
+		}
+		n++
+	}
+	if n == 0 {
+		return fmt.Errorf("dataset is empty")
+	}
+	x, err := mat.FromData(n, size, rows)
+	if err != nil {
+		return err
+	}
+	return sc.Fit(x)
+}
+
+//Normalize returns a new dataset whose inputs are transformed by the fitted scaler sc (see FitScaler), as a row
+func Normalize(d Dataset, sc stat.Scaler) (*Mapped, error) {
+	if sc == nil {
+		return nil, fmt.Errorf("scaler is nil")
+	}
+	return Map(d, func(p *Datapoint) (*Datapoint, error) {
+		r, c := p.Inp.Dims()
+		row := mat.NewM64(1, r*c, nil)
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				row.Set(0, i*c+j, p.Inp.At(i, j))
+			}
+		}
+		t, err := sc.Transform(row)
+		if err != nil {
+			return nil, err
+		}
+		return &Datapoint{Inp: mapInput(p.Inp, func(_ float64, i int) float64 { return t.At(0, i) }), Exp: p.Exp}, nil
+	})
+}
+
+//Noise returns a new dataset adding gaussian noise N(0,std²) drawn from src to each input value
+func Noise(d Dataset, src rand.Source, std float64) (*Mapped, error) {
+	if err := checkRandom(d, src); err != nil {
+		return nil, err
+	}
+	rnd := rand.New(src)
+	std = math.Abs(std)
+	return Map(d, func(p *Datapoint) (*Datapoint, error) {
+		return &Datapoint{Inp: mapInput(p.Inp, func(v float64, _ int) float64 { return v + std*rnd.NormFloat64() }), Exp: p.Exp}, nil
+	})
+}
+
+//Mask returns a new dataset setting each input value to 0 with probability ratio, drawn from src. ratio is clamped to [0,1]
+func Mask(d Dataset, src rand.Source, ratio float64) (*Mapped, error) {
+	if err := checkRandom(d, src); err != nil {
+		return nil, err
+	}
+	rnd := rand.New(src)
+	ratio = clampRatio(ratio)
+	return Map(d, func(p *Datapoint) (*Datapoint, error) {
+		return &Datapoint{Inp: mapInput(p.Inp, func(v float64, _ int) float64 {
+			if rnd.Float64() < ratio {
+				return 0
+			}
+			return v
+		}), Exp: p.Exp}, nil
+	})
+}
+
+//clampRatio returns ratio clamped to [0,1]
+func clampRatio(ratio float64) float64 {
+	return math.Max(0, math.Min(1, ratio))
+}
+
+//Mixed is a Dataset applying mixup to an inner dataset: each Datapoint is blended with the next one (the last with the first), inputs and expected outputs alike, with a weight drawn from Beta(alpha,alpha). The inner dataset should be shuffled
+type Mixed struct {
+	wrapper
+	rnd     *rand.Rand
+	beta    *dist.Beta
+	first   *Datapoint
+	pending *Datapoint
+	started bool
+	err     error
+}
+
+//Mixup returns a new mixup dataset over d, with weights drawn from src. alpha must be positive, 0.2 is common
+func Mixup(d Dataset, src rand.Source, alpha float64) (*Mixed, error) {
+	if err := checkRandom(d, src); err != nil {
+		return nil, err
+	}
+	b, err := dist.NewBeta(alpha, alpha)
+	if err != nil {
+		return nil, err
+	}
+	return &Mixed{wrapper: wrapper{inner: d}, rnd: rand.New(src), beta: b}, nil
+}
+
+//Next returns the next blended Datapoint, nil at the end or on error
+func (m *Mixed) Next() *Datapoint {
+	if m.err != nil {
+		return nil
+	}
+	if !m.started {
+		m.started = true
+		m.pending = m.inner.Next()
+		m.first = m.pending
+	}
+	a := m.pending
+	if a == nil {
+		return nil
+	}
+	m.pending = m.inner.Next()
+	b := m.pending
+	if b == nil {
+		b = m.first
+	}
+	lambda := m.beta.Rand(m.rnd)
+	inp, err := blend(a.Inp, b.Inp, lambda)
+	if err != nil {
+		m.err = fmt.Errorf("input: %s", err.Error())
+		return nil
+	}
+	exp, err := blend(a.Exp, b.Exp, lambda)
+	if err != nil {
+		m.err = fmt.Errorf("expected output: %s", err.Error())
+		return nil
+	}
+	return &Datapoint{Inp: inp, Exp: exp}
+}
+
+//blend returns a new matrix lambda*a+(1-lambda)*b
+func blend(a, b *mat.M64, lambda float64) (*mat.M64, error) {
+	if err := sameDims(a, b); err != nil {
+		return nil, err
+	}
+	_, c := a.Dims()
+	return mapInput(a, func(v float64, i int) float64 {
+		return lambda*v + (1-lambda)*b.At(i/c, i%c)
+	}), nil
+}
+
+//Left returns the number of Datapoints not served yet
+func (m *Mixed) Left() int {
+	if m.pending != nil {
+		return m.inner.Left() + 1
+	}
+	if m.started {
+		return 0
+	}
+	return m.inner.Left()
+}
+
+//Err returns the error which stopped the dataset, nil otherwise
+func (m *Mixed) Err() error {
+	return m.err
+}
+
+//Reset starts a new pass over the inner dataset, clearing the error
+func (m *Mixed) Reset() {
+	m.started, m.first, m.pending, m.err = false, nil, nil, nil
+	m.inner.Reset()
+}
@@ -0,0 +1,130 @@
+package nn
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/math/stat"
+	"github.com/twiggg/tester"
+)
+
+//newFittedZScore returns a z-score scaler fitted on the inputs of d
+func newFittedZScore(t *testing.T, d Dataset) *stat.ZScore {
+	z := stat.NewZScore()
+	if err := FitScaler(d, z); err != nil {
+		t.Fatal(err)
+	}
+	return z
+}
+
+func TestFitScaler(t *testing.T) {
+	te := tester.New(t)
+	d, _ := NewMemoryFromM64(mat.NewM64(3, 2, []float64{1, 2, 3, 2, 5, 2}), mat.NewM64(3, 1, nil))
+	z := newFittedZScore(t, d)
+	te.DeepEqual(0, "mean", []float64{3, 2}, z.Mean())
+	te.DeepEqual(0, "reset after", 3, d.Left())
+
+	n, err := Normalize(d, z)
+	te.CompareError(0, nil, err)
+	p := n.Next()
+	te.DeepEqual(0, "normalized", mat.NewM64(2, 1, []float64{-1, 0}), p.Inp)
+	te.DeepEqual(0, "targets untouched", d.At(0).Exp, p.Exp)
+	//a scaler fitted on other dims stops the dataset
+	other, _ := NewMemoryFromM64(mat.NewM64(1, 3, nil), mat.NewM64(1, 1, nil))
+	n, _ = Normalize(other, z)
+	te.DeepEqual(1, "stopped", (*Datapoint)(nil), n.Next())
+	te.CompareError(1, fmt.Errorf("x has 3 colomns, scaler was fitted on 2"), n.Err())
+
+	te.CompareError(2, fmt.Errorf("dataset is nil"), FitScaler(nil, z))
+	te.CompareError(3, fmt.Errorf("scaler is nil"), FitScaler(d, nil))
+	empty, _, _, _ := Split(d, nil, 0, 0)
+	te.CompareError(4, fmt.Errorf("dataset is empty"), FitScaler(empty, z))
+	mixed, _ := NewMemory(points(2, 2))
+	mixed.points[1] = &Datapoint{Inp: mat.NewM64(2, 1, nil), Exp: mixed.points[1].Exp}
+	te.CompareError(5, fmt.Errorf("datapoint[1]: input has 2 values, expected 1"), FitScaler(mixed, z))
+	_, err = Normalize(nil, z)
+	te.CompareError(6, fmt.Errorf("dataset is nil"), err)
+	_, err = Normalize(d, nil)
+	te.CompareError(7, fmt.Errorf("scaler is nil"), err)
+}
+
+func TestNoiseMask(t *testing.T) {
+	te := tester.New(t)
+	ones := mat.NewM64(1000, 1, nil)
+	for i := 0; i < 1000; i++ {
+		ones.Set(i, 0, 1)
+	}
+	d, _ := NewMemory([]*Datapoint{{Inp: ones, Exp: mat.NewM64(1, 1, nil)}})
+
+	n, err := Noise(d, rand.NewSource(1), 0.5)
+	te.CompareError(0, nil, err)
+	p := n.Next()
+	mean, sq := 0.0, 0.0
+	for i := 0; i < 1000; i++ {
+		v := p.Inp.At(i, 0) - 1
+		mean += v / 1000
+		sq += v * v / 1000
+	}
+	te.DeepEqual(0, "noise mean", true, math.Abs(mean) < 0.05)
+	te.DeepEqual(0, "noise std", true, math.Abs(math.Sqrt(sq)-0.5) < 0.05)
+	te.DeepEqual(0, "inner untouched", 1.0, ones.At(0, 0))
+
+	d.Reset()
+	m, err := Mask(d, rand.NewSource(1), 0.3)
+	te.CompareError(1, nil, err)
+	p = m.Next()
+	zeros := 0
+	for i := 0; i < 1000; i++ {
+		if p.Inp.At(i, 0) == 0 {
+			zeros++
+		}
+	}
+	te.DeepEqual(1, "masked", true, zeros > 250 && zeros < 350)
+	d.Reset()
+	m, _ = Mask(d, rand.NewSource(1), 3)
+	te.DeepEqual(2, "clamped", mat.NewM64(1000, 1, nil), m.Next().Inp)
+
+	_, err = Noise(d, nil, 1)
+	te.CompareError(3, fmt.Errorf("random source src is nil"), err)
+	_, err = Mask(nil, rand.NewSource(1), 1)
+	te.CompareError(4, fmt.Errorf("dataset is nil"), err)
+}
+
+func TestMixup(t *testing.T) {
+	te := tester.New(t)
+	d, _ := NewMemory(points(3, 2))
+	m, err := Mixup(d, rand.NewSource(1), 0.4)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "left", 3, m.Left())
+	var got []*Datapoint
+	for p := m.Next(); p != nil; p = m.Next() {
+		got = append(got, p)
+	}
+	te.DeepEqual(0, "served", 3, len(got))
+	te.DeepEqual(0, "left at end", 0, m.Left())
+	//each point is blended with the next one, the last with the first, with the same weight for inputs and outputs
+	next := []float64{1, 2, 0}
+	for i, p := range got {
+		x := p.Inp.At(0, 0)
+		lambda := (x - next[i]) / (float64(i) - next[i])
+		te.DeepEqual(i, "weight", true, lambda >= 0 && lambda <= 1)
+		y := d.At(i).Exp.At(0, 0)*lambda + d.At(int(next[i])).Exp.At(0, 0)*(1-lambda)
+		te.DeepEqual(i, "outputs", true, math.Abs(p.Exp.At(0, 0)-y) < 1e-12)
+	}
+	m.Reset()
+	te.DeepEqual(1, "reset", true, m.Next() != nil)
+	te.DeepEqual(1, "left", 2, m.Left())
+
+	_, err = Mixup(d, rand.NewSource(1), 0)
+	te.CompareError(2, fmt.Errorf("alpha and beta must be positive"), err)
+	_, err = Mixup(d, nil, 1)
+	te.CompareError(3, fmt.Errorf("random source src is nil"), err)
+	bad, _ := NewMemory(points(2, 2))
+	bad.points[1] = &Datapoint{Inp: mat.NewM64(2, 1, nil), Exp: bad.points[1].Exp}
+	m, _ = Mixup(bad, rand.NewSource(1), 1)
+	te.DeepEqual(4, "stopped", (*Datapoint)(nil), m.Next())
+	te.CompareError(4, fmt.Errorf("input: dims 2*1, expected 1*1"), m.Err())
+}
@@ -0,0 +1,378 @@
+package nn
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Missing is the policy applied to missing values of a delimited file: empty fields and the NA strings
+type Missing int
+
+const (
+	//MissingError fails on a missing value
+	MissingError Missing = iota
+	//MissingSkip drops the rows with a missing value
+	MissingSkip
+	//MissingZero replaces a missing value by 0 (an all zero vector for one-hot colomns)
+	MissingZero
+	//MissingMean replaces a missing value by the mean of the colomn (the frequencies of the categories for one-hot colomns). It needs a first pass over the data
+	MissingMean
+)
+
+//CSVConfig describes how Datapoints are read from a delimited file: each row is a Datapoint, whose input and expected output are colomn vectors made of the selected colomns. Zero values are replaced by defaults
+type CSVConfig struct {
+	//Comma is the field delimiter, ',' by default. Use '\t' for TSV
+	Comma rune
+	//Header is true if the first row holds the colomn names
+	Header bool
+	//Inputs are the indices of the input colomns, all colomns but the targets by default
+	Inputs []int
+	//Targets are the indices of the target colomns. Required
+	Targets []int
+	//OneHot are the target colomns holding categories, one-hot encoded into one value per category
+	OneHot []int
+	//Categories optionally fixes the categories of one-hot colomns, in order. Otherwise they are collected from the data and sorted
+	Categories map[int][]string
+	//Missing is the policy applied to missing values
+	Missing Missing
+	//NA are the strings read as missing values besides the empty field, {"NA"} by default
+	NA []string
+}
+
+//csvSchema holds what is known of a delimited file: the header, and the colomn statistics gathered by a first pass
+type csvSchema struct {
+	cfg     CSVConfig
+	header  []string
+	width   int
+	inputs  []int
+	oneHot  map[int]bool
+	na      map[string]bool
+	cats    map[int][]string
+	catInd  map[int]map[string]int
+	catSeen map[int]map[string]int
+	sums    map[int]float64
+	counts  map[int]int
+	rows    int
+}
+
+func newCSVSchema(cfg *CSVConfig) (*csvSchema, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	s := &csvSchema{cfg: *cfg, oneHot: map[int]bool{}, na: map[string]bool{}, cats: map[int][]string{}, catInd: map[int]map[string]int{},
+		catSeen: map[int]map[string]int{}, sums: map[int]float64{}, counts: map[int]int{}}
+	if s.cfg.Comma == 0 {
+		s.cfg.Comma = ','
+	}
+	if len(s.cfg.NA) == 0 {
+		s.cfg.NA = []string{"NA"}
+	}
+	for _, v := range s.cfg.NA {
+		s.na[v] = true
+	}
+	if len(s.cfg.Targets) == 0 {
+		return nil, fmt.Errorf("targets must not be empty")
+	}
+	targets := map[int]bool{}
+	for _, t := range s.cfg.Targets {
+		targets[t] = true
+	}
+	for _, in := range s.cfg.Inputs {
+		if targets[in] {
+			return nil, fmt.Errorf("colomn %d is both an input and a target", in)
+		}
+	}
+	for _, o := range s.cfg.OneHot {
+		if !targets[o] {
+			return nil, fmt.Errorf("one-hot colomn %d is not a target", o)
+		}
+		s.oneHot[o] = true
+		s.catSeen[o] = map[string]int{}
+		if cats, ok := s.cfg.Categories[o]; ok {
+			s.setCategories(o, cats)
+		}
+	}
+	return s, nil
+}
+
+//setCategories fixes the categories of the one-hot colomn col
+func (s *csvSchema) setCategories(col int, cats []string) {
+	s.cats[col] = append([]string(nil), cats...)
+	s.catInd[col] = map[string]int{}
+	for i, c := range cats {
+		s.catInd[col][c] = i
+	}
+}
+
+//reader returns a csv reader of r set up with the delimiter
+func (s *csvSchema) reader(r io.Reader) *csv.Reader {
+	rd := csv.NewReader(r)
+	rd.Comma = s.cfg.Comma
+	rd.ReuseRecord = true
+	return rd
+}
+
+//start reads the header if any, and checks the selected colomns against the first record
+func (s *csvSchema) start(rd *csv.Reader) error {
+	if s.cfg.Header {
+		rec, err := rd.Read()
+		if err != nil {
+			return fmt.Errorf("header: %s", err.Error())
+		}
+		s.header = append([]string(nil), rec...)
+	}
+	return nil
+}
+
+//setWidth checks the selected colomns against the number of colomns of the records, and picks the default inputs
+func (s *csvSchema) setWidth(w int) error {
+	if s.width != 0 {
+		return nil
+	}
+	for _, cols := range [][]int{s.cfg.Inputs, s.cfg.Targets} {
+		for _, c := range cols {
+			if c < 0 || c >= w {
+				return fmt.Errorf("colomn %d is out of range, records have %d colomns", c, w)
+			}
+		}
+	}
+	s.width = w
+	s.inputs = s.cfg.Inputs
+	if len(s.inputs) == 0 {
+		targets := map[int]bool{}
+		for _, t := range s.cfg.Targets {
+			targets[t] = true
+		}
+		for c := 0; c < w; c++ {
+			if !targets[c] {
+				s.inputs = append(s.inputs, c)
+			}
+		}
+		if len(s.inputs) == 0 {
+			return fmt.Errorf("no colomn left for inputs")
+		}
+	}
+	return nil
+}
+
+func (s *csvSchema) missing(field string) bool {
+	f := strings.TrimSpace(field)
+	return f == "" || s.na[f]
+}
+
+//observe gathers the statistics of a record of the first pass: categories, sums and counts. Returns false if the record is skipped
+func (s *csvSchema) observe(rec []string, row int) (bool, error) {
+	if err := s.setWidth(len(rec)); err != nil {
+		return false, err
+	}
+	if s.cfg.Missing == MissingSkip && s.hasMissing(rec) {
+		return false, nil
+	}
+	for _, c := range append(append([]int(nil), s.inputs...), s.cfg.Targets...) {
+		if s.missing(rec[c]) {
+			continue
+		}
+		f := strings.TrimSpace(rec[c])
+		if s.oneHot[c] {
+			s.catSeen[c][f]++
+			s.counts[c]++
+			continue
+		}
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return false, fmt.Errorf("row %d, colomn %d: %s", row, c, err.Error())
+		}
+		s.sums[c] += v
+		s.counts[c]++
+	}
+	s.rows++
+	return true, nil
+}
+
+//finish sorts the collected categories of the one-hot colomns which were not fixed
+func (s *csvSchema) finish() {
+	for c := range s.oneHot {
+		if _, ok := s.catInd[c]; ok {
+			continue
+		}
+		cats := make([]string, 0, len(s.catSeen[c]))
+		for k := range s.catSeen[c] {
+			cats = append(cats, k)
+		}
+		sort.Strings(cats)
+		s.setCategories(c, cats)
+	}
+}
+
+func (s *csvSchema) hasMissing(rec []string) bool {
+	for _, c := range s.inputs {
+		if s.missing(rec[c]) {
+			return true
+		}
+	}
+	for _, c := range s.cfg.Targets {
+		if s.missing(rec[c]) {
+			return true
+		}
+	}
+	return false
+}
+
+//point returns the Datapoint of a record, nil if it is skipped
+func (s *csvSchema) point(rec []string, row int) (*Datapoint, error) {
+	if err := s.setWidth(len(rec)); err != nil {
+		return nil, err
+	}
+	if s.cfg.Missing == MissingSkip && s.hasMissing(rec) {
+		return nil, nil
+	}
+	inp := mat.NewM64(len(s.inputs), 1, nil)
+	for i, c := range s.inputs {
+		v, err := s.value(rec[c], row, c)
+		if err != nil {
+			return nil, err
+		}
+		inp.Set(i, 0, v)
+	}
+	var vals []float64
+	for _, c := range s.cfg.Targets {
+		if !s.oneHot[c] {
+			v, err := s.value(rec[c], row, c)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, v)
+			continue
+		}
+		vec, err := s.encode(rec[c], row, c)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, vec...)
+	}
+	exp, err := mat.FromData(len(vals), 1, vals)
+	if err != nil {
+		return nil, fmt.Errorf("row %d: expected output: %s", row, err.Error())
+	}
+	return &Datapoint{Inp: inp, Exp: exp}, nil
+}
+
+//value parses a numeric field, applying the missing values policy
+func (s *csvSchema) value(field string, row, col int) (float64, error) {
+	if !s.missing(field) {
+		v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return 0, fmt.Errorf("row %d, colomn %d: %s", row, col, err.Error())
+		}
+		return v, nil
+	}
+	switch s.cfg.Missing {
+	case MissingZero:
+		return 0, nil
+	case MissingMean:
+		if s.counts[col] == 0 {
+			return 0, nil
+		}
+		return s.sums[col] / float64(s.counts[col]), nil
+	}
+	return 0, fmt.Errorf("row %d, colomn %d: missing value", row, col)
+}
+
+//encode returns the one-hot vector of a categorical field, applying the missing values policy
+func (s *csvSchema) encode(field string, row, col int) ([]float64, error) {
+	vec := make([]float64, len(s.cats[col]))
+	if !s.missing(field) {
+		ind, ok := s.catInd[col][strings.TrimSpace(field)]
+		if !ok {
+			return nil, fmt.Errorf("row %d, colomn %d: unknown category %q", row, col, strings.TrimSpace(field))
+		}
+		vec[ind] = 1
+		return vec, nil
+	}
+	switch s.cfg.Missing {
+	case MissingZero:
+		return vec, nil
+	case MissingMean:
+		for i, c := range s.cats[col] {
+			if s.counts[col] > 0 {
+				vec[i] = float64(s.catSeen[col][c]) / float64(s.counts[col])
+			}
+		}
+		return vec, nil
+	}
+	return nil, fmt.Errorf("row %d, colomn %d: missing value", row, col)
+}
+
+//Header returns the colomn names, nil if the file has no header
+func (s *csvSchema) Header() []string {
+	return append([]string(nil), s.header...)
+}
+
+//Categories returns the categories of the one-hot colomn col, in the order of the encoding
+func (s *csvSchema) Categories(col int) []string {
+	return append([]string(nil), s.cats[col]...)
+}
+
+//CSVDataset is an in-memory Dataset read from a delimited file
+type CSVDataset struct {
+	*Memory
+	*csvSchema
+}
+
+//ReadCSV reads all the rows of r as described by cfg into an in-memory dataset, which can be shuffled and split like any Memory
+func ReadCSV(r io.Reader, cfg *CSVConfig) (*CSVDataset, error) {
+	s, err := newCSVSchema(cfg)
+	if err != nil {
+		return nil, err
+	}
+	rd := s.reader(r)
+	rd.ReuseRecord = false
+	if err := s.start(rd); err != nil {
+		return nil, err
+	}
+	recs, err := rd.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	first := 1
+	if s.cfg.Header {
+		first = 2
+	}
+	for i, rec := range recs {
+		if _, err := s.observe(rec, first+i); err != nil {
+			return nil, err
+		}
+	}
+	s.finish()
+	var points []*Datapoint
+	for i, rec := range recs {
+		p, err := s.point(rec, first+i)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			points = append(points, p)
+		}
+	}
+	if len(points) == 0 {
+		return nil, fmt.Errorf("no rows to read")
+	}
+	return &CSVDataset{Memory: newMemory(points), csvSchema: s}, nil
+}
+
+//ReadCSVFile reads the delimited file at path with ReadCSV
+func ReadCSVFile(path string, cfg *CSVConfig) (*CSVDataset, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadCSV(f, cfg)
+}
@@ -0,0 +1,115 @@
+package nn
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+const irisLike = `len,width,species,weight
+1.5,0.5,setosa,2
+4.5,1.5,versicolor,3
+6,2,virginica,4
+1.25,0.25,setosa,1
+`
+
+//vectors returns the inputs and expected outputs served by d, as slices
+func vectors(d Dataset) ([][]float64, [][]float64) {
+	var inp, exp [][]float64
+	flat := func(m *mat.M64) []float64 {
+		r, _ := m.Dims()
+		res := make([]float64, r)
+		for i := range res {
+			res[i] = m.At(i, 0)
+		}
+		return res
+	}
+	for p := d.Next(); p != nil; p = d.Next() {
+		inp = append(inp, flat(p.Inp))
+		exp = append(exp, flat(p.Exp))
+	}
+	return inp, exp
+}
+
+func TestReadCSV(t *testing.T) {
+	te := tester.New(t)
+	d, err := ReadCSV(strings.NewReader(irisLike), &CSVConfig{Header: true, Targets: []int{2, 3}, OneHot: []int{2}})
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "header", []string{"len", "width", "species", "weight"}, d.Header())
+	te.DeepEqual(0, "categories", []string{"setosa", "versicolor", "virginica"}, d.Categories(2))
+	te.DeepEqual(0, "size", 4, d.Size())
+	inp, exp := vectors(d)
+	te.DeepEqual(0, "inputs", [][]float64{{1.5, 0.5}, {4.5, 1.5}, {6, 2}, {1.25, 0.25}}, inp)
+	te.DeepEqual(0, "targets", [][]float64{{1, 0, 0, 2}, {0, 1, 0, 3}, {0, 0, 1, 4}, {1, 0, 0, 1}}, exp)
+	//the embedded Memory can be split
+	train, _, test, err := Split(d.Memory, nil, 0.5, 0)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "split", []int{2, 2}, []int{train.Size(), test.Size()})
+
+	//TSV, selected inputs, fixed categories
+	tsv := "1\t2\tb\n3\t4\ta\n"
+	d, err = ReadCSV(strings.NewReader(tsv), &CSVConfig{Comma: '\t', Inputs: []int{1}, Targets: []int{2}, OneHot: []int{2}, Categories: map[int][]string{2: {"b", "a", "c"}}})
+	te.CompareError(1, nil, err)
+	inp, exp = vectors(d)
+	te.DeepEqual(1, "inputs", [][]float64{{2}, {4}}, inp)
+	te.DeepEqual(1, "targets", [][]float64{{1, 0, 0}, {0, 1, 0}}, exp)
+	te.DeepEqual(1, "no header", []string(nil), d.Header())
+}
+
+func TestReadCSVMissing(t *testing.T) {
+	te := tester.New(t)
+	data := "1,,a\n3,4,NA\n?,6,b\n5,8,a\n"
+	cfg := func(m Missing) *CSVConfig {
+		return &CSVConfig{Targets: []int{2}, OneHot: []int{2}, Missing: m, NA: []string{"NA", "?"}}
+	}
+	tests := []struct {
+		m   Missing
+		inp [][]float64
+		exp [][]float64
+		err error
+	}{
+		{MissingError, nil, nil, fmt.Errorf("row 1, colomn 1: missing value")},
+		{MissingSkip, [][]float64{{5, 8}}, [][]float64{{1}}, nil},
+		{MissingZero, [][]float64{{1, 0}, {3, 4}, {0, 6}, {5, 8}}, [][]float64{{1, 0}, {0, 0}, {0, 1}, {1, 0}}, nil},
+		{MissingMean, [][]float64{{1, 6}, {3, 4}, {3, 6}, {5, 8}}, [][]float64{{1, 0}, {2.0 / 3, 1.0 / 3}, {0, 1}, {1, 0}}, nil},
+	}
+	for ind, test := range tests {
+		d, err := ReadCSV(strings.NewReader(data), cfg(test.m))
+		te.CompareError(ind, test.err, err)
+		if err != nil {
+			continue
+		}
+		inp, exp := vectors(d)
+		te.DeepEqual(ind, "inputs", test.inp, inp)
+		te.DeepEqual(ind, "targets", test.exp, exp)
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	te := tester.New(t)
+	data := "1,2,x\n3,4,y\n"
+	tests := []struct {
+		data string
+		cfg  *CSVConfig
+		err  error
+	}{
+		{data, nil, fmt.Errorf("config is nil")},
+		{data, &CSVConfig{}, fmt.Errorf("targets must not be empty")},
+		{data, &CSVConfig{Inputs: []int{0, 1}, Targets: []int{1}}, fmt.Errorf("colomn 1 is both an input and a target")},
+		{data, &CSVConfig{Targets: []int{1}, OneHot: []int{2}}, fmt.Errorf("one-hot colomn 2 is not a target")},
+		{data, &CSVConfig{Targets: []int{3}}, fmt.Errorf("colomn 3 is out of range, records have 3 colomns")},
+		{data, &CSVConfig{Targets: []int{1}}, fmt.Errorf(`row 1, colomn 2: strconv.ParseFloat: parsing "x": invalid syntax`)},
+		{data, &CSVConfig{Targets: []int{2}, OneHot: []int{2}, Categories: map[int][]string{2: {"x"}}}, fmt.Errorf(`row 2, colomn 2: unknown category "y"`)},
+		{"1,2\n", &CSVConfig{Inputs: []int{0}, Targets: []int{1}, Missing: MissingSkip, NA: []string{"1"}}, fmt.Errorf("no rows to read")},
+		{"1\n", &CSVConfig{Targets: []int{0}}, fmt.Errorf("no colomn left for inputs")},
+	}
+	for ind, test := range tests {
+		_, err := ReadCSV(strings.NewReader(test.data), test.cfg)
+		te.CompareError(ind, test.err, err)
+	}
+	_, err := ReadCSVFile("does/not/exist.csv", &CSVConfig{Targets: []int{0}})
+	te.DeepEqual(0, "file error", true, err != nil)
+}
@@ -0,0 +1,156 @@
+package nn
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+//CSVStream is a Dataset reading its Datapoints one row at a time from a delimited file, never holding the whole file in memory. If the reader is also an io.Seeker (like *os.File), a first pass counts the rows and gathers the colomn statistics, and Reset rewinds it for a new epoch. Otherwise the stream serves a single pass: Size and Left return -1, Reset has no effect and FFNTrainer refuses it. Read and parse errors stop the stream, see Err
+type CSVStream struct {
+	*csvSchema
+	r      io.Reader
+	seeker io.Seeker
+	rd     *csv.Reader
+	row    int
+	served int
+	err    error
+}
+
+//NewCSVStream returns a new stream of the rows of r, read as described by cfg. Without an io.Seeker, MissingMean is not available and the categories of one-hot colomns must be given in cfg
+func NewCSVStream(r io.Reader, cfg *CSVConfig) (*CSVStream, error) {
+	if r == nil {
+		return nil, fmt.Errorf("reader is nil")
+	}
+	s, err := newCSVSchema(cfg)
+	if err != nil {
+		return nil, err
+	}
+	d := &CSVStream{csvSchema: s, r: r}
+	d.seeker, _ = r.(io.Seeker)
+	if d.seeker == nil {
+		if s.cfg.Missing == MissingMean {
+			return nil, fmt.Errorf("MissingMean needs a reader able to seek")
+		}
+		for c := range s.oneHot {
+			if _, ok := s.catInd[c]; !ok {
+				return nil, fmt.Errorf("categories of colomn %d must be given for a reader unable to seek", c)
+			}
+		}
+		if err := d.open(); err != nil {
+			return nil, err
+		}
+		return d, nil
+	}
+	if err := d.scan(); err != nil {
+		return nil, err
+	}
+	return d, d.open()
+}
+
+//scan is the first pass over a seekable reader
+func (d *CSVStream) scan() error {
+	if _, err := d.seeker.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	rd := d.reader(d.r)
+	if err := d.start(rd); err != nil {
+		return err
+	}
+	row := 1
+	if d.cfg.Header {
+		row = 2
+	}
+	for ; ; row++ {
+		rec, err := rd.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := d.observe(rec, row); err != nil {
+			return err
+		}
+	}
+	d.finish()
+	return nil
+}
+
+//open starts reading the rows from the beginning of the reader
+func (d *CSVStream) open() error {
+	if d.seeker != nil {
+		if _, err := d.seeker.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	d.rd = d.reader(d.r)
+	d.row, d.served = 1, 0
+	if d.cfg.Header {
+		d.row = 2
+		//the header is read again to keep the csv reader in step. A reader unable to seek has had no first pass, it is kept here
+		rec, err := d.rd.Read()
+		if err != nil {
+			return fmt.Errorf("header: %s", err.Error())
+		}
+		if d.header == nil {
+			d.header = append([]string(nil), rec...)
+		}
+	}
+	return nil
+}
+
+//Next returns the next Datapoint, nil at the end of the data or on error
+func (d *CSVStream) Next() *Datapoint {
+	for d.err == nil {
+		rec, err := d.rd.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			d.err = err
+			return nil
+		}
+		row := d.row
+		d.row++
+		p, err := d.point(rec, row)
+		if err != nil {
+			d.err = err
+			return nil
+		}
+		if p != nil {
+			d.served++
+			return p
+		}
+	}
+	return nil
+}
+
+//Err returns the error which stopped the stream, nil at the end of the data
+func (d *CSVStream) Err() error {
+	return d.err
+}
+
+//Size returns the number of Datapoints, -1 if the reader is unable to seek
+func (d *CSVStream) Size() int {
+	if d.seeker == nil {
+		return -1
+	}
+	return d.rows
+}
+
+//Left returns the number of Datapoints not served yet, -1 if the reader is unable to seek
+func (d *CSVStream) Left() int {
+	if d.seeker == nil {
+		return -1
+	}
+	return d.rows - d.served
+}
+
+//Reset rewinds the reader for a new pass. It has no effect on a reader unable to seek, which it leaves where it is
+func (d *CSVStream) Reset() {
+	if d.seeker == nil {
+		return
+	}
+	d.err = d.open()
+}
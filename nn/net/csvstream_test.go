@@ -0,0 +1,81 @@
+package nn
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+//pipe hides the Seek method of a reader
+type pipe struct {
+	r io.Reader
+}
+
+func (p pipe) Read(b []byte) (int, error) {
+	return p.r.Read(b)
+}
+
+func TestCSVStream(t *testing.T) {
+	te := tester.New(t)
+	cfg := &CSVConfig{Header: true, Targets: []int{2, 3}, OneHot: []int{2}}
+	mem, _ := ReadCSV(strings.NewReader(irisLike), cfg)
+	expInp, expExp := vectors(mem)
+
+	d, err := NewCSVStream(strings.NewReader(irisLike), cfg)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "size", 4, d.Size())
+	te.DeepEqual(0, "header", []string{"len", "width", "species", "weight"}, d.Header())
+	te.DeepEqual(0, "categories", []string{"setosa", "versicolor", "virginica"}, d.Categories(2))
+	d.Next()
+	te.DeepEqual(0, "left", 3, d.Left())
+	d.Reset()
+	inp, exp := vectors(d)
+	te.DeepEqual(0, "inputs", expInp, inp)
+	te.DeepEqual(0, "targets", expExp, exp)
+	te.CompareError(0, nil, d.Err())
+	//a second epoch
+	d.Reset()
+	inp, _ = vectors(d)
+	te.DeepEqual(0, "second epoch", expInp, inp)
+
+	//single pass over a reader unable to seek
+	cfg.Categories = map[int][]string{2: {"setosa", "versicolor", "virginica"}}
+	p, err := NewCSVStream(pipe{strings.NewReader(irisLike)}, cfg)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "size", -1, p.Size())
+	te.DeepEqual(1, "header", []string{"len", "width", "species", "weight"}, p.Header())
+	inp, exp = vectors(p)
+	te.DeepEqual(1, "inputs", expInp, inp)
+	te.DeepEqual(1, "targets", expExp, exp)
+	p.Reset()
+	te.DeepEqual(1, "no rewind", (*Datapoint)(nil), p.Next())
+
+	//mean policy with a first pass
+	m, err := NewCSVStream(strings.NewReader("1,2\n,4\n5,6\n"), &CSVConfig{Targets: []int{1}, Missing: MissingMean})
+	te.CompareError(2, nil, err)
+	inp, _ = vectors(m)
+	te.DeepEqual(2, "mean", [][]float64{{1}, {3}, {5}}, inp)
+}
+
+func TestCSVStreamErrors(t *testing.T) {
+	te := tester.New(t)
+	_, err := NewCSVStream(nil, &CSVConfig{Targets: []int{0}})
+	te.CompareError(0, fmt.Errorf("reader is nil"), err)
+	_, err = NewCSVStream(pipe{strings.NewReader("1,2\n")}, &CSVConfig{Targets: []int{1}, Missing: MissingMean})
+	te.CompareError(1, fmt.Errorf("MissingMean needs a reader able to seek"), err)
+	_, err = NewCSVStream(pipe{strings.NewReader("1,a\n")}, &CSVConfig{Targets: []int{1}, OneHot: []int{1}})
+	te.CompareError(2, fmt.Errorf("categories of colomn 1 must be given for a reader unable to seek"), err)
+	_, err = NewCSVStream(strings.NewReader("1,2\n3,x\n"), &CSVConfig{Targets: []int{1}})
+	te.CompareError(3, fmt.Errorf(`row 2, colomn 1: strconv.ParseFloat: parsing "x": invalid syntax`), err)
+
+	//errors found while streaming stop it
+	d, err := NewCSVStream(pipe{strings.NewReader("1,2\n3,\n5,6\n")}, &CSVConfig{Targets: []int{1}})
+	te.CompareError(4, nil, err)
+	te.DeepEqual(4, "first", true, d.Next() != nil)
+	te.DeepEqual(4, "stopped", (*Datapoint)(nil), d.Next())
+	te.CompareError(4, fmt.Errorf("row 2, colomn 1: missing value"), d.Err())
+	te.DeepEqual(4, "still stopped", (*Datapoint)(nil), d.Next())
+}
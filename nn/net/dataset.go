@@ -0,0 +1,138 @@
+package nn
+
+import (
+	"fmt"
+	"math/rand"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Memory is a Dataset holding its Datapoints in memory. They are served in order, or in a new random order at each Reset once shuffling is set
+type Memory struct {
+	points []*Datapoint
+	order  []int
+	pos    int
+	rnd    *rand.Rand
+}
+
+//NewMemory returns a new in-memory dataset serving points. All inputs must have the same dims, and so must all expected outputs. The slice is copied, not the Datapoints
+func NewMemory(points []*Datapoint) (*Memory, error) {
+	if len(points) == 0 {
+		return nil, fmt.Errorf("points is empty")
+	}
+	for i, p := range points {
+		if p == nil || !p.Inp.Valid() || !p.Exp.Valid() {
+			return nil, fmt.Errorf("points[%d] is nil or has nil matrices", i)
+		}
+		if err := sameDims(points[0].Inp, p.Inp); err != nil {
+			return nil, fmt.Errorf("points[%d]: input: %s", i, err.Error())
+		}
+		if err := sameDims(points[0].Exp, p.Exp); err != nil {
+			return nil, fmt.Errorf("points[%d]: expected output: %s", i, err.Error())
+		}
+	}
+	return newMemory(append([]*Datapoint(nil), points...)), nil
+}
+
+//NewMemoryFromM64 returns a new in-memory dataset from two matrices with a sample per row: row i of inputs and targets become the colomn vectors Inp and Exp of Datapoint i
+func NewMemoryFromM64(inputs, targets *mat.M64) (*Memory, error) {
+	if !inputs.Valid() {
+		return nil, fmt.Errorf("inputs is nil")
+	}
+	if !targets.Valid() {
+		return nil, fmt.Errorf("targets is nil")
+	}
+	r, ci := inputs.Dims()
+	rt, ct := targets.Dims()
+	if r != rt {
+		return nil, fmt.Errorf("inputs,targets rows not equal")
+	}
+	points := make([]*Datapoint, r)
+	for i := range points {
+		points[i] = &Datapoint{Inp: rowVector(inputs, i, ci), Exp: rowVector(targets, i, ct)}
+	}
+	return newMemory(points), nil
+}
+
+//rowVector returns row i of m as a new c*1 colomn vector
+func rowVector(m *mat.M64, i, c int) *mat.M64 {
+	v := mat.NewM64(c, 1, nil)
+	for j := 0; j < c; j++ {
+		v.Set(j, 0, m.At(i, j))
+	}
+	return v
+}
+
+//sameDims returns an error if a and b dims differ
+func sameDims(a, b *mat.M64) error {
+	ra, ca := a.Dims()
+	rb, cb := b.Dims()
+	if ra != rb || ca != cb {
+		return fmt.Errorf("dims %d*%d, expected %d*%d", rb, cb, ra, ca)
+	}
+	return nil
+}
+
+func newMemory(points []*Datapoint) *Memory {
+	d := &Memory{points: points, order: make([]int, len(points))}
+	for i := range d.order {
+		d.order[i] = i
+	}
+	return d
+}
+
+//SetShuffle makes the dataset serve its Datapoints in a random order drawn from src, renewed at each Reset. The order is drawn right away. A nil src restores the original order
+func (d *Memory) SetShuffle(src rand.Source) {
+	d.rnd = nil
+	if src != nil {
+		d.rnd = rand.New(src)
+	}
+	d.Reset()
+}
+
+//Next returns the next Datapoint, nil when all of them were served
+func (d *Memory) Next() *Datapoint {
+	if d.pos >= len(d.order) {
+		return nil
+	}
+	p := d.points[d.order[d.pos]]
+	d.pos++
+	return p
+}
+
+//Size returns the number of Datapoints
+func (d *Memory) Size() int {
+	return len(d.points)
+}
+
+//Left returns the number of Datapoints not served yet
+func (d *Memory) Left() int {
+	return len(d.order) - d.pos
+}
+
+//Reset starts a new pass over the Datapoints, in a new order if shuffling is set
+func (d *Memory) Reset() {
+	d.pos = 0
+	for i := range d.order {
+		d.order[i] = i
+	}
+	if d.rnd != nil {
+		d.rnd.Shuffle(len(d.order), func(i, j int) {
+			d.order[i], d.order[j] = d.order[j], d.order[i]
+		})
+	}
+}
+
+//At returns Datapoint i, in the original order. panics if i is out of range
+func (d *Memory) At(i int) *Datapoint {
+	return d.points[i]
+}
+
+//Subset returns a new dataset with the Datapoints at indices idx (in the original order), which are shared with d. Shuffling is not inherited. panics if an index is out of range
+func (d *Memory) Subset(idx []int) *Memory {
+	points := make([]*Datapoint, len(idx))
+	for i, ind := range idx {
+		points[i] = d.points[ind]
+	}
+	return newMemory(points)
+}
@@ -0,0 +1,107 @@
+package nn
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+//points returns n Datapoints with input (i) and a one-hot expected output of class i%classes
+func points(n, classes int) []*Datapoint {
+	res := make([]*Datapoint, n)
+	for i := range res {
+		exp := mat.NewM64(classes, 1, nil)
+		exp.Set(i%classes, 0, 1)
+		res[i] = &Datapoint{Inp: mat.NewM64(1, 1, []float64{float64(i)}), Exp: exp}
+	}
+	return res
+}
+
+//drain returns the inputs served by d until Next returns nil
+func drain(d Dataset) []float64 {
+	var res []float64
+	for p := d.Next(); p != nil; p = d.Next() {
+		res = append(res, p.Inp.At(0, 0))
+	}
+	return res
+}
+
+func TestNewMemory(t *testing.T) {
+	te := tester.New(t)
+	good := points(3, 2)
+	tests := []struct {
+		points []*Datapoint
+		err    error
+	}{
+		{good, nil},
+		{nil, fmt.Errorf("points is empty")},
+		{[]*Datapoint{good[0], nil}, fmt.Errorf("points[1] is nil or has nil matrices")},
+		{[]*Datapoint{good[0], {Inp: good[1].Inp}}, fmt.Errorf("points[1] is nil or has nil matrices")},
+		{[]*Datapoint{good[0], {Inp: mat.NewM64(2, 1, nil), Exp: good[1].Exp}}, fmt.Errorf("points[1]: input: dims 2*1, expected 1*1")},
+		{[]*Datapoint{good[0], {Inp: good[1].Inp, Exp: mat.NewM64(3, 1, nil)}}, fmt.Errorf("points[1]: expected output: dims 3*1, expected 2*1")},
+	}
+	for ind, test := range tests {
+		_, err := NewMemory(test.points)
+		te.CompareError(ind, test.err, err)
+	}
+}
+
+func TestMemory(t *testing.T) {
+	te := tester.New(t)
+	d, err := NewMemory(points(5, 2))
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "size", 5, d.Size())
+	te.DeepEqual(0, "left", 5, d.Left())
+	d.Next()
+	te.DeepEqual(0, "left after next", 4, d.Left())
+	te.DeepEqual(0, "rest", []float64{1, 2, 3, 4}, drain(d))
+	te.DeepEqual(0, "left at end", 0, d.Left())
+	d.Reset()
+	te.DeepEqual(0, "in order", []float64{0, 1, 2, 3, 4}, drain(d))
+
+	//shuffling is seeded and renewed at each epoch
+	d.SetShuffle(rand.NewSource(1))
+	first := drain(d)
+	d.Reset()
+	second := drain(d)
+	e, _ := NewMemory(points(5, 2))
+	e.SetShuffle(rand.NewSource(1))
+	te.DeepEqual(1, "seeded", first, drain(e))
+	te.DeepEqual(1, "new order", false, fmt.Sprint(first) == fmt.Sprint(second))
+	te.DeepEqual(1, "all served", 5, len(second))
+	te.DeepEqual(1, "at", 3.0, d.At(3).Inp.At(0, 0))
+	d.SetShuffle(nil)
+	te.DeepEqual(1, "unshuffled", []float64{0, 1, 2, 3, 4}, drain(d))
+
+	sub := d.Subset([]int{4, 1})
+	te.DeepEqual(2, "subset", []float64{4, 1}, drain(sub))
+	te.DeepEqual(2, "shared", d.At(4), sub.At(0))
+}
+
+func TestNewMemoryFromM64(t *testing.T) {
+	te := tester.New(t)
+	inputs := mat.NewM64(3, 2, []float64{
+		1, 2,
+		3, 4,
+		5, 6,
+	})
+	targets := mat.NewM64(3, 1, []float64{0, 1, 0})
+	d, err := NewMemoryFromM64(inputs, targets)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "size", 3, d.Size())
+	te.DeepEqual(0, "inp", mat.NewM64(2, 1, []float64{3, 4}), d.At(1).Inp)
+	te.DeepEqual(0, "exp", mat.NewM64(1, 1, []float64{1}), d.At(1).Exp)
+	//matrices are copied
+	inputs.Set(1, 0, 9)
+	te.DeepEqual(0, "copied", 3.0, d.At(1).Inp.At(0, 0))
+
+	_, err = NewMemoryFromM64(nil, targets)
+	te.CompareError(1, fmt.Errorf("inputs is nil"), err)
+	_, err = NewMemoryFromM64(inputs, nil)
+	te.CompareError(2, fmt.Errorf("targets is nil"), err)
+	_, err = NewMemoryFromM64(inputs, mat.NewM64(2, 1, nil))
+	te.CompareError(3, fmt.Errorf("inputs,targets rows not equal"), err)
+}
@@ -13,6 +13,7 @@ type FFN struct {
 	layers     []*layer
 	states     []*mat.M64
 	keepStates bool
+	ws         *workspace
 }
 
 //NewFFN returns a new instance of FeedForward Neural Network, with no layers
@@ -51,13 +52,16 @@ func (ff *FFN) SetLayers(configs ...*LayerConfig) error {
 	}
 	ff.layers = layers
 	ff.states = make([]*mat.M64, n2)
+	ff.ws = newWorkspace(layers)
 	return nil
 }
 
-//Feed feeds data forward from input, returns output layer's state
+//Feed feeds data forward from input, returns output layer's state as a new matrix
 func (ff *FFN) Feed(input *mat.M64) (*mat.M64, error) {
+	if len(ff.layers) == 0 {
+		return nil, fmt.Errorf("network has no layers")
+	}
 	in := input
-	var out *mat.M64
 	if ff.keepStates {
 		ff.states = make([]*mat.M64, len(ff.layers))
 	}
@@ -71,7 +75,7 @@ func (ff *FFN) Feed(input *mat.M64) (*mat.M64, error) {
 		}
 		in = out
 	}
-	return out, nil
+	return in, nil
 }
 
 //GetState returns the output values of a layer if keepStates==true or an error
@@ -0,0 +1,77 @@
+package nn
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func FuzzReadIDX(f *testing.F) {
+	f.Add(idxBytes(0x08, []int{2, 2}, []uint8{0, 255, 7, 1}))
+	f.Add(idxBytes(0x0B, []int{3}, []int16{-1, 2, 3}))
+	f.Add(idxBytes(0x0E, []int{1, 1}, []float64{-1.25}))
+	f.Add([]byte{0, 0, 0x08, 3, 0x7f, 0xff, 0xff, 0xff, 0, 0, 0, 1, 0, 0, 0, 1})
+	f.Add([]byte{0, 0, 0x0D, 0})
+	f.Fuzz(func(t *testing.T, b []byte) {
+		res, err := ReadIDX(bytes.NewReader(b))
+		if err != nil {
+			return
+		}
+		n := 1
+		for _, d := range res.Dims {
+			n *= d
+		}
+		if len(res.Data) != n {
+			t.Fatalf("dims %v hold %d values, got %d", res.Dims, n, len(res.Data))
+		}
+		if 4+4*len(res.Dims)+n > len(b) {
+			t.Fatalf("%d values read from %d bytes", n, len(b))
+		}
+	})
+}
+
+func FuzzReadCSV(f *testing.F) {
+	f.Add(irisLike, true, 2, true, uint8(MissingError))
+	f.Add("a,b,y\n1,,x\n2,3,NA\n4,5,z\n", true, 2, true, uint8(MissingMean))
+	f.Add("1,2,0\n3,NA,1\n,4,1\n", false, 2, false, uint8(MissingSkip))
+	f.Add("1\t2\n3\t4\n", false, 0, false, uint8(MissingZero))
+	f.Add("x,\"y\nz\",1\n", true, 1, true, uint8(MissingError))
+	f.Fuzz(func(t *testing.T, data string, header bool, target int, oneHot bool, missing uint8) {
+		if target < 0 || target > 8 {
+			t.Skip()
+		}
+		cfg := &CSVConfig{Header: header, Targets: []int{target}, Missing: Missing(missing % 4)}
+		if strings.Count(data, "\t") > strings.Count(data, ",") {
+			cfg.Comma = '\t'
+		}
+		if oneHot {
+			cfg.OneHot = []int{target}
+		}
+		d, err := ReadCSV(strings.NewReader(data), cfg)
+		if err != nil {
+			return
+		}
+		inp, exp := vectors(d)
+		if len(inp) != d.Size() || len(inp) == 0 {
+			t.Fatalf("%d Datapoints served, size %d", len(inp), d.Size())
+		}
+		for i := range inp {
+			if len(inp[i]) != len(inp[0]) || len(exp[i]) != len(exp[0]) {
+				t.Fatalf("datapoint[%d]: %d*%d values, expected %d*%d", i, len(inp[i]), len(exp[i]), len(inp[0]), len(exp[0]))
+			}
+		}
+		//a stream on a seekable reader serves the same Datapoints
+		s, err := NewCSVStream(strings.NewReader(data), cfg)
+		if err != nil {
+			t.Fatalf("stream: %s", err.Error())
+		}
+		sinp, sexp := vectors(s)
+		if s.Err() != nil {
+			t.Fatalf("stream: %s", s.Err().Error())
+		}
+		if fmt.Sprint(inp, exp) != fmt.Sprint(sinp, sexp) || s.Size() != d.Size() {
+			t.Fatalf("stream served %v %v, expected %v %v", sinp, sexp, inp, exp)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package nn
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//idxChunk is the number of values ReadIDX reads at once
+const idxChunk = 1 << 16
+
+//IDX holds the content of a file in the IDX format used by MNIST: a multi-dimensional array, stored row major
+type IDX struct {
+	Dims []int
+	Data []float64
+}
+
+//ReadIDX reads an array in the IDX format: two zero bytes, the data type, the number of dims, the dims as big endian uint32, then the big endian values
+func ReadIDX(r io.Reader) (*IDX, error) {
+	br := bufio.NewReader(r)
+	var magic [4]byte
+	if _, err := io.ReadFull(br, magic[:]); err != nil {
+		return nil, fmt.Errorf("magic number: %s", err.Error())
+	}
+	if magic[0] != 0 || magic[1] != 0 {
+		return nil, fmt.Errorf("invalid magic number")
+	}
+	size, ok := map[byte]int{0x08: 1, 0x09: 1, 0x0B: 2, 0x0C: 4, 0x0D: 4, 0x0E: 8}[magic[2]]
+	if !ok {
+		return nil, fmt.Errorf("unknown data type 0x%02x", magic[2])
+	}
+	res := &IDX{Dims: make([]int, magic[3])}
+	n := 1
+	for i := range res.Dims {
+		var d uint32
+		if err := binary.Read(br, binary.BigEndian, &d); err != nil {
+			return nil, fmt.Errorf("dims: %s", err.Error())
+		}
+		res.Dims[i] = int(d)
+		if d > 0 && n > math.MaxInt32/int(d) {
+			return nil, fmt.Errorf("array is too large")
+		}
+		n *= int(d)
+	}
+	//the values are read chunk by chunk, so that a corrupt header can not make the reader allocate more than the data actually found
+	chunk := idxChunk
+	if n < chunk {
+		chunk = n
+	}
+	buf := make([]byte, chunk*size)
+	res.Data = make([]float64, 0, chunk)
+	for left := n; left > 0; left -= chunk {
+		if left < chunk {
+			chunk = left
+		}
+		if _, err := io.ReadFull(br, buf[:chunk*size]); err != nil {
+			return nil, fmt.Errorf("data: %s", err.Error())
+		}
+		for i := 0; i < chunk; i++ {
+			res.Data = append(res.Data, idxValue(magic[2], buf[i*size:(i+1)*size]))
+		}
+	}
+	return res, nil
+}
+
+//idxValue decodes a single big endian value of the IDX data type typ
+func idxValue(typ byte, b []byte) float64 {
+	switch typ {
+	case 0x09:
+		return float64(int8(b[0]))
+	case 0x0B:
+		return float64(int16(binary.BigEndian.Uint16(b)))
+	case 0x0C:
+		return float64(int32(binary.BigEndian.Uint32(b)))
+	case 0x0D:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b)))
+	case 0x0E:
+		return math.Float64frombits(binary.BigEndian.Uint64(b))
+	}
+	return float64(b[0])
+}
+
+//ReadIDXDataset returns an in-memory dataset from a pair of IDX arrays: the n*... images and the n labels. Each input is the colomn vector of the values of an image divided by 255, each expected output the one-hot vector of its label among classes
+func ReadIDXDataset(images, labels io.Reader, classes int) (*Memory, error) {
+	if classes < 1 {
+		return nil, fmt.Errorf("classes must be positive")
+	}
+	img, err := ReadIDX(images)
+	if err != nil {
+		return nil, fmt.Errorf("images: %s", err.Error())
+	}
+	lab, err := ReadIDX(labels)
+	if err != nil {
+		return nil, fmt.Errorf("labels: %s", err.Error())
+	}
+	if len(img.Dims) < 1 || len(lab.Dims) != 1 || img.Dims[0] != lab.Dims[0] {
+		return nil, fmt.Errorf("images and labels dims do not match: %v, %v", img.Dims, lab.Dims)
+	}
+	n := lab.Dims[0]
+	if n == 0 {
+		return nil, fmt.Errorf("no images to read")
+	}
+	size := len(img.Data) / n
+	points := make([]*Datapoint, n)
+	for i := range points {
+		l := lab.Data[i]
+		if l < 0 || l >= float64(classes) || l != math.Floor(l) {
+			return nil, fmt.Errorf("label[%d]=%g is not a class", i, l)
+		}
+		inp := mat.NewM64(size, 1, nil)
+		for j := 0; j < size; j++ {
+			inp.Set(j, 0, img.Data[i*size+j]/255)
+		}
+		exp := mat.NewM64(classes, 1, nil)
+		exp.Set(int(l), 0, 1)
+		points[i] = &Datapoint{Inp: inp, Exp: exp}
+	}
+	return newMemory(points), nil
+}
+
+//ReadMNIST returns an in-memory dataset from the images and labels files of MNIST or Fashion-MNIST: 784*1 inputs in [0,1] and 10*1 one-hot outputs. Files ending in .gz are decompressed
+func ReadMNIST(imagesPath, labelsPath string) (*Memory, error) {
+	images, err := openMaybeGzip(imagesPath)
+	if err != nil {
+		return nil, err
+	}
+	defer images.Close()
+	labels, err := openMaybeGzip(labelsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer labels.Close()
+	return ReadIDXDataset(images, labels, 10)
+}
+
+//gzipFile closes both the gzip reader and the file
+type gzipFile struct {
+	*gzip.Reader
+	f *os.File
+}
+
+func (g gzipFile) Close() error {
+	g.Reader.Close()
+	return g.f.Close()
+}
+
+//openMaybeGzip opens the file at path, decompressing it if its name ends in .gz
+func openMaybeGzip(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return f, nil
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %s", path, err.Error())
+	}
+	return gzipFile{Reader: gz, f: f}, nil
+}
@@ -0,0 +1,113 @@
+package nn
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+//idxBytes encodes values in the IDX format with the given type code
+func idxBytes(typ byte, dims []int, values interface{}) []byte {
+	var buf bytes.Buffer
+	buf.Write([]byte{0, 0, typ, byte(len(dims))})
+	for _, d := range dims {
+		binary.Write(&buf, binary.BigEndian, uint32(d))
+	}
+	binary.Write(&buf, binary.BigEndian, values)
+	return buf.Bytes()
+}
+
+func TestReadIDX(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		data []byte
+		res  *IDX
+		err  error
+	}{
+		{idxBytes(0x08, []int{2, 2}, []uint8{0, 255, 7, 1}), &IDX{Dims: []int{2, 2}, Data: []float64{0, 255, 7, 1}}, nil},
+		{idxBytes(0x09, []int{2}, []int8{-3, 4}), &IDX{Dims: []int{2}, Data: []float64{-3, 4}}, nil},
+		{idxBytes(0x0B, []int{1}, []int16{-300}), &IDX{Dims: []int{1}, Data: []float64{-300}}, nil},
+		{idxBytes(0x0C, []int{1}, []int32{70000}), &IDX{Dims: []int{1}, Data: []float64{70000}}, nil},
+		{idxBytes(0x0D, []int{1}, []float32{0.5}), &IDX{Dims: []int{1}, Data: []float64{0.5}}, nil},
+		{idxBytes(0x0E, []int{1, 1}, []float64{-1.25}), &IDX{Dims: []int{1, 1}, Data: []float64{-1.25}}, nil},
+		{[]byte{1, 0, 8, 1}, nil, fmt.Errorf("invalid magic number")},
+		{[]byte{0, 0, 7, 1}, nil, fmt.Errorf("unknown data type 0x07")},
+		{[]byte{0, 0}, nil, fmt.Errorf("magic number: unexpected EOF")},
+		{idxBytes(0x08, []int{3}, []uint8{1, 2}), nil, fmt.Errorf("data: unexpected EOF")},
+		{idxBytes(0x08, []int{1 << 30}, []uint8{1, 2}), nil, fmt.Errorf("data: unexpected EOF")},
+		{idxBytes(0x08, []int{1 << 16, 1 << 16}, []uint8{}), nil, fmt.Errorf("array is too large")},
+	}
+	for ind, test := range tests {
+		res, err := ReadIDX(bytes.NewReader(test.data))
+		te.CompareError(ind, test.err, err)
+		te.DeepEqual(ind, "res", test.res, res)
+	}
+}
+
+func TestReadIDXDataset(t *testing.T) {
+	te := tester.New(t)
+	images := idxBytes(0x08, []int{3, 2, 2}, []uint8{
+		0, 255, 255, 0,
+		51, 51, 51, 51,
+		255, 0, 0, 0,
+	})
+	labels := idxBytes(0x08, []int{3}, []uint8{2, 0, 1})
+	d, err := ReadIDXDataset(bytes.NewReader(images), bytes.NewReader(labels), 3)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "size", 3, d.Size())
+	te.DeepEqual(0, "inp", mat.NewM64(4, 1, []float64{0, 1, 1, 0}), d.At(0).Inp)
+	te.DeepEqual(0, "exp", mat.NewM64(3, 1, []float64{0, 0, 1}), d.At(0).Exp)
+	te.DeepEqual(0, "scaled", mat.NewM64(4, 1, []float64{0.2, 0.2, 0.2, 0.2}), d.At(1).Inp)
+	te.DeepEqual(0, "label", 1, Label(d.At(2)))
+
+	tests := []struct {
+		images, labels []byte
+		classes        int
+		err            error
+	}{
+		{images, labels, 2, fmt.Errorf("label[0]=2 is not a class")},
+		{images, labels, 0, fmt.Errorf("classes must be positive")},
+		{images, idxBytes(0x08, []int{2}, []uint8{0, 1}), 3, fmt.Errorf("images and labels dims do not match: [3 2 2], [2]")},
+		{[]byte{0}, labels, 3, fmt.Errorf("images: magic number: unexpected EOF")},
+		{images, []byte{0}, 3, fmt.Errorf("labels: magic number: unexpected EOF")},
+	}
+	for ind, test := range tests {
+		_, err := ReadIDXDataset(bytes.NewReader(test.images), bytes.NewReader(test.labels), test.classes)
+		te.CompareError(ind, test.err, err)
+	}
+}
+
+func TestReadMNIST(t *testing.T) {
+	te := tester.New(t)
+	dir := t.TempDir()
+	pixels := make([]uint8, 2*28*28)
+	pixels[28*28+5] = 255
+	imgPath := filepath.Join(dir, "train-images-idx3-ubyte.gz")
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write(idxBytes(0x08, []int{2, 28, 28}, pixels))
+	w.Close()
+	te.CompareError(0, nil, ioutil.WriteFile(imgPath, gz.Bytes(), 0644))
+	labPath := filepath.Join(dir, "train-labels-idx1-ubyte")
+	te.CompareError(0, nil, ioutil.WriteFile(labPath, idxBytes(0x08, []int{2}, []uint8{7, 9}), 0644))
+
+	d, err := ReadMNIST(imgPath, labPath)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "size", 2, d.Size())
+	r, _ := d.At(1).Inp.Dims()
+	te.DeepEqual(0, "784 inputs", 784, r)
+	te.DeepEqual(0, "pixel", 1.0, d.At(1).Inp.At(5, 0))
+	te.DeepEqual(0, "labels", []int{7, 9}, []int{Label(d.At(0)), Label(d.At(1))})
+
+	_, err = ReadMNIST(filepath.Join(dir, "missing"), labPath)
+	te.DeepEqual(1, "missing file", true, err != nil)
+	_, err = ReadMNIST(labPath+".gz", labPath)
+	te.DeepEqual(2, "missing gz", true, err != nil)
+}
@@ -0,0 +1,126 @@
+package nn
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg" //registers the JPEG decoder
+	_ "image/png"  //registers the PNG decoder
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//ImageConfig describes how images are turned into inputs. Zero values are replaced by defaults
+type ImageConfig struct {
+	//Width and Height are the size images are resized to (nearest neighbour), the size of the first image by default
+	Width  int
+	Height int
+	//Gray converts the images to grayscale: one value per pixel instead of three (red, green, blue)
+	Gray bool
+}
+
+//ImageVector returns the pixels of img resized to w*h (nearest neighbour) as a new colomn vector of values in [0,1]. Grayscale images give w*h values row by row, color images 3*w*h values: the red plane, then green and blue
+func ImageVector(img image.Image, w, h int, gray bool) *mat.M64 {
+	b := img.Bounds()
+	planes := 3
+	if gray {
+		planes = 1
+	}
+	v := mat.NewM64(planes*w*h, 1, nil)
+	for y := 0; y < h; y++ {
+		sy := b.Min.Y + y*b.Dy()/h
+		for x := 0; x < w; x++ {
+			sx := b.Min.X + x*b.Dx()/w
+			r, g, bl, _ := img.At(sx, sy).RGBA()
+			if gray {
+				//same weights as color.GrayModel
+				v.Set(y*w+x, 0, (299*float64(r)+587*float64(g)+114*float64(bl))/(1000*0xffff))
+				continue
+			}
+			for p, c := range []uint32{r, g, bl} {
+				v.Set(p*w*h+y*w+x, 0, float64(c)/0xffff)
+			}
+		}
+	}
+	return v
+}
+
+//ImageDataset is an in-memory Dataset of labelled images
+type ImageDataset struct {
+	*Memory
+	classes []string
+}
+
+//Classes returns the class names, in the order of the one-hot encoding
+func (d *ImageDataset) Classes() []string {
+	return append([]string(nil), d.classes...)
+}
+
+//ReadImageDir reads the PNG and JPEG images of a directory with a sub-directory per class: dir/<class>/<image>. Classes are sorted by name. Each input is the ImageVector of an image, each expected output the one-hot vector of its class
+func ReadImageDir(dir string, cfg *ImageConfig) (*ImageDataset, error) {
+	var c ImageConfig
+	if cfg != nil {
+		c = *cfg
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	d := &ImageDataset{}
+	for _, e := range entries {
+		if e.IsDir() {
+			d.classes = append(d.classes, e.Name())
+		}
+	}
+	sort.Strings(d.classes)
+	if len(d.classes) == 0 {
+		return nil, fmt.Errorf("no class directory in %s", dir)
+	}
+	var points []*Datapoint
+	for ci, class := range d.classes {
+		files, err := ioutil.ReadDir(filepath.Join(dir, class))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			ext := strings.ToLower(filepath.Ext(f.Name()))
+			if f.IsDir() || (ext != ".png" && ext != ".jpg" && ext != ".jpeg") {
+				continue
+			}
+			path := filepath.Join(dir, class, f.Name())
+			img, err := decodeImage(path)
+			if err != nil {
+				return nil, err
+			}
+			if c.Width <= 0 || c.Height <= 0 {
+				c.Width, c.Height = img.Bounds().Dx(), img.Bounds().Dy()
+			}
+			exp := mat.NewM64(len(d.classes), 1, nil)
+			exp.Set(ci, 0, 1)
+			points = append(points, &Datapoint{Inp: ImageVector(img, c.Width, c.Height, c.Gray), Exp: exp})
+		}
+	}
+	if len(points) == 0 {
+		return nil, fmt.Errorf("no image in %s", dir)
+	}
+	d.Memory = newMemory(points)
+	return d, nil
+}
+
+//decodeImage decodes the image file at path
+func decodeImage(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err.Error())
+	}
+	return img, nil
+}
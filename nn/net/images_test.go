@@ -0,0 +1,100 @@
+package nn
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+//writeImage encodes img at path, as PNG or JPEG after the extension
+func writeImage(t *testing.T, path string, img image.Image) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if filepath.Ext(path) == ".png" {
+		err = png.Encode(f, img)
+	} else {
+		err = jpeg.Encode(f, img, &jpeg.Options{Quality: 100})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+//uniform returns a w*h image of a single color
+func uniform(w, h int, c color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestImageVector(t *testing.T) {
+	te := tester.New(t)
+	img := image.NewGray(image.Rect(0, 0, 2, 2))
+	img.Pix = []uint8{0, 255, 51, 255}
+	te.DeepEqual(0, "gray", mat.NewM64(4, 1, []float64{0, 1, 0.2, 1}), ImageVector(img, 2, 2, true))
+	//nearest neighbour downsampling keeps the top left pixels
+	te.DeepEqual(0, "resized", mat.NewM64(1, 1, []float64{0}), ImageVector(img, 1, 1, true))
+	rgb := uniform(1, 2, color.RGBA{R: 255, G: 0, B: 51, A: 255})
+	te.DeepEqual(1, "planes", mat.NewM64(6, 1, []float64{1, 1, 0, 0, 0.2, 0.2}), ImageVector(rgb, 1, 2, false))
+}
+
+func TestReadImageDir(t *testing.T) {
+	te := tester.New(t)
+	dir := t.TempDir()
+	writeImage(t, filepath.Join(dir, "white", "a.png"), uniform(4, 4, color.White))
+	writeImage(t, filepath.Join(dir, "white", "b.jpg"), uniform(8, 8, color.White))
+	writeImage(t, filepath.Join(dir, "black", "c.png"), uniform(4, 4, color.Black))
+	te.CompareError(0, nil, ioutil.WriteFile(filepath.Join(dir, "black", "notes.txt"), []byte("skipped"), 0644))
+
+	d, err := ReadImageDir(dir, &ImageConfig{Width: 2, Height: 2, Gray: true})
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "classes", []string{"black", "white"}, d.Classes())
+	te.DeepEqual(0, "size", 3, d.Size())
+	te.DeepEqual(0, "black", mat.NewM64(4, 1, nil), d.At(0).Inp)
+	te.DeepEqual(0, "black label", 0, Label(d.At(0)))
+	for i := 1; i < 3; i++ {
+		for j := 0; j < 4; j++ {
+			if v := d.At(i).Inp.At(j, 0); v < 0.99 {
+				te.DeepEqual(i, "white", 1.0, v)
+			}
+		}
+		te.DeepEqual(i, "white label", 1, Label(d.At(i)))
+	}
+
+	//default size is the first image's, in color
+	d, err = ReadImageDir(dir, nil)
+	te.CompareError(1, nil, err)
+	r, _ := d.At(2).Inp.Dims()
+	te.DeepEqual(1, "inputs", 3*4*4, r)
+
+	_, err = ReadImageDir(filepath.Join(dir, "white"), nil)
+	te.CompareError(2, fmt.Errorf("no class directory in %s", filepath.Join(dir, "white")), err)
+	empty := t.TempDir()
+	os.Mkdir(filepath.Join(empty, "none"), 0755)
+	_, err = ReadImageDir(empty, nil)
+	te.CompareError(3, fmt.Errorf("no image in %s", empty), err)
+	bad := t.TempDir()
+	te.CompareError(4, nil, os.Mkdir(filepath.Join(bad, "x"), 0755))
+	te.CompareError(4, nil, ioutil.WriteFile(filepath.Join(bad, "x", "broken.png"), []byte("not a png"), 0644))
+	_, err = ReadImageDir(bad, nil)
+	te.CompareError(4, fmt.Errorf("%s: image: unknown format", filepath.Join(bad, "x", "broken.png")), err)
+}
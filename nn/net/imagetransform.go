@@ -0,0 +1,75 @@
+package nn
+
+import (
+	"fmt"
+	"math/rand"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//ImageShape describes the layout of an image input, as built by ImageVector: Channels planes of Height rows of Width values
+type ImageShape struct {
+	Width    int
+	Height   int
+	Channels int
+}
+
+//check returns an error if inp does not hold an image of shape s
+func (s ImageShape) check(inp *mat.M64) error {
+	r, c := inp.Dims()
+	if exp := s.Width * s.Height * s.Channels; r*c != exp || exp == 0 {
+		return fmt.Errorf("input has %d values, expected %d", r*c, exp)
+	}
+	return nil
+}
+
+//Flip returns a new dataset mirroring the image inputs of shape s left to right with probability ratio, drawn from src. ratio is clamped to [0,1]
+func Flip(d Dataset, src rand.Source, s ImageShape, ratio float64) (*Mapped, error) {
+	if err := checkRandom(d, src); err != nil {
+		return nil, err
+	}
+	rnd := rand.New(src)
+	ratio = clampRatio(ratio)
+	return Map(d, func(p *Datapoint) (*Datapoint, error) {
+		if err := s.check(p.Inp); err != nil {
+			return nil, err
+		}
+		if rnd.Float64() >= ratio {
+			return p, nil
+		}
+		_, c := p.Inp.Dims()
+		return &Datapoint{Inp: mapInput(p.Inp, func(_ float64, i int) float64 {
+			x := i % s.Width
+			j := i - x + s.Width - 1 - x
+			return p.Inp.At(j/c, j%c)
+		}), Exp: p.Exp}, nil
+	})
+}
+
+//Crop returns a new dataset cropping the image inputs of shape s to a width*height window at a random position drawn from src. Inputs become colomn vectors of Channels*height*width values
+func Crop(d Dataset, src rand.Source, s ImageShape, width, height int) (*Mapped, error) {
+	if err := checkRandom(d, src); err != nil {
+		return nil, err
+	}
+	if width < 1 || height < 1 || width > s.Width || height > s.Height {
+		return nil, fmt.Errorf("crop %d*%d does not fit in the image %d*%d", width, height, s.Width, s.Height)
+	}
+	rnd := rand.New(src)
+	return Map(d, func(p *Datapoint) (*Datapoint, error) {
+		if err := s.check(p.Inp); err != nil {
+			return nil, err
+		}
+		x0, y0 := rnd.Intn(s.Width-width+1), rnd.Intn(s.Height-height+1)
+		_, c := p.Inp.Dims()
+		res := mat.NewM64(s.Channels*width*height, 1, nil)
+		for ch := 0; ch < s.Channels; ch++ {
+			for y := 0; y < height; y++ {
+				for x := 0; x < width; x++ {
+					j := ch*s.Width*s.Height + (y0+y)*s.Width + x0 + x
+					res.Set(ch*width*height+y*width+x, 0, p.Inp.At(j/c, j%c))
+				}
+			}
+		}
+		return &Datapoint{Inp: res, Exp: p.Exp}, nil
+	})
+}
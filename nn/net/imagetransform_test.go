@@ -0,0 +1,69 @@
+package nn
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+//image3x2 returns a dataset of a single 3*2 image with 2 channels, values 0 to 11
+func image3x2() *Memory {
+	inp := mat.NewM64(12, 1, nil)
+	for i := 0; i < 12; i++ {
+		inp.Set(i, 0, float64(i))
+	}
+	d, _ := NewMemory([]*Datapoint{{Inp: inp, Exp: mat.NewM64(1, 1, nil)}})
+	return d
+}
+
+func TestFlip(t *testing.T) {
+	te := tester.New(t)
+	s := ImageShape{Width: 3, Height: 2, Channels: 2}
+	d := image3x2()
+	f, err := Flip(d, rand.NewSource(1), s, 1)
+	te.CompareError(0, nil, err)
+	p := f.Next()
+	te.DeepEqual(0, "flipped", mat.NewM64(12, 1, []float64{2, 1, 0, 5, 4, 3, 8, 7, 6, 11, 10, 9}), p.Inp)
+	d.Reset()
+	f, _ = Flip(d, rand.NewSource(1), s, 0)
+	p = f.Next()
+	te.DeepEqual(1, "kept", d.At(0), p)
+	d.Reset()
+	f, _ = Flip(d, rand.NewSource(1), ImageShape{Width: 2, Height: 2, Channels: 1}, 1)
+	te.DeepEqual(2, "stopped", (*Datapoint)(nil), f.Next())
+	te.CompareError(2, fmt.Errorf("input has 12 values, expected 4"), f.Err())
+	_, err = Flip(nil, rand.NewSource(1), s, 1)
+	te.CompareError(3, fmt.Errorf("dataset is nil"), err)
+}
+
+func TestCrop(t *testing.T) {
+	te := tester.New(t)
+	s := ImageShape{Width: 3, Height: 2, Channels: 2}
+	d := image3x2()
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		d.Reset()
+		c, _ := Crop(d, rand.NewSource(int64(i)), s, 2, 2)
+		p := c.Next()
+		seen[fmt.Sprint(p.Inp)] = true
+	}
+	te.DeepEqual(0, "both positions", map[string]bool{
+		fmt.Sprint(mat.NewM64(8, 1, []float64{0, 1, 3, 4, 6, 7, 9, 10})):  true,
+		fmt.Sprint(mat.NewM64(8, 1, []float64{1, 2, 4, 5, 7, 8, 10, 11})): true,
+	}, seen)
+	d.Reset()
+	c, err := Crop(d, rand.NewSource(1), s, 3, 2)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "whole", d.At(0).Inp, c.Next().Inp)
+	_, err = Crop(d, rand.NewSource(1), s, 4, 1)
+	te.CompareError(2, fmt.Errorf("crop 4*1 does not fit in the image 3*2"), err)
+	_, err = Crop(d, nil, s, 2, 2)
+	te.CompareError(3, fmt.Errorf("random source src is nil"), err)
+	d.Reset()
+	c, _ = Crop(d, rand.NewSource(1), ImageShape{Width: 2, Height: 2, Channels: 1}, 2, 2)
+	te.DeepEqual(4, "stopped", (*Datapoint)(nil), c.Next())
+	te.CompareError(4, fmt.Errorf("input has 12 values, expected 4"), c.Err())
+}
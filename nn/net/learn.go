@@ -70,15 +70,50 @@ func (t *FFNTrainer) Validate() error {
 	if t.training == nil || t.training.Size() == 0 {
 		return fmt.Errorf("training set is empty")
 	}
+	//a dataset of unknown size (like a CSVStream on a reader unable to seek) serves a single pass, and every epoch after the first would be empty
+	if t.training.Size() < 0 {
+		return fmt.Errorf("training set has an unknown size, it must be able to reset")
+	}
+	if t.validation != nil && t.validation.Size() < 0 {
+		return fmt.Errorf("validation set has an unknown size, it must be able to reset")
+	}
 	if t.test == nil || t.test.Size() == 0 {
 		return fmt.Errorf("test set is empty")
 	}
+	if t.test.Size() < 0 {
+		return fmt.Errorf("test set has an unknown size, it must be able to reset")
+	}
 	if t.maxiter < 20 {
 		t.maxiter = 20
 	}
 	return nil
 }
 
+//erring is implemented by the Datasets which stop on an error, like CSVStream, Mapped or Mixed
+type erring interface {
+	Err() error
+}
+
+//datasetErr returns the error which stopped d, nil if d has none or does not report errors
+func datasetErr(d Dataset) error {
+	if e, ok := d.(erring); ok {
+		return e.Err()
+	}
+	return nil
+}
+
+//sum returns the sum of the elements of m
+func sum(m *mat.M64) float64 {
+	s := 0.0
+	r, c := m.Dims()
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			s += m.At(i, j)
+		}
+	}
+	return s
+}
+
 //selectDrops provides a random selection of neurons to be deactivated
 func selectDrops(r rand.Source, dropSize, fleetSize int) map[int]struct{} {
 	if fleetSize <= 0 {
@@ -130,12 +165,13 @@ func (t *FFNTrainer) WithBackprop(r rand.Source, dropOutPeriod uint, dropOutRati
 		if data == nil {
 			break
 		}
-		pred, err := t.n.Feed(data.Inp)
+		pred, err := t.n.forward(data.Inp)
 		if err != nil {
 			return t.n, fmt.Errorf("failed during training: datapoint[%d]: %s", ind, err.Error())
 		}
 		//compute loss
-		d, err := mat.Sub(data.Exp, pred)
+		d := t.n.ws.diff
+		err = mat.SubTo(d, data.Exp, pred)
 		if err != nil {
 			return t.n, fmt.Errorf("failed during training: datapoint[%d]: deviation: %s", ind, err.Error())
 		}
@@ -143,9 +179,12 @@ func (t *FFNTrainer) WithBackprop(r rand.Source, dropOutPeriod uint, dropOutRati
 		if err != nil {
 			return t.n, fmt.Errorf("failed during training: datapoint[%d]: cost: %s", ind, err.Error())
 		}
-		loss+=
+		loss += sum(d)
 		ind++
 	}
+	if err := datasetErr(t.training); err != nil {
+		return t.n, fmt.Errorf("failed during training: datapoint[%d]: %s", ind, err.Error())
+	}
 	t.l.Printf("Training: Total Average Loss = %f", loss)
 	//validation set
 	if t.validation != nil && t.validation.Size() > 0 {
@@ -160,6 +199,9 @@ func (t *FFNTrainer) WithBackprop(r rand.Source, dropOutPeriod uint, dropOutRati
 			}
 
 		}
+		if err := datasetErr(t.training); err != nil {
+			return t.n, fmt.Errorf("failed during validation: %s", err.Error())
+		}
 		t.l.Printf("Validation: Total Average Loss = %f", loss)
 	}
 	//test set
@@ -1,9 +1,15 @@
 package nn
 
 import (
+	"fmt"
+	"io/ioutil"
+	"log"
 	"math/rand"
+	"strings"
 	"testing"
 
+	mat "github.com/twiggg/math/mat64"
+
 	"github.com/twiggg/tester"
 )
 
@@ -25,3 +31,45 @@ func TestSelectDrops(t *testing.T) {
 		te.DeepEqual(ind, "res", test.res, res)
 	}
 }
+
+func TestFFNTrainerValidate(t *testing.T) {
+	te := tester.New(t)
+	ff := newTestFFN()
+	mem, _ := NewMemory(points(4, 2))
+	stream := func() Dataset {
+		s, _ := NewCSVStream(pipe{strings.NewReader("1,0\n2,1\n")}, &CSVConfig{Targets: []int{1}})
+		return s
+	}
+	tests := []struct {
+		training, validation, test Dataset
+		err                        error
+	}{
+		{mem, nil, mem, nil},
+		{stream(), nil, mem, fmt.Errorf("training set has an unknown size, it must be able to reset")},
+		{mem, stream(), mem, fmt.Errorf("validation set has an unknown size, it must be able to reset")},
+		{mem, nil, stream(), fmt.Errorf("test set has an unknown size, it must be able to reset")},
+	}
+	for ind, test := range tests {
+		_, err := NewFFNTrainer(ff, dftLogger, test.training, test.validation, test.test, 20, 0)
+		te.CompareError(ind, test.err, err)
+	}
+}
+
+func TestWithBackpropStreamError(t *testing.T) {
+	te := tester.New(t)
+	ff := newTestFFN()
+	mem, _ := NewMemoryFromM64(mat.NewM64(4, 3, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1, 1, 1, 1}), mat.NewM64(4, 2, nil))
+	//the third Datapoint is bad, like a bad row in the middle of a file
+	served := 0
+	bad, _ := Map(mem, func(p *Datapoint) (*Datapoint, error) {
+		served++
+		if served == 3 {
+			return nil, fmt.Errorf("row 3, colomn 1: missing value")
+		}
+		return p, nil
+	})
+	tr, err := NewFFNTrainer(ff, log.New(ioutil.Discard, "", 0), bad, nil, mem, 20, 0)
+	te.CompareError(0, nil, err)
+	_, err = tr.WithBackprop(rand.NewSource(1), 0, 0.5, func(x float64) float64 { return x * x })
+	te.CompareError(0, fmt.Errorf("failed during training: datapoint[2]: row 3, colomn 1: missing value"), err)
+}
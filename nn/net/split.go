@@ -0,0 +1,214 @@
+package nn
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+//Label returns the class of a Datapoint for classification: the index of the largest expected output, or the expected output itself if it holds a single value
+func Label(p *Datapoint) int {
+	r, c := p.Exp.Dims()
+	if r*c == 1 {
+		return int(math.Round(p.Exp.At(0, 0)))
+	}
+	best, bi := math.Inf(-1), 0
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if v := p.Exp.At(i, j); v > best {
+				best, bi = v, i*c+j
+			}
+		}
+	}
+	return bi
+}
+
+//permutation returns the indices 0..n-1, shuffled with src unless it is nil
+func permutation(n int, src rand.Source) []int {
+	if src == nil {
+		idx := make([]int, n)
+		for i := range idx {
+			idx[i] = i
+		}
+		return idx
+	}
+	return rand.New(src).Perm(n)
+}
+
+//checkRatios returns an error if the training and validation ratios are not valid
+func checkRatios(train, validation float64) error {
+	if !(train >= 0) || !(validation >= 0) || train+validation > 1 {
+		return fmt.Errorf("ratios must not be negative and must sum to at most 1")
+	}
+	return nil
+}
+
+//cut splits idx into three parts of ratios train, validation and the remainder, each sorted
+func cut(idx []int, train, validation float64) [3][]int {
+	n := len(idx)
+	nt := int(math.Round(train * float64(n)))
+	nv := int(math.Round(validation * float64(n)))
+	if nt+nv > n {
+		nv = n - nt
+	}
+	parts := [3][]int{
+		append([]int(nil), idx[:nt]...),
+		append([]int(nil), idx[nt:nt+nv]...),
+		append([]int(nil), idx[nt+nv:]...),
+	}
+	for _, p := range parts {
+		sort.Ints(p)
+	}
+	return parts
+}
+
+//Split splits d at random into training, validation and test sets, holding the ratios train, validation and the remainder of the Datapoints. src drives the selection, a nil src keeps the original order. Sets may be empty
+func Split(d *Memory, src rand.Source, train, validation float64) (*Memory, *Memory, *Memory, error) {
+	if d == nil {
+		return nil, nil, nil, fmt.Errorf("dataset is nil")
+	}
+	if err := checkRatios(train, validation); err != nil {
+		return nil, nil, nil, err
+	}
+	parts := cut(permutation(d.Size(), src), train, validation)
+	return d.Subset(parts[0]), d.Subset(parts[1]), d.Subset(parts[2]), nil
+}
+
+//byClass returns the indices of the Datapoints of d grouped by Label, classes sorted
+func byClass(d *Memory) [][]int {
+	groups := map[int][]int{}
+	var classes []int
+	for i, p := range d.points {
+		l := Label(p)
+		if _, ok := groups[l]; !ok {
+			classes = append(classes, l)
+		}
+		groups[l] = append(groups[l], i)
+	}
+	sort.Ints(classes)
+	res := make([][]int, len(classes))
+	for i, c := range classes {
+		res[i] = groups[c]
+	}
+	return res
+}
+
+//shuffleGroups shuffles each group in place with rnd, if not nil
+func shuffleGroups(groups [][]int, rnd *rand.Rand) {
+	if rnd == nil {
+		return
+	}
+	for _, g := range groups {
+		rnd.Shuffle(len(g), func(i, j int) { g[i], g[j] = g[j], g[i] })
+	}
+}
+
+func newRand(src rand.Source) *rand.Rand {
+	if src == nil {
+		return nil
+	}
+	return rand.New(src)
+}
+
+//StratifiedSplit is Split applied to each class (see Label) separately, so that every set keeps the class proportions of d
+func StratifiedSplit(d *Memory, src rand.Source, train, validation float64) (*Memory, *Memory, *Memory, error) {
+	if d == nil {
+		return nil, nil, nil, fmt.Errorf("dataset is nil")
+	}
+	if err := checkRatios(train, validation); err != nil {
+		return nil, nil, nil, err
+	}
+	groups := byClass(d)
+	shuffleGroups(groups, newRand(src))
+	var all [3][]int
+	for _, g := range groups {
+		for i, p := range cut(g, train, validation) {
+			all[i] = append(all[i], p...)
+		}
+	}
+	for _, p := range all {
+		sort.Ints(p)
+	}
+	return d.Subset(all[0]), d.Subset(all[1]), d.Subset(all[2]), nil
+}
+
+//KFold iterates over the k folds of a k-fold cross-validation: each fold serves once as the validation set, the others forming the training set
+type KFold struct {
+	d     *Memory
+	folds [][]int
+	i     int
+}
+
+//NewKFold returns a new k-fold iterator over d. src shuffles the Datapoints before they are dealt into folds of sizes differing by at most 1, a nil src keeps contiguous folds
+func NewKFold(d *Memory, k int, src rand.Source) (*KFold, error) {
+	if err := checkFolds(d, k); err != nil {
+		return nil, err
+	}
+	idx := permutation(d.Size(), src)
+	folds := make([][]int, k)
+	n := d.Size()
+	for f := range folds {
+		folds[f] = append([]int(nil), idx[f*n/k:(f+1)*n/k]...)
+		sort.Ints(folds[f])
+	}
+	return &KFold{d: d, folds: folds}, nil
+}
+
+//NewStratifiedKFold returns a new k-fold iterator over d whose folds keep the class proportions of d (see Label): the Datapoints of each class are dealt in turn to the folds
+func NewStratifiedKFold(d *Memory, k int, src rand.Source) (*KFold, error) {
+	if err := checkFolds(d, k); err != nil {
+		return nil, err
+	}
+	groups := byClass(d)
+	shuffleGroups(groups, newRand(src))
+	folds := make([][]int, k)
+	f := 0
+	for _, g := range groups {
+		for _, i := range g {
+			folds[f] = append(folds[f], i)
+			f = (f + 1) % k
+		}
+	}
+	for _, fold := range folds {
+		sort.Ints(fold)
+	}
+	return &KFold{d: d, folds: folds}, nil
+}
+
+func checkFolds(d *Memory, k int) error {
+	if d == nil {
+		return fmt.Errorf("dataset is nil")
+	}
+	if k < 2 || k > d.Size() {
+		return fmt.Errorf("k must be between 2 and %d", d.Size())
+	}
+	return nil
+}
+
+//K returns the number of folds
+func (f *KFold) K() int {
+	return len(f.folds)
+}
+
+//Next returns the training and validation sets of the next fold, false when all folds were served
+func (f *KFold) Next() (*Memory, *Memory, bool) {
+	if f.i >= len(f.folds) {
+		return nil, nil, false
+	}
+	var train []int
+	for j, fold := range f.folds {
+		if j != f.i {
+			train = append(train, fold...)
+		}
+	}
+	sort.Ints(train)
+	val := f.folds[f.i]
+	f.i++
+	return f.d.Subset(train), f.d.Subset(val), true
+}
+
+//Reset starts again from the first fold, with the same folds
+func (f *KFold) Reset() {
+	f.i = 0
+}
@@ -0,0 +1,143 @@
+package nn
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+//classCount returns the number of Datapoints of d in each class
+func classCount(d *Memory) map[int]int {
+	res := map[int]int{}
+	for i := 0; i < d.Size(); i++ {
+		res[Label(d.At(i))]++
+	}
+	return res
+}
+
+//union returns the sorted inputs of the sets
+func union(sets ...*Memory) []float64 {
+	var res []float64
+	for _, s := range sets {
+		s.Reset()
+		res = append(res, drain(s)...)
+	}
+	sort.Float64s(res)
+	return res
+}
+
+func TestLabel(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		exp *mat.M64
+		res int
+	}{
+		{mat.NewM64(3, 1, []float64{0, 0, 1}), 2},
+		{mat.NewM64(1, 3, []float64{0.1, 0.7, 0.2}), 1},
+		{mat.NewM64(1, 1, []float64{4}), 4},
+		{mat.NewM64(1, 1, []float64{0.9}), 1},
+	}
+	for ind, test := range tests {
+		te.DeepEqual(ind, "label", test.res, Label(&Datapoint{Exp: test.exp}))
+	}
+}
+
+func TestSplit(t *testing.T) {
+	te := tester.New(t)
+	d, _ := NewMemory(points(20, 2))
+	all := union(d)
+	train, val, test, err := Split(d, rand.NewSource(3), 0.6, 0.25)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "sizes", []int{12, 5, 3}, []int{train.Size(), val.Size(), test.Size()})
+	te.DeepEqual(0, "partition", all, union(train, val, test))
+
+	//nil source keeps the order
+	train, val, test, err = Split(d, nil, 0.5, 0)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "train", []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, drain(train))
+	te.DeepEqual(1, "empty validation", 0, val.Size())
+	te.DeepEqual(1, "test", 10, test.Size())
+
+	_, _, _, err = Split(d, nil, 0.8, 0.3)
+	te.CompareError(2, fmt.Errorf("ratios must not be negative and must sum to at most 1"), err)
+	_, _, _, err = Split(nil, nil, 0.8, 0.1)
+	te.CompareError(3, fmt.Errorf("dataset is nil"), err)
+}
+
+func TestStratifiedSplit(t *testing.T) {
+	te := tester.New(t)
+	//30 points of class 0, 10 of class 1
+	pts := points(40, 4)
+	for _, p := range pts {
+		if Label(p) != 3 {
+			p.Exp = mat.NewM64(2, 1, []float64{1, 0})
+		} else {
+			p.Exp = mat.NewM64(2, 1, []float64{0, 1})
+		}
+	}
+	d, _ := NewMemory(pts)
+	train, val, test, err := StratifiedSplit(d, rand.NewSource(5), 0.5, 0.3)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "train", map[int]int{0: 15, 1: 5}, classCount(train))
+	te.DeepEqual(0, "validation", map[int]int{0: 9, 1: 3}, classCount(val))
+	te.DeepEqual(0, "test", map[int]int{0: 6, 1: 2}, classCount(test))
+	te.DeepEqual(0, "partition", union(d), union(train, val, test))
+}
+
+func TestKFold(t *testing.T) {
+	te := tester.New(t)
+	d, _ := NewMemory(points(10, 2))
+	kf, err := NewKFold(d, 3, nil)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "k", 3, kf.K())
+	var vals []float64
+	sizes := []int{}
+	for train, val, ok := kf.Next(); ok; train, val, ok = kf.Next() {
+		te.DeepEqual(0, "partition", union(d), union(train, val))
+		vals = append(vals, union(val)...)
+		sizes = append(sizes, val.Size())
+	}
+	te.DeepEqual(0, "contiguous folds", []int{3, 3, 4}, sizes)
+	sort.Float64s(vals)
+	te.DeepEqual(0, "each once", union(d), vals)
+	kf.Reset()
+	_, val, ok := kf.Next()
+	te.DeepEqual(0, "reset", true, ok)
+	te.DeepEqual(0, "first fold", []float64{0, 1, 2}, drain(val))
+
+	kf, err = NewKFold(d, 5, rand.NewSource(2))
+	te.CompareError(1, nil, err)
+	vals = nil
+	for _, val, ok := kf.Next(); ok; _, val, ok = kf.Next() {
+		te.DeepEqual(1, "size", 2, val.Size())
+		vals = append(vals, union(val)...)
+	}
+	sort.Float64s(vals)
+	te.DeepEqual(1, "each once", union(d), vals)
+
+	_, err = NewKFold(d, 1, nil)
+	te.CompareError(2, fmt.Errorf("k must be between 2 and 10"), err)
+	_, err = NewKFold(d, 11, nil)
+	te.CompareError(3, fmt.Errorf("k must be between 2 and 10"), err)
+	_, err = NewStratifiedKFold(nil, 2, nil)
+	te.CompareError(4, fmt.Errorf("dataset is nil"), err)
+}
+
+func TestStratifiedKFold(t *testing.T) {
+	te := tester.New(t)
+	d, _ := NewMemory(points(12, 3))
+	kf, err := NewStratifiedKFold(d, 4, rand.NewSource(9))
+	te.CompareError(0, nil, err)
+	for ind := 0; ; ind++ {
+		train, val, ok := kf.Next()
+		if !ok {
+			break
+		}
+		te.DeepEqual(ind, "validation classes", 3, len(classCount(val)))
+		te.DeepEqual(ind, "train size", 9, train.Size())
+	}
+}
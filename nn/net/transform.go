@@ -0,0 +1,145 @@
+package nn
+
+import (
+	"fmt"
+	"math/rand"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//wrapper holds the inner Dataset of a transform, and passes Size, Left and Reset on to it
+type wrapper struct {
+	inner Dataset
+}
+
+//Size returns the number of Datapoints of the inner dataset
+func (w *wrapper) Size() int {
+	return w.inner.Size()
+}
+
+//Left returns the number of Datapoints of the inner dataset not served yet
+func (w *wrapper) Left() int {
+	return w.inner.Left()
+}
+
+//Reset starts a new pass over the inner dataset
+func (w *wrapper) Reset() {
+	w.inner.Reset()
+}
+
+//Mapped is a Dataset applying a function to each Datapoint of an inner dataset, on the fly. Transforms never modify the Datapoints they receive: they return new ones, which may share unchanged matrices. An error stops the dataset, see Err
+type Mapped struct {
+	wrapper
+	fn  func(p *Datapoint) (*Datapoint, error)
+	err error
+}
+
+//Map returns a new dataset serving fn(p) for each Datapoint p of d
+func Map(d Dataset, fn func(p *Datapoint) (*Datapoint, error)) (*Mapped, error) {
+	if d == nil {
+		return nil, fmt.Errorf("dataset is nil")
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("fn is nil")
+	}
+	return &Mapped{wrapper: wrapper{inner: d}, fn: fn}, nil
+}
+
+//Next returns the next transformed Datapoint, nil at the end or on error
+func (m *Mapped) Next() *Datapoint {
+	if m.err != nil {
+		return nil
+	}
+	p := m.inner.Next()
+	if p == nil {
+		return nil
+	}
+	res, err := m.fn(p)
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	return res
+}
+
+//Err returns the error which stopped the dataset, nil otherwise
+func (m *Mapped) Err() error {
+	return m.err
+}
+
+//Reset starts a new pass over the inner dataset, clearing the error
+func (m *Mapped) Reset() {
+	m.err = nil
+	m.inner.Reset()
+}
+
+//checkRandom returns an error if the inner dataset or the random source of a transform is nil
+func checkRandom(d Dataset, src rand.Source) error {
+	if d == nil {
+		return fmt.Errorf("dataset is nil")
+	}
+	if src == nil {
+		return fmt.Errorf("random source src is nil")
+	}
+	return nil
+}
+
+//mapInput returns a new matrix with fn applied to each value of the input, with its index
+func mapInput(inp *mat.M64, fn func(v float64, i int) float64) *mat.M64 {
+	r, c := inp.Dims()
+	res := mat.NewM64(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			res.Set(i, j, fn(inp.At(i, j), i*c+j))
+		}
+	}
+	return res
+}
+
+//Shuffled is a Dataset serving the Datapoints of an inner dataset in a random order, drawn from a buffer of a few Datapoints. The inner dataset is read once per pass, so it may be a stream larger than memory
+type Shuffled struct {
+	wrapper
+	rnd    *rand.Rand
+	size   int
+	buffer []*Datapoint
+}
+
+//Shuffle returns a new dataset serving the Datapoints of d in a random order drawn from src. Each Datapoint served is picked at random among the next buffer ones of d, a buffer <= 0 holding the whole dataset for a uniform shuffle
+func Shuffle(d Dataset, src rand.Source, buffer int) (*Shuffled, error) {
+	if err := checkRandom(d, src); err != nil {
+		return nil, err
+	}
+	return &Shuffled{wrapper: wrapper{inner: d}, rnd: rand.New(src), size: buffer}, nil
+}
+
+//Next returns a Datapoint picked at random in the buffer, refilled from the inner dataset
+func (s *Shuffled) Next() *Datapoint {
+	for s.size <= 0 || len(s.buffer) < s.size {
+		p := s.inner.Next()
+		if p == nil {
+			break
+		}
+		s.buffer = append(s.buffer, p)
+	}
+	n := len(s.buffer)
+	if n == 0 {
+		return nil
+	}
+	i := s.rnd.Intn(n)
+	p := s.buffer[i]
+	s.buffer[i] = s.buffer[n-1]
+	s.buffer[n-1] = nil
+	s.buffer = s.buffer[:n-1]
+	return p
+}
+
+//Left returns the number of Datapoints not served yet, those of the buffer included
+func (s *Shuffled) Left() int {
+	return s.inner.Left() + len(s.buffer)
+}
+
+//Reset empties the buffer and starts a new pass over the inner dataset
+func (s *Shuffled) Reset() {
+	s.buffer = s.buffer[:0]
+	s.inner.Reset()
+}
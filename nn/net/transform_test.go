@@ -0,0 +1,107 @@
+package nn
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestMap(t *testing.T) {
+	te := tester.New(t)
+	d, _ := NewMemory(points(4, 2))
+	double, err := Map(d, func(p *Datapoint) (*Datapoint, error) {
+		if p.Inp.At(0, 0) == 3 {
+			return nil, fmt.Errorf("three")
+		}
+		return &Datapoint{Inp: mapInput(p.Inp, func(v float64, _ int) float64 { return 2 * v }), Exp: p.Exp}, nil
+	})
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "size", 4, double.Size())
+	te.DeepEqual(0, "mapped", []float64{0, 2, 4}, drain(double))
+	te.CompareError(0, fmt.Errorf("three"), double.Err())
+	te.DeepEqual(0, "left", 0, double.Left())
+	double.Reset()
+	te.CompareError(1, nil, double.Err())
+	te.DeepEqual(1, "again", 0.0, double.Next().Inp.At(0, 0))
+	//the inner Datapoints are untouched
+	te.DeepEqual(1, "inner", 1.0, d.At(1).Inp.At(0, 0))
+
+	_, err = Map(nil, func(p *Datapoint) (*Datapoint, error) { return p, nil })
+	te.CompareError(2, fmt.Errorf("dataset is nil"), err)
+	_, err = Map(d, nil)
+	te.CompareError(3, fmt.Errorf("fn is nil"), err)
+}
+
+func TestShuffle(t *testing.T) {
+	te := tester.New(t)
+	d, _ := NewMemory(points(20, 2))
+	tests := []struct {
+		buffer int
+	}{
+		{0},
+		{1},
+		{5},
+		{50},
+	}
+	for ind, test := range tests {
+		d.Reset()
+		s, err := Shuffle(d, rand.NewSource(4), test.buffer)
+		te.CompareError(ind, nil, err)
+		te.DeepEqual(ind, "size", 20, s.Size())
+		first := drain(s)
+		te.DeepEqual(ind, "left", 0, s.Left())
+		s.Reset()
+		s.Next()
+		te.DeepEqual(ind, "left after next", 19, s.Left())
+		got := append([]float64(nil), first...)
+		sort.Float64s(got)
+		te.DeepEqual(ind, "permutation", union(d), got)
+		if test.buffer == 1 {
+			te.DeepEqual(ind, "buffer of 1 keeps the order", union(d), first)
+		} else {
+			te.DeepEqual(ind, "shuffled", false, fmt.Sprint(union(d)) == fmt.Sprint(first))
+		}
+	}
+	//a small buffer only moves Datapoints a little ahead
+	d.Reset()
+	s, _ := Shuffle(d, rand.NewSource(1), 3)
+	for i, v := range drain(s) {
+		te.DeepEqual(i, "bounded", true, v <= float64(i+2))
+	}
+	_, err := Shuffle(d, nil, 3)
+	te.CompareError(0, fmt.Errorf("random source src is nil"), err)
+	_, err = Shuffle(nil, rand.NewSource(1), 3)
+	te.CompareError(1, fmt.Errorf("dataset is nil"), err)
+}
+
+func TestChain(t *testing.T) {
+	te := tester.New(t)
+	inputs := mat.NewM64(4, 2, []float64{
+		1, 10,
+		3, 10,
+		5, 30,
+		7, 30,
+	})
+	d, _ := NewMemoryFromM64(inputs, mat.NewM64(4, 1, nil))
+	z := newFittedZScore(t, d)
+	s, err := Shuffle(d, rand.NewSource(2), 0)
+	te.CompareError(0, nil, err)
+	n, err := Normalize(s, z)
+	te.CompareError(0, nil, err)
+	ds, err := Noise(n, rand.NewSource(3), 0)
+	te.CompareError(0, nil, err)
+	var got []float64
+	for p := ds.Next(); p != nil; p = ds.Next() {
+		got = append(got, p.Inp.At(0, 0)+p.Inp.At(1, 0))
+	}
+	sort.Float64s(got)
+	//z-scores: first colomn -1.162,-0.387,0.387,1.162, second -0.866,-0.866,0.866,0.866
+	exp := []float64{-2.0279, -1.2533, 1.2533, 2.0279}
+	for i := range exp {
+		te.DeepEqual(i, "normalized", true, got[i]-exp[i] < 1e-3 && exp[i]-got[i] < 1e-3)
+	}
+}
@@ -0,0 +1,116 @@
+package nn
+
+import (
+	"fmt"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//workspace holds the buffers of the forward and backward passes of a network, so that steady state training allocates nothing.
+//Its buffers never leave the network, except the gradients returned by Gradient
+type workspace struct {
+	input *mat.M64   //last input of the forward pass, not owned
+	z     []*mat.M64 //w*x+b of each layer
+	a     []*mat.M64 //fn(z) of each layer
+	dz    []*mat.M64 //deriv(z) of each layer
+	delta []*mat.M64 //gradient of the error w.r.t. z of each layer, which is also the gradient w.r.t. b
+	gw    []*mat.M64 //gradient of the error w.r.t. w of each layer
+	diff  *mat.M64   //deviation of the output from the expected value
+}
+
+func newWorkspace(layers []*layer) *workspace {
+	n := len(layers)
+	ws := &workspace{
+		z:     make([]*mat.M64, n),
+		a:     make([]*mat.M64, n),
+		dz:    make([]*mat.M64, n),
+		delta: make([]*mat.M64, n),
+		gw:    make([]*mat.M64, n),
+	}
+	for i, l := range layers {
+		ws.z[i] = mat.NewM64(l.outSize, 1, nil)
+		ws.a[i] = mat.NewM64(l.outSize, 1, nil)
+		ws.dz[i] = mat.NewM64(l.outSize, 1, nil)
+		ws.delta[i] = mat.NewM64(l.outSize, 1, nil)
+		ws.gw[i] = mat.NewM64(l.outSize, l.inSize, nil)
+	}
+	if n > 0 {
+		ws.diff = mat.NewM64(layers[n-1].outSize, 1, nil)
+	}
+	return ws
+}
+
+//computeTo sets z to w*x+b and a to fn(z), without allocating
+func (l *layer) computeTo(x, z, a *mat.M64) error {
+	if err := mat.MulTo(z, l.w, x); err != nil {
+		return fmt.Errorf("w*x failed: %s", err.Error())
+	}
+	if err := z.Add(l.b); err != nil {
+		return fmt.Errorf("w*x +b failed: %s", err.Error())
+	}
+	return mat.MapElemTo(a, z, l.fn)
+}
+
+//forward feeds input forward in the workspace of the network without allocating, returns the output layer's state, which is overwritten by the next call
+func (ff *FFN) forward(input *mat.M64) (*mat.M64, error) {
+	if ff.ws == nil {
+		return nil, fmt.Errorf("network has no layers")
+	}
+	in := input
+	for i, l := range ff.layers {
+		if err := l.computeTo(in, ff.ws.z[i], ff.ws.a[i]); err != nil {
+			return nil, fmt.Errorf("layer[%d]: %s", i, err.Error())
+		}
+		in = ff.ws.a[i]
+	}
+	ff.ws.input = input
+	return in, nil
+}
+
+//Backprop feeds input forward then computes the gradients of the squared error ½||out-exp||² w.r.t. the weights and biases of every layer, without allocating.
+//Gradients are kept in the workspace until the next call, see Gradient
+func (ff *FFN) Backprop(input, exp *mat.M64) error {
+	if _, err := ff.forward(input); err != nil {
+		return err
+	}
+	ws := ff.ws
+	last := len(ff.layers) - 1
+	if err := mat.SubTo(ws.delta[last], ws.a[last], exp); err != nil {
+		return fmt.Errorf("deviation: %s", err.Error())
+	}
+	for i := last; i >= 0; i-- {
+		l := ff.layers[i]
+		//delta[i] = (w[i+1]ᵀ*delta[i+1]).*deriv(z[i]), the first factor being already in delta[i]
+		if err := mat.MapElemTo(ws.dz[i], ws.z[i], l.deriv); err != nil {
+			return fmt.Errorf("layer[%d]: %s", i, err.Error())
+		}
+		if err := ws.delta[i].MulElem(ws.dz[i]); err != nil {
+			return fmt.Errorf("layer[%d]: %s", i, err.Error())
+		}
+		in := ws.input
+		if i > 0 {
+			in = ws.a[i-1]
+		}
+		if err := mat.OuterTo(ws.gw[i], ws.delta[i], in); err != nil {
+			return fmt.Errorf("layer[%d]: weight gradient: %s", i, err.Error())
+		}
+		if i > 0 {
+			if err := mat.MulTransTo(ws.delta[i-1], l.w, ws.delta[i]); err != nil {
+				return fmt.Errorf("layer[%d]: %s", i, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+//Gradient returns the gradients of the weights and biases of a layer, computed by the last call to Backprop. They are overwritten by the next call
+func (ff *FFN) Gradient(layerInd int) (*mat.M64, *mat.M64, error) {
+	if ff == nil || ff.ws == nil {
+		return nil, nil, fmt.Errorf("no gradients")
+	}
+	l := len(ff.layers)
+	if layerInd < 0 || layerInd > l-1 {
+		return nil, nil, fmt.Errorf("layer index must be between %d and %d", 0, l-1)
+	}
+	return ff.ws.gw[layerInd], ff.ws.delta[layerInd], nil
+}
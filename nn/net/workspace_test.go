@@ -0,0 +1,126 @@
+package nn
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/math/nn/activation"
+
+	"github.com/twiggg/tester"
+)
+
+func newTestFFN() *FFN {
+	ff, _ := NewFFN(3, true)
+	ff.SetLayers(
+		&LayerConfig{Size: 4, Fn: activation.Sigmoid, Deriv: activation.DerivSigmoid},
+		&LayerConfig{Size: 2, Fn: activation.Sigmoid, Deriv: activation.DerivSigmoid},
+	)
+	ff.layers[0].UpdateData([]float64{0.1, -0.2, 0.3, 0.05, 0.4, 0.1, -0.3, -0.1, -0.5, 0.2, 0.2, 0.3, 0.1, 0.1, 0.1, 0})
+	ff.layers[1].UpdateData([]float64{0.3, -0.1, 0.2, 0.5, 0.1, -0.4, 0.2, 0.3, 0.1, -0.2})
+	return ff
+}
+
+func TestFeedWorkspace(t *testing.T) {
+	te := tester.New(t)
+	ff := newTestFFN()
+	x := mat.NewM64(3, 1, []float64{1, 0.5, -1})
+	out, err := ff.Feed(x)
+	te.CompareError(0, nil, err)
+	h, _ := ff.layers[0].ComputeWith(x)
+	exp, _ := ff.layers[1].ComputeWith(h)
+	te.DeepEqual(0, "out", exp, out)
+	state, err := ff.GetState(0)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "state", h, state)
+	//outputs and states are not overwritten by the next call
+	x2 := mat.NewM64(3, 1, []float64{-1, 2, 0})
+	out2, _ := ff.Feed(x2)
+	te.DeepEqual(0, "out kept", exp, out)
+	te.DeepEqual(0, "state kept", h, state)
+	te.DeepEqual(0, "outputs differ", false, fmt.Sprint(out) == fmt.Sprint(out2))
+	kept := mat.NewDense(out2)
+	ws, _ := ff.forward(x2)
+	te.DeepEqual(0, "forward", out2, ws)
+	ff.forward(x)
+	te.DeepEqual(0, "out kept after forward", kept, out2)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		ff.Backprop(x, exp)
+	})
+	te.DeepEqual(1, "allocs", 0.0, allocs)
+
+	_, err = ff.Feed(mat.NewM64(2, 1, nil))
+	te.CompareError(2, fmt.Errorf("layer[0]: w*x failed: m colomns != n rows"), err)
+	te.CompareError(2, fmt.Errorf("layer[0]: w*x failed: m colomns != n rows"), ff.Backprop(mat.NewM64(2, 1, nil), exp))
+	empty, _ := NewFFN(3, false)
+	_, err = empty.Feed(x)
+	te.CompareError(3, fmt.Errorf("network has no layers"), err)
+	te.CompareError(4, fmt.Errorf("network has no layers"), empty.Backprop(x, x))
+}
+
+func TestFeedConcurrent(t *testing.T) {
+	te := tester.New(t)
+	ff := newTestFFN()
+	ff.keepStates, ff.states = false, nil
+	x := mat.NewM64(3, 1, []float64{1, 0.5, -1})
+	exp, _ := ff.Feed(x)
+	outs := make(chan *mat.M64, 8)
+	for i := 0; i < cap(outs); i++ {
+		go func() {
+			out, _ := ff.Feed(x)
+			outs <- out
+		}()
+	}
+	for i := 0; i < cap(outs); i++ {
+		te.DeepEqual(i, "out", exp, <-outs)
+	}
+}
+
+//halfSquaredError returns ½||ff(x)-exp||²
+func halfSquaredError(ff *FFN, x, exp *mat.M64) float64 {
+	out, _ := ff.Feed(x)
+	e := 0.0
+	for i := 0; i < 2; i++ {
+		d := out.At(i, 0) - exp.At(i, 0)
+		e += 0.5 * d * d
+	}
+	return e
+}
+
+func TestBackprop(t *testing.T) {
+	te := tester.New(t)
+	ff := newTestFFN()
+	x := mat.NewM64(3, 1, []float64{1, 0.5, -1})
+	exp := mat.NewM64(2, 1, []float64{1, 0})
+	te.CompareError(0, nil, ff.Backprop(x, exp))
+	//compare with central finite differences
+	const h = 1e-6
+	for li, l := range ff.layers {
+		gw, gb, err := ff.Gradient(li)
+		te.CompareError(li, nil, err)
+		gw, gb = mat.NewDense(gw), mat.NewDense(gb)
+		for _, p := range []struct {
+			m    *mat.M64
+			grad *mat.M64
+		}{{l.w, gw}, {l.b, gb}} {
+			r, c := p.m.Dims()
+			for i := 0; i < r; i++ {
+				for j := 0; j < c; j++ {
+					v := p.m.At(i, j)
+					p.m.Set(i, j, v+h)
+					ep := halfSquaredError(ff, x, exp)
+					p.m.Set(i, j, v-h)
+					em := halfSquaredError(ff, x, exp)
+					p.m.Set(i, j, v)
+					if num := (ep - em) / (2 * h); math.Abs(num-p.grad.At(i, j)) > 1e-8 {
+						t.Errorf("layer %d: gradient (%d,%d): expected %v, got %v", li, i, j, num, p.grad.At(i, j))
+					}
+				}
+			}
+		}
+	}
+	_, _, err := ff.Gradient(2)
+	te.CompareError(2, fmt.Errorf("layer index must be between 0 and 1"), err)
+}
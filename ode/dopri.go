@@ -0,0 +1,103 @@
+package ode
+
+//Dormand-Prince 5(4) coefficients
+var (
+	dpC = [7]float64{0, 1.0 / 5, 3.0 / 10, 4.0 / 5, 8.0 / 9, 1, 1}
+	dpA = [7][6]float64{
+		{},
+		{1.0 / 5},
+		{3.0 / 40, 9.0 / 40},
+		{44.0 / 45, -56.0 / 15, 32.0 / 9},
+		{19372.0 / 6561, -25360.0 / 2187, 64448.0 / 6561, -212.0 / 729},
+		{9017.0 / 3168, -355.0 / 33, 46732.0 / 5247, 49.0 / 176, -5103.0 / 18656},
+		{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84},
+	}
+	//dpE holds the differences between the fifth and the embedded fourth order weights
+	dpE = [7]float64{71.0 / 57600, 0, -71.0 / 16695, 71.0 / 1920, -17253.0 / 339200, 22.0 / 525, -1.0 / 40}
+	//dpD holds the weights of the fourth order dense output
+	dpD = [7]float64{-12715105075.0 / 11282082432, 0, 87487479700.0 / 32700410799, -10690763975.0 / 1880347072, 701980252875.0 / 199316789632, -1453857185.0 / 822651844, 69997945.0 / 29380423}
+)
+
+//dopri is the Dormand-Prince 5(4) method. The last stage is the derivative at the new state, reused as the first stage of the next step
+type dopri struct {
+	sys *system
+	set Settings
+	k   [7][]float64
+	tmp []float64
+	err []float64
+}
+
+func (m *dopri) order() int { return 4 }
+
+func (m *dopri) step(t float64, y, f []float64, h float64) ([]float64, []float64, float64, segment, error) {
+	k := m.k
+	copy(k[0], f)
+	for s := 1; s < 7; s++ {
+		for i := range y {
+			sum := 0.0
+			for j := 0; j < s; j++ {
+				sum += dpA[s][j] * k[j][i]
+			}
+			m.tmp[i] = y[i] + h*sum
+		}
+		m.sys.f(k[s], t+dpC[s]*h, m.tmp)
+	}
+	//the state of the last stage is the fifth order solution
+	y1 := append([]float64(nil), m.tmp...)
+	f1 := append([]float64(nil), k[6]...)
+	for i := range y {
+		sum := 0.0
+		for j := 0; j < 7; j++ {
+			sum += dpE[j] * k[j][i]
+		}
+		m.err[i] = h * sum
+	}
+	e := errNorm(m.err, y, y1, m.set)
+	seg := &dopriSegment{t0: t, h: h, y0: y, r: make([][]float64, 4)}
+	for j := range seg.r {
+		seg.r[j] = make([]float64, len(y))
+	}
+	for i := range y {
+		diff := y1[i] - y[i]
+		bspl := h*k[0][i] - diff
+		seg.r[0][i] = diff
+		seg.r[1][i] = bspl
+		seg.r[2][i] = diff - h*k[6][i] - bspl
+		sum := 0.0
+		for j := 0; j < 7; j++ {
+			sum += dpD[j] * k[j][i]
+		}
+		seg.r[3][i] = h * sum
+	}
+	return y1, f1, e, seg, nil
+}
+
+//dopriSegment is the fourth order continuous extension of a Dormand-Prince step
+type dopriSegment struct {
+	t0, h float64
+	y0    []float64
+	r     [][]float64
+}
+
+func (s *dopriSegment) at(t float64, dst []float64) {
+	th := (t - s.t0) / s.h
+	th1 := 1 - th
+	for i := range dst {
+		dst[i] = s.y0[i] + th*(s.r[0][i]+th1*(s.r[1][i]+th*(s.r[2][i]+th1*s.r[3][i])))
+	}
+}
+
+//DormandPrince integrates p from T0 to t1 with the adaptive Dormand-Prince 5(4) method: the step is adapted so that the estimated error of each step stays within RelTol and AbsTol. The solution is interpolated between steps by the fourth order dense output of the method
+func DormandPrince(p Problem, t1 float64, s *Settings) (*Solution, error) {
+	sys, y, err := newSystem(p, t1)
+	if err != nil {
+		return nil, err
+	}
+	set := settingsOrDefault(s, t1-p.T0)
+	n := sys.n
+	m := &dopri{sys: sys, set: set, tmp: make([]float64, n), err: make([]float64, n)}
+	for j := range m.k {
+		m.k[j] = make([]float64, n)
+	}
+	return integrate(sys, y, t1, set, m, true, set.Step)
+}
@@ -0,0 +1,44 @@
+package ode
+
+import (
+	"math"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestDormandPrince(t *testing.T) {
+	te := tester.New(t)
+	sol, err := DormandPrince(oscillator, 20, &Settings{RelTol: 1e-9, AbsTol: 1e-12})
+	te.CompareError(0, nil, err)
+	last := sol.Y[len(sol.Y)-1]
+	te.DeepEqual(0, "end", true, math.Abs(last.At(0, 0)-math.Cos(20)) < 1e-7)
+	te.DeepEqual(0, "adaptive", true, sol.Steps < 600)
+	//dense output between steps
+	maxErr := 0.0
+	for x := 0.0; x < 20; x += 0.37 {
+		y, err := sol.At(x)
+		te.CompareError(1, nil, err)
+		maxErr = math.Max(maxErr, math.Abs(y.At(0, 0)-math.Cos(x)))
+	}
+	te.DeepEqual(1, "dense output", true, maxErr < 1e-7)
+
+	//looser tolerances take fewer steps
+	loose, _ := DormandPrince(oscillator, 20, nil)
+	te.DeepEqual(2, "fewer steps", true, loose.Steps < sol.Steps)
+	te.DeepEqual(2, "loose end", true, math.Abs(loose.Y[len(loose.Y)-1].At(0, 0)-math.Cos(20)) < 1e-4)
+
+	//matrix state Y'=A*Y, with A a rotation generator: Y(t)=exp(t*A)
+	a := mat.NewM64(2, 2, []float64{0, -1, 1, 0})
+	p := Problem{
+		F: func(dy *mat.M64, t float64, y *mat.M64) {
+			_ = mat.MulTo(dy, a, y)
+		},
+		Y0: mat.Identity(2),
+	}
+	sol, err = DormandPrince(p, 1, &Settings{RelTol: 1e-10, AbsTol: 1e-12})
+	te.CompareError(3, nil, err)
+	exp, _ := mat.Expm(a)
+	te.DeepEqual(3, "matrix state", true, mat.EqualApprox(exp, sol.Y[len(sol.Y)-1], 1e-8))
+}
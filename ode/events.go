@@ -0,0 +1,80 @@
+package ode
+
+import (
+	"sort"
+
+	"github.com/twiggg/math/optimize"
+)
+
+//events tracks the signs of the event functions along the integration
+type events struct {
+	sys *system
+	g   []float64
+	buf []float64
+}
+
+func newEvents(sys *system, t float64, y []float64) *events {
+	ev := &events{sys: sys, g: make([]float64, len(sys.p.Events)), buf: make([]float64, sys.n)}
+	for i, e := range sys.p.Events {
+		ev.g[i] = e.G(t, sys.wrap(y))
+	}
+	return ev
+}
+
+func (ev *events) crossed(i int, g0, g1 float64) bool {
+	rising := g0 < 0 && g1 >= 0
+	falling := g0 > 0 && g1 <= 0
+	switch ev.sys.p.Events[i].Direction {
+	case 1:
+		return rising
+	case -1:
+		return falling
+	}
+	return rising || falling
+}
+
+//check locates the crossings inside the step [t0,t1] ending at y1. If a terminal event is crossed, the solution is closed at its time and check returns true
+func (ev *events) check(t0, t1 float64, y1 []float64, seg segment) (bool, error) {
+	sys := ev.sys
+	type hit struct {
+		EventHit
+		y []float64
+	}
+	var hits []hit
+	for i, e := range sys.p.Events {
+		g1 := e.G(t1, sys.wrap(y1))
+		g0 := ev.g[i]
+		ev.g[i] = g1
+		if !ev.crossed(i, g0, g1) {
+			continue
+		}
+		tc := t1
+		if g1 != 0 {
+			g := func(t float64) float64 {
+				seg.at(t, ev.buf)
+				return e.G(t, sys.wrap(ev.buf))
+			}
+			res, err := optimize.Brent(g, t0, t1, &optimize.Settings{XTol: 1e-12 * (1 + t1 - t0)})
+			if err != nil {
+				return false, err
+			}
+			tc = res.X
+		}
+		y := make([]float64, sys.n)
+		if tc == t1 {
+			copy(y, y1)
+		} else {
+			seg.at(tc, y)
+		}
+		hits = append(hits, hit{EventHit{Index: i, T: tc, Y: sys.wrap(y)}, y})
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].T < hits[j].T })
+	for _, h := range hits {
+		sys.sol.Events = append(sys.sol.Events, h.EventHit)
+		if sys.p.Events[h.Index].Terminal {
+			sys.push(h.T, h.y, seg)
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,61 @@
+package ode
+
+import (
+	"math"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestEvents(t *testing.T) {
+	te := tester.New(t)
+	//ball dropped from 10m: stops when it hits the ground at √(20/g)
+	const g = 9.81
+	ball := Problem{
+		F: func(dy *mat.M64, t float64, y *mat.M64) {
+			dy.Set(0, 0, y.At(0, 1))
+			dy.Set(0, 1, -g)
+		},
+		Y0:     mat.NewM64(1, 2, []float64{10, 0}),
+		Events: []Event{{G: func(t float64, y *mat.M64) float64 { return y.At(0, 0) }, Terminal: true}},
+	}
+	for i, solve := range []func(Problem, float64, *Settings) (*Solution, error){RK4, DormandPrince, Rosenbrock} {
+		sol, err := solve(ball, 5, nil)
+		te.CompareError(i, nil, err)
+		exp := math.Sqrt(20 / g)
+		te.DeepEqual(i, "one event", 1, len(sol.Events))
+		te.DeepEqual(i, "time", true, math.Abs(sol.Events[0].T-exp) < 1e-8)
+		te.DeepEqual(i, "height", true, math.Abs(sol.Events[0].Y.At(0, 0)) < 1e-7)
+		te.DeepEqual(i, "stopped", sol.Events[0].T, sol.T[len(sol.T)-1])
+		y, err := sol.At(exp / 2)
+		te.CompareError(i, nil, err)
+		te.DeepEqual(i, "before", true, math.Abs(y.At(0, 0)-(10-g*exp*exp/8)) < 1e-7)
+	}
+
+	//zero crossings of cos(t) on [0,10]: π/2, 3π/2 (rising), 5π/2
+	p := oscillator
+	x := func(t float64, y *mat.M64) float64 { return y.At(0, 0) }
+	p.Events = []Event{{G: x}, {G: x, Direction: 1}, {G: x, Direction: -1}}
+	sol, err := DormandPrince(p, 10, &Settings{RelTol: 1e-9, AbsTol: 1e-12})
+	te.CompareError(10, nil, err)
+	var got [3][]float64
+	for _, h := range sol.Events {
+		got[h.Index] = append(got[h.Index], h.T)
+	}
+	near := func(x []float64, exp ...float64) bool {
+		if len(x) != len(exp) {
+			return false
+		}
+		for i := range x {
+			if math.Abs(x[i]-exp[i]) > 1e-8 {
+				return false
+			}
+		}
+		return true
+	}
+	te.DeepEqual(10, "both", true, near(got[0], math.Pi/2, 3*math.Pi/2, 5*math.Pi/2))
+	te.DeepEqual(10, "rising", true, near(got[1], 3*math.Pi/2))
+	te.DeepEqual(10, "falling", true, near(got[2], math.Pi/2, 5*math.Pi/2))
+	te.DeepEqual(10, "not terminal", 10.0, sol.T[len(sol.T)-1])
+}
@@ -0,0 +1,66 @@
+package ode
+
+import (
+	"fmt"
+	"math"
+)
+
+//stepper advances the state by one step
+type stepper interface {
+	//step attempts a step of h from (t,y), f being F(t,y). It returns the new state, the derivative there, the error scaled by the tolerances (accepted if <= 1) and the interpolant of the step
+	step(t float64, y, f []float64, h float64) ([]float64, []float64, float64, segment, error)
+	//order is the order of the error estimate, used to adapt the step
+	order() int
+}
+
+//integrate runs the step loop shared by all solvers. h is the step of fixed step solvers, and the initial step of adaptive ones, estimated if <= 0
+func integrate(sys *system, y []float64, t1 float64, set Settings, st stepper, adaptive bool, h float64) (*Solution, error) {
+	sol := sys.sol
+	t := sys.p.T0
+	f := make([]float64, sys.n)
+	sys.f(f, t, y)
+	sys.push(t, y, nil)
+	ev := newEvents(sys, t, y)
+	if adaptive && h <= 0 {
+		h = initialStep(y, f, set)
+	}
+	for t < t1 {
+		if sol.Steps+sol.Rejected >= set.MaxSteps {
+			return sol, fmt.Errorf("maximum number of steps reached at t=%g", t)
+		}
+		last := false
+		if t+h*(1+1e-8) >= t1 {
+			h, last = t1-t, true
+		}
+		y1, f1, e, seg, err := st.step(t, y, f, h)
+		if err != nil {
+			return sol, err
+		}
+		fac := 5.0
+		if e > 0 {
+			fac = math.Min(5, math.Max(0.2, 0.9*math.Pow(e, -1/float64(st.order()+1))))
+		}
+		if adaptive && !(e <= 1) {
+			sol.Rejected++
+			h *= math.Min(fac, 0.9)
+			if h <= 1e-14*math.Max(1, math.Abs(t)) {
+				return sol, fmt.Errorf("step size too small at t=%g", t)
+			}
+			continue
+		}
+		sol.Steps++
+		t1s := t + h
+		if last {
+			t1s = t1
+		}
+		if stop, err := ev.check(t, t1s, y1, seg); err != nil || stop {
+			return sol, err
+		}
+		sys.push(t1s, y1, seg)
+		t, y, f = t1s, y1, f1
+		if adaptive {
+			h = math.Min(h*fac, set.MaxStep)
+		}
+	}
+	return sol, nil
+}
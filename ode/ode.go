@@ -0,0 +1,210 @@
+//Package ode integrates initial value problems dy/dt=f(t,y), the state y being a M64 of any dims, with explicit Runge-Kutta methods and an implicit Rosenbrock method for stiff systems
+package ode
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Problem describes an initial value problem
+type Problem struct {
+	//F sets dy (dims of y) to the derivative of the state at (t,y). Required
+	F func(dy *mat.M64, t float64, y *mat.M64)
+	//Jac sets jac (n*n, n being the size of y) to the jacobian of F w.r.t. y, in row major order of y. Optional, only used by Rosenbrock which uses finite differences if nil
+	Jac func(jac *mat.M64, t float64, y *mat.M64)
+	//T0 is the initial time
+	T0 float64
+	//Y0 is the initial state
+	Y0 *mat.M64
+	//Events are the zero crossings to locate
+	Events []Event
+}
+
+//Event is a zero crossing of G(t,y) to locate during the integration
+type Event struct {
+	//G is the event function. Required
+	G func(t float64, y *mat.M64) float64
+	//Direction restricts the crossings detected: 1 for rising only, -1 for falling only, 0 for both
+	Direction int
+	//Terminal stops the integration at the first crossing
+	Terminal bool
+}
+
+//EventHit is a located crossing of an event
+type EventHit struct {
+	//Index is the index of the event in Problem.Events
+	Index int
+	//T is the time of the crossing
+	T float64
+	//Y is the state at T
+	Y *mat.M64
+}
+
+//Settings holds the parameters of the solvers. Zero values are replaced by defaults
+type Settings struct {
+	//Step is the step of RK4, (t1-t0)/100 by default, and the initial step of the adaptive solvers, estimated by default
+	Step float64
+	//MaxStep bounds the steps of the adaptive solvers, t1-t0 by default
+	MaxStep float64
+	//RelTol and AbsTol are the relative and absolute error tolerances per step of the adaptive solvers, 1e-6 and 1e-9 by default
+	RelTol float64
+	AbsTol float64
+	//MaxSteps is the maximum number of steps, 100000 by default
+	MaxSteps int
+}
+
+func settingsOrDefault(s *Settings, span float64) Settings {
+	var res Settings
+	if s != nil {
+		res = *s
+	}
+	if res.MaxStep <= 0 {
+		res.MaxStep = span
+	}
+	if res.RelTol <= 0 {
+		res.RelTol = 1e-6
+	}
+	if res.AbsTol <= 0 {
+		res.AbsTol = 1e-9
+	}
+	if res.MaxSteps <= 0 {
+		res.MaxSteps = 100000
+	}
+	return res
+}
+
+//Solution holds the trajectory computed by a solver
+type Solution struct {
+	//T holds the times of the steps, starting with T0
+	T []float64
+	//Y holds the states at T
+	Y []*mat.M64
+	//Events holds the crossings located, in time order
+	Events []EventHit
+	//Steps and Rejected count the accepted and rejected steps, FuncEvals the calls to F
+	Steps     int
+	Rejected  int
+	FuncEvals int
+
+	r, c     int
+	segments []segment
+}
+
+//At returns the state at time t, between the first and last times of the solution, interpolated inside the step containing t
+func (s *Solution) At(t float64) (*mat.M64, error) {
+	if len(s.T) == 0 || t < s.T[0] || t > s.T[len(s.T)-1] {
+		return nil, fmt.Errorf("t is out of the solution range")
+	}
+	i := sort.SearchFloat64s(s.T, t)
+	if s.T[i] == t {
+		return s.Y[i], nil
+	}
+	res := make([]float64, s.r*s.c)
+	s.segments[i-1].at(t, res)
+	return mat.NewM64(s.r, s.c, res), nil
+}
+
+//segment interpolates the state inside one step
+type segment interface {
+	at(t float64, dst []float64)
+}
+
+//hermite is the cubic Hermite interpolant of a step from the states and derivatives at both ends
+type hermite struct {
+	t0, h          float64
+	y0, f0, y1, f1 []float64
+}
+
+func (s *hermite) at(t float64, dst []float64) {
+	th := (t - s.t0) / s.h
+	h00 := (1 + 2*th) * (1 - th) * (1 - th)
+	h10 := th * (1 - th) * (1 - th)
+	h01 := th * th * (3 - 2*th)
+	h11 := th * th * (th - 1)
+	for i := range dst {
+		dst[i] = h00*s.y0[i] + h10*s.h*s.f0[i] + h01*s.y1[i] + h11*s.h*s.f1[i]
+	}
+}
+
+//system evaluates a problem on flat state vectors
+type system struct {
+	p    Problem
+	r, c int
+	n    int
+	sol  *Solution
+}
+
+func newSystem(p Problem, t1 float64) (*system, []float64, error) {
+	if p.F == nil {
+		return nil, nil, fmt.Errorf("problem has no function")
+	}
+	if !p.Y0.Valid() {
+		return nil, nil, fmt.Errorf("y0 is nil")
+	}
+	if !(t1 > p.T0) {
+		return nil, nil, fmt.Errorf("t1 must be greater than t0")
+	}
+	for _, e := range p.Events {
+		if e.G == nil {
+			return nil, nil, fmt.Errorf("event has no function")
+		}
+	}
+	r, c := p.Y0.Dims()
+	y := make([]float64, 0, r*c)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			y = append(y, p.Y0.At(i, j))
+		}
+	}
+	sys := &system{p: p, r: r, c: c, n: r * c, sol: &Solution{r: r, c: c}}
+	return sys, y, nil
+}
+
+//wrap returns a matrix of the dims of the state sharing the data of v
+func (sys *system) wrap(v []float64) *mat.M64 {
+	return mat.NewM64(sys.r, sys.c, v)
+}
+
+//f sets dy to F(t,y)
+func (sys *system) f(dy []float64, t float64, y []float64) {
+	sys.sol.FuncEvals++
+	sys.p.F(sys.wrap(dy), t, sys.wrap(y))
+}
+
+//push appends a point to the solution, seg interpolating from the previous point
+func (sys *system) push(t float64, y []float64, seg segment) {
+	sol := sys.sol
+	sol.T = append(sol.T, t)
+	sol.Y = append(sol.Y, sys.wrap(append([]float64(nil), y...)))
+	if seg != nil {
+		sol.segments = append(sol.segments, seg)
+	}
+}
+
+//errNorm returns the RMS norm of err scaled by the tolerance on each component
+func errNorm(err, y0, y1 []float64, set Settings) float64 {
+	sum := 0.0
+	for i, e := range err {
+		sc := set.AbsTol + set.RelTol*math.Max(math.Abs(y0[i]), math.Abs(y1[i]))
+		sum += (e / sc) * (e / sc)
+	}
+	return math.Sqrt(sum / float64(len(err)))
+}
+
+//initialStep returns a first step for an adaptive method, from the scales of the state and of its derivative
+func initialStep(y, f []float64, set Settings) float64 {
+	d0, d1 := 0.0, 0.0
+	for i := range y {
+		sc := set.AbsTol + set.RelTol*math.Abs(y[i])
+		d0 += (y[i] / sc) * (y[i] / sc)
+		d1 += (f[i] / sc) * (f[i] / sc)
+	}
+	h := 1e-6
+	if d0 > 1e-10 && d1 > 1e-10 {
+		h = 0.01 * math.Sqrt(d0/d1)
+	}
+	return math.Min(h, set.MaxStep)
+}
@@ -0,0 +1,53 @@
+package ode
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+//decay is y'=-y, y(t)=e^-t
+var decay = Problem{
+	F:  func(dy *mat.M64, t float64, y *mat.M64) { dy.Set(0, 0, -y.At(0, 0)) },
+	Y0: mat.NewM64(1, 1, []float64{1}),
+}
+
+//oscillator is x''=-x as a 2*1 state (x,x'), x(t)=cos(t)
+var oscillator = Problem{
+	F: func(dy *mat.M64, t float64, y *mat.M64) {
+		dy.Set(0, 0, y.At(1, 0))
+		dy.Set(1, 0, -y.At(0, 0))
+	},
+	Y0: mat.NewM64(2, 1, []float64{1, 0}),
+}
+
+func TestProblem(t *testing.T) {
+	te := tester.New(t)
+	_, err := RK4(Problem{Y0: decay.Y0}, 1, nil)
+	te.CompareError(0, fmt.Errorf("problem has no function"), err)
+	_, err = RK4(Problem{F: decay.F}, 1, nil)
+	te.CompareError(1, fmt.Errorf("y0 is nil"), err)
+	_, err = DormandPrince(decay, 0, nil)
+	te.CompareError(2, fmt.Errorf("t1 must be greater than t0"), err)
+	p := decay
+	p.Events = []Event{{}}
+	_, err = Rosenbrock(p, 1, nil)
+	te.CompareError(3, fmt.Errorf("event has no function"), err)
+
+	sol, err := RK4(decay, 1, &Settings{Step: 0.1})
+	te.CompareError(4, nil, err)
+	_, err = sol.At(1.5)
+	te.CompareError(4, fmt.Errorf("t is out of the solution range"), err)
+	y, err := sol.At(0.3)
+	te.CompareError(5, nil, err)
+	te.DeepEqual(5, "step point", sol.Y[3], y)
+	y, err = sol.At(0.35)
+	te.CompareError(6, nil, err)
+	te.DeepEqual(6, "hermite", true, math.Abs(y.At(0, 0)-math.Exp(-0.35)) < 1e-6)
+
+	_, err = DormandPrince(decay, 1, &Settings{MaxSteps: 3, Step: 1e-3})
+	te.CompareError(7, fmt.Errorf("maximum number of steps reached at t=%g", sol.T[0]+0.001+0.005+0.025), err)
+}
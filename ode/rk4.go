@@ -0,0 +1,44 @@
+package ode
+
+//rk4 is the classical fourth order Runge-Kutta method
+type rk4 struct {
+	sys             *system
+	k2, k3, k4, tmp []float64
+}
+
+func (m *rk4) order() int { return 4 }
+
+func (m *rk4) step(t float64, y, f []float64, h float64) ([]float64, []float64, float64, segment, error) {
+	stage := func(dst []float64, k []float64, c float64) {
+		for i := range y {
+			m.tmp[i] = y[i] + c*h*k[i]
+		}
+		m.sys.f(dst, t+c*h, m.tmp)
+	}
+	stage(m.k2, f, 0.5)
+	stage(m.k3, m.k2, 0.5)
+	stage(m.k4, m.k3, 1)
+	y1 := make([]float64, len(y))
+	for i := range y {
+		y1[i] = y[i] + h/6*(f[i]+2*m.k2[i]+2*m.k3[i]+m.k4[i])
+	}
+	f1 := make([]float64, len(y))
+	m.sys.f(f1, t+h, y1)
+	return y1, f1, 0, &hermite{t0: t, h: h, y0: y, f0: f, y1: y1, f1: f1}, nil
+}
+
+//RK4 integrates p from T0 to t1 with the classical fourth order Runge-Kutta method and a fixed step, Settings.Step or (t1-t0)/100. The last step is shortened to end at t1. The solution is interpolated between steps by cubic Hermite polynomials
+func RK4(p Problem, t1 float64, s *Settings) (*Solution, error) {
+	sys, y, err := newSystem(p, t1)
+	if err != nil {
+		return nil, err
+	}
+	set := settingsOrDefault(s, t1-p.T0)
+	h := set.Step
+	if h <= 0 {
+		h = (t1 - p.T0) / 100
+	}
+	n := sys.n
+	m := &rk4{sys: sys, k2: make([]float64, n), k3: make([]float64, n), k4: make([]float64, n), tmp: make([]float64, n)}
+	return integrate(sys, y, t1, set, m, false, h)
+}
@@ -0,0 +1,33 @@
+package ode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestRK4(t *testing.T) {
+	te := tester.New(t)
+	//fourth order: halving the step divides the error by about 16
+	errAt := func(h float64) float64 {
+		sol, err := RK4(decay, 1, &Settings{Step: h})
+		te.CompareError(0, nil, err)
+		return math.Abs(sol.Y[len(sol.Y)-1].At(0, 0) - math.Exp(-1))
+	}
+	ratio := errAt(0.1) / errAt(0.05)
+	te.DeepEqual(0, "order 4", true, ratio > 14 && ratio < 18)
+
+	sol, err := RK4(oscillator, 2*math.Pi, nil)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "steps", 100, sol.Steps)
+	te.DeepEqual(1, "evals", 401, sol.FuncEvals)
+	last := sol.Y[len(sol.Y)-1]
+	te.DeepEqual(1, "period", true, math.Abs(last.At(0, 0)-1) < 1e-6 && math.Abs(last.At(1, 0)) < 1e-6)
+	te.DeepEqual(1, "end time", 2*math.Pi, sol.T[len(sol.T)-1])
+
+	//the last step is shortened
+	sol, _ = RK4(decay, 1, &Settings{Step: 0.3})
+	te.DeepEqual(2, "times", 5, len(sol.T))
+	te.DeepEqual(2, "end", 1.0, sol.T[4])
+}
@@ -0,0 +1,138 @@
+package ode
+
+import (
+	"fmt"
+	"math"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//rosenbrock is the L-stable Rosenbrock 2(3) method of Shampine and Reichelt. Each step solves three linear systems with the matrix I-h*d*J, factorized once
+type rosenbrock struct {
+	sys *system
+	set Settings
+	jac *mat.M64
+	w   *mat.M64
+	tmp []float64
+	fp  []float64
+}
+
+const (
+	rosD   = 0.2928932188134524 //1/(2+√2)
+	rosE32 = 7.414213562373095  //6+√2
+)
+
+func (m *rosenbrock) order() int { return 2 }
+
+//jacobian sets m.jac to the jacobian of F at (t,y), f being F(t,y)
+func (m *rosenbrock) jacobian(t float64, y, f []float64) {
+	sys := m.sys
+	if sys.p.Jac != nil {
+		sys.p.Jac(m.jac, t, sys.wrap(y))
+		return
+	}
+	copy(m.tmp, y)
+	for j := range y {
+		h := math.Sqrt(2.2e-16) * math.Max(1, math.Abs(y[j]))
+		m.tmp[j] = y[j] + h
+		sys.f(m.fp, t, m.tmp)
+		m.tmp[j] = y[j]
+		for i := range y {
+			m.jac.Set(i, j, (m.fp[i]-f[i])/h)
+		}
+	}
+}
+
+//solve returns the solution x of w*x=b
+func solve(lu *mat.LU, b []float64) ([]float64, error) {
+	bm, err := mat.FromData(len(b), 1, b)
+	if err != nil {
+		return nil, err
+	}
+	x, err := lu.Solve(bm)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]float64, len(b))
+	for i := range res {
+		res[i] = x.At(i, 0)
+	}
+	return res, nil
+}
+
+func (m *rosenbrock) step(t float64, y, f []float64, h float64) ([]float64, []float64, float64, segment, error) {
+	sys := m.sys
+	n := len(y)
+	m.jacobian(t, y, f)
+	//time derivative of F by finite differences
+	dt := math.Sqrt(2.2e-16) * math.Max(1, math.Abs(t))
+	sys.f(m.fp, t+dt, y)
+	ft := make([]float64, n)
+	for i := range ft {
+		ft[i] = (m.fp[i] - f[i]) / dt
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			v := -h * rosD * m.jac.At(i, j)
+			if i == j {
+				v++
+			}
+			m.w.Set(i, j, v)
+		}
+	}
+	lu, err := mat.NewLU(m.w)
+	if err != nil {
+		return nil, nil, 0, nil, fmt.Errorf("rosenbrock at t=%g: %s", t, err.Error())
+	}
+	b := make([]float64, n)
+	for i := range b {
+		b[i] = f[i] + h*rosD*ft[i]
+	}
+	k1, err := solve(lu, b)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+	for i := range y {
+		m.tmp[i] = y[i] + h/2*k1[i]
+	}
+	f1 := make([]float64, n)
+	sys.f(f1, t+h/2, m.tmp)
+	for i := range b {
+		b[i] = f1[i] - k1[i]
+	}
+	k2, err := solve(lu, b)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+	y1 := make([]float64, n)
+	for i := range k2 {
+		k2[i] += k1[i]
+		y1[i] = y[i] + h*k2[i]
+	}
+	f2 := make([]float64, n)
+	sys.f(f2, t+h, y1)
+	for i := range b {
+		b[i] = f2[i] - rosE32*(k2[i]-f1[i]) - 2*(k1[i]-f[i]) + h*rosD*ft[i]
+	}
+	k3, err := solve(lu, b)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+	for i := range b {
+		b[i] = h / 6 * (k1[i] - 2*k2[i] + k3[i])
+	}
+	e := errNorm(b, y, y1, m.set)
+	return y1, f2, e, &hermite{t0: t, h: h, y0: y, f0: f, y1: y1, f1: f2}, nil
+}
+
+//Rosenbrock integrates the stiff problem p from T0 to t1 with the adaptive, L-stable, Rosenbrock 2(3) method (as ode23s), which solves linear systems with the jacobian of F through the LU decomposition of mat64 instead of iterating. p.Jac is used if set. The solution is interpolated between steps by cubic Hermite polynomials
+func Rosenbrock(p Problem, t1 float64, s *Settings) (*Solution, error) {
+	sys, y, err := newSystem(p, t1)
+	if err != nil {
+		return nil, err
+	}
+	set := settingsOrDefault(s, t1-p.T0)
+	n := sys.n
+	m := &rosenbrock{sys: sys, set: set, jac: mat.NewM64(n, n, nil), w: mat.NewM64(n, n, nil), tmp: make([]float64, n), fp: make([]float64, n)}
+	return integrate(sys, y, t1, set, m, true, set.Step)
+}
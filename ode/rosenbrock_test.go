@@ -0,0 +1,48 @@
+package ode
+
+import (
+	"math"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestRosenbrock(t *testing.T) {
+	te := tester.New(t)
+	//Robertson's chemical kinetics, a classical stiff problem
+	robertson := Problem{
+		F: func(dy *mat.M64, t float64, y *mat.M64) {
+			a, b, c := y.At(0, 0), y.At(1, 0), y.At(2, 0)
+			dy.Set(0, 0, -0.04*a+1e4*b*c)
+			dy.Set(1, 0, 0.04*a-1e4*b*c-3e7*b*b)
+			dy.Set(2, 0, 3e7*b*b)
+		},
+		Y0: mat.NewM64(3, 1, []float64{1, 0, 0}),
+	}
+	sol, err := Rosenbrock(robertson, 40, &Settings{RelTol: 1e-5, AbsTol: 1e-10})
+	te.CompareError(0, nil, err)
+	last := sol.Y[len(sol.Y)-1]
+	te.DeepEqual(0, "y1", true, math.Abs(last.At(0, 0)-0.7158271) < 1e-4)
+	te.DeepEqual(0, "y2", true, math.Abs(last.At(1, 0)-9.185535e-6) < 1e-8)
+	te.DeepEqual(0, "y3", true, math.Abs(last.At(2, 0)-0.2841637) < 1e-4)
+	te.DeepEqual(0, "few steps", true, sol.Steps < 1000)
+	//an explicit method is bound by stability, not accuracy
+	_, err = DormandPrince(robertson, 40, &Settings{RelTol: 1e-5, AbsTol: 1e-10, MaxSteps: 2000})
+	te.DeepEqual(0, "explicit struggles", true, err != nil)
+
+	//y'=-1000*(y-cos(t)) with an analytical jacobian, y follows cos(t) closely
+	p := Problem{
+		F: func(dy *mat.M64, t float64, y *mat.M64) { dy.Set(0, 0, -1000*(y.At(0, 0)-math.Cos(t))) },
+		Jac: func(jac *mat.M64, t float64, y *mat.M64) {
+			jac.Set(0, 0, -1000)
+		},
+		Y0: mat.NewM64(1, 1, []float64{0}),
+	}
+	sol, err = Rosenbrock(p, 3, &Settings{RelTol: 1e-4, AbsTol: 1e-7})
+	te.CompareError(1, nil, err)
+	//y(t)≈cos(t)+sin(t)/1000 after the transient
+	y, _ := sol.At(2)
+	te.DeepEqual(1, "tracks", true, math.Abs(y.At(0, 0)-math.Cos(2)-math.Sin(2)/1000) < 1e-4)
+	te.DeepEqual(1, "few steps", true, sol.Steps < 300)
+}
@@ -0,0 +1,92 @@
+package optimize
+
+import (
+	"math"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//direction computes the search directions of a gradient based method
+type direction interface {
+	//next sets d to the search direction at p
+	next(ev *evaluator, d []float64, p *point)
+	//update records the step s=x1-x0 and the gradient change y=g1-g0 of the last iteration
+	update(s, y []float64)
+	//reset forgets the history, when the last direction was not a descent direction
+	reset()
+	//unitStep is true if the line search should start from a step of 1
+	unitStep() bool
+}
+
+//descend runs the line search loop shared by all gradient based methods
+func descend(p Problem, x0 *mat.M64, s *Settings, dir func(n int) direction, def LineSearch, c2 float64) (*Result, error) {
+	set := settingsOrDefault(s)
+	ev, x, err := newEvaluator(p, x0)
+	if err != nil {
+		return nil, err
+	}
+	maxIter := set.MaxIter
+	if maxIter <= 0 {
+		maxIter = 1000
+	}
+	n := ev.n
+	cur, next := newPoint(n), newPoint(n)
+	copy(cur.x, x)
+	cur.f = ev.f(cur.x)
+	ev.grad(cur.g, cur.x)
+	ls := pickLineSearch(set, def, c2)
+	dirs := dir(n)
+	d, sv, y := make([]float64, n), make([]float64, n), make([]float64, n)
+	prevStep, prevSlope := 0.0, 0.0
+	status := IterationLimit
+	it := 0
+	for ; ; it++ {
+		if normInf(cur.g) <= set.GradTol {
+			status = GradientTolerance
+			break
+		}
+		if it >= maxIter {
+			break
+		}
+		dirs.next(ev, d, cur)
+		slope := dot(d, cur.g)
+		if !(slope < 0) {
+			dirs.reset()
+			dirs.next(ev, d, cur)
+			if slope = dot(d, cur.g); !(slope < 0) {
+				for i, v := range cur.g {
+					d[i] = -v
+				}
+				slope = dot(d, cur.g)
+			}
+		}
+		step := 1.0
+		if !dirs.unitStep() {
+			if prevStep == 0 {
+				step = math.Min(1, 1/normInf(d))
+			} else {
+				//same first order decrease as the last step
+				step = prevStep * prevSlope / slope
+			}
+		}
+		alpha, ok := ls.search(ev, cur, next, d, step)
+		if !ok {
+			status = LineSearchFailed
+			break
+		}
+		for i := range sv {
+			sv[i] = next.x[i] - cur.x[i]
+			y[i] = next.g[i] - cur.g[i]
+		}
+		dirs.update(sv, y)
+		decrease := cur.f - next.f
+		prevStep, prevSlope = alpha, slope
+		cur, next = next, cur
+		if set.FuncTol > 0 && decrease <= set.FuncTol*(1+math.Abs(cur.f)) {
+			it++
+			status = FunctionTolerance
+			break
+		}
+	}
+	return ev.finish(cur.x, cur.f, cur.g, it, status), nil
+}
@@ -0,0 +1,58 @@
+package optimize
+
+import (
+	"math"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+type steepest struct{}
+
+func (steepest) next(ev *evaluator, d []float64, p *point) {
+	for i, v := range p.g {
+		d[i] = -v
+	}
+}
+func (steepest) update(s, y []float64) {}
+func (steepest) reset()                {}
+func (steepest) unitStep() bool        { return false }
+
+//GradientDescent minimizes p from x0 along the steepest descent direction, with an Armijo line search by default
+func GradientDescent(p Problem, x0 *mat.M64, s *Settings) (*Result, error) {
+	return descend(p, x0, s, func(n int) direction { return steepest{} }, Armijo, 0.9)
+}
+
+//conjugate computes Polak-Ribière+ directions, restarted every n iterations
+type conjugate struct {
+	n     int
+	count int
+	has   bool
+	gPrev []float64
+	dPrev []float64
+}
+
+func (c *conjugate) next(ev *evaluator, d []float64, p *point) {
+	beta := 0.0
+	if c.has && c.count < c.n {
+		beta = math.Max(0, (dot(p.g, p.g)-dot(p.g, c.gPrev))/dot(c.gPrev, c.gPrev))
+		c.count++
+	} else {
+		c.count = 0
+	}
+	for i, v := range p.g {
+		d[i] = -v + beta*c.dPrev[i]
+	}
+	copy(c.dPrev, d)
+	copy(c.gPrev, p.g)
+	c.has = true
+}
+func (c *conjugate) update(s, y []float64) {}
+func (c *conjugate) reset()                { c.has = false }
+func (c *conjugate) unitStep() bool        { return false }
+
+//ConjugateGradient minimizes p from x0 with the nonlinear conjugate gradient method (Polak-Ribière+), with a Wolfe line search by default
+func ConjugateGradient(p Problem, x0 *mat.M64, s *Settings) (*Result, error) {
+	return descend(p, x0, s, func(n int) direction {
+		return &conjugate{n: n, gPrev: make([]float64, n), dPrev: make([]float64, n)}
+	}, Wolfe, 0.1)
+}
@@ -0,0 +1,41 @@
+package optimize
+
+import (
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestGradientMethods(t *testing.T) {
+	te := tester.New(t)
+	x0 := mat.NewM64(2, 1, []float64{-1.2, 1})
+	res, err := GradientDescent(rosenbrock, x0, &Settings{MaxIter: 50000, GradTol: 1e-6})
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "converged", true, res.Converged)
+	te.DeepEqual(0, "status", GradientTolerance, res.Status)
+	te.DeepEqual(0, "x", true, atOnes(res.X, 1e-4))
+
+	res, err = ConjugateGradient(rosenbrock, x0, nil)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "converged", true, res.Converged)
+	te.DeepEqual(1, "x", true, atOnes(res.X, 1e-6))
+	te.DeepEqual(1, "x0 untouched", mat.NewM64(2, 1, []float64{-1.2, 1}), x0)
+
+	//without the gradient
+	res, err = ConjugateGradient(Problem{Func: rosenbrock.Func}, x0, &Settings{GradTol: 1e-5})
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "converged", true, res.Converged)
+	te.DeepEqual(2, "x", true, atOnes(res.X, 1e-3))
+	te.DeepEqual(2, "no gradient calls", 0, res.GradEvals)
+
+	res, err = GradientDescent(rosenbrock, x0, &Settings{MaxIter: 3})
+	te.CompareError(3, nil, err)
+	te.DeepEqual(3, "limit", IterationLimit, res.Status)
+	te.DeepEqual(3, "iterations", 3, res.Iterations)
+	te.DeepEqual(3, "not converged", false, res.Converged)
+
+	res, err = GradientDescent(rosenbrock, x0, &Settings{FuncTol: 1e-3})
+	te.CompareError(4, nil, err)
+	te.DeepEqual(4, "function tolerance", FunctionTolerance, res.Status)
+}
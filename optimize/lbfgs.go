@@ -0,0 +1,80 @@
+package optimize
+
+import (
+	"math"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//lbfgs approximates the inverse hessian from the last m steps and gradient changes
+type lbfgs struct {
+	m     int
+	s, y  [][]float64
+	rho   []float64
+	alpha []float64
+}
+
+func (l *lbfgs) next(ev *evaluator, d []float64, p *point) {
+	for i, v := range p.g {
+		d[i] = -v
+	}
+	k := len(l.s)
+	if k == 0 {
+		//no curvature known yet, keep the first step small
+		if g := normInf(p.g); g > 1 {
+			for i := range d {
+				d[i] /= g
+			}
+		}
+		return
+	}
+	//two loop recursion
+	for i := k - 1; i >= 0; i-- {
+		l.alpha[i] = l.rho[i] * dot(l.s[i], d)
+		for j, v := range l.y[i] {
+			d[j] -= l.alpha[i] * v
+		}
+	}
+	gamma := dot(l.s[k-1], l.y[k-1]) / dot(l.y[k-1], l.y[k-1])
+	for j := range d {
+		d[j] *= gamma
+	}
+	for i := 0; i < k; i++ {
+		beta := l.rho[i] * dot(l.y[i], d)
+		for j, v := range l.s[i] {
+			d[j] += (l.alpha[i] - beta) * v
+		}
+	}
+}
+
+func (l *lbfgs) update(s, y []float64) {
+	sy := dot(s, y)
+	//skip pairs breaking the positive definiteness of the approximation
+	if !(sy > 1e-10*math.Sqrt(dot(s, s)*dot(y, y))) {
+		return
+	}
+	var sn, yn []float64
+	if len(l.s) == l.m {
+		sn, yn = l.s[0], l.y[0]
+		l.s, l.y, l.rho = l.s[1:], l.y[1:], l.rho[1:]
+	} else {
+		sn, yn = make([]float64, len(s)), make([]float64, len(y))
+	}
+	copy(sn, s)
+	copy(yn, y)
+	l.s, l.y, l.rho = append(l.s, sn), append(l.y, yn), append(l.rho, 1/sy)
+}
+
+func (l *lbfgs) reset() {
+	l.s, l.y, l.rho = l.s[:0], l.y[:0], l.rho[:0]
+}
+
+func (l *lbfgs) unitStep() bool { return true }
+
+//LBFGS minimizes p from x0 with the limited memory BFGS quasi-Newton method, keeping Settings.Memory corrections, with a Wolfe line search by default
+func LBFGS(p Problem, x0 *mat.M64, s *Settings) (*Result, error) {
+	m := settingsOrDefault(s).Memory
+	return descend(p, x0, s, func(n int) direction {
+		return &lbfgs{m: m, alpha: make([]float64, m)}
+	}, Wolfe, 0.9)
+}
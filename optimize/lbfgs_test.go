@@ -0,0 +1,41 @@
+package optimize
+
+import (
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestLBFGS(t *testing.T) {
+	te := tester.New(t)
+	x0 := mat.NewM64(10, 1, nil)
+	for i := 0; i < 10; i++ {
+		x0.Set(i, 0, -1.2)
+	}
+	res, err := LBFGS(rosenbrock, x0, nil)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "converged", true, res.Converged)
+	te.DeepEqual(0, "x", true, atOnes(res.X, 1e-6))
+	te.DeepEqual(0, "fast", true, res.Iterations < 200)
+
+	res, err = LBFGS(rosenbrock, x0, &Settings{Memory: 2, LineSearch: Armijo})
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "converged", true, res.Converged)
+	te.DeepEqual(1, "x", true, atOnes(res.X, 1e-6))
+
+	//the parameters keep the dims of x0
+	quad := Problem{Func: func(x *mat.M64) float64 {
+		sum := 0.0
+		for i := 0; i < 2; i++ {
+			for j := 0; j < 3; j++ {
+				d := x.At(i, j) - float64(i*3+j)
+				sum += d * d
+			}
+		}
+		return sum
+	}}
+	res, err = LBFGS(quad, mat.NewM64(2, 3, nil), nil)
+	te.CompareError(2, nil, err)
+	te.DeepEqual(2, "matrix parameters", true, mat.EqualApprox(mat.NewM64(2, 3, []float64{0, 1, 2, 3, 4, 5}), res.X, 1e-8))
+}
@@ -0,0 +1,121 @@
+package optimize
+
+import "math"
+
+//cgold is the golden section ratio (3-√5)/2
+const cgold = 0.3819660112501051
+
+//Golden returns a minimum of f in [a,b] by golden section search. f must be unimodal in [a,b]
+func Golden(f func(x float64) float64, a, b float64, s *Settings) (*ScalarResult, error) {
+	xtol, maxIter := scalarSettings(s)
+	if a > b {
+		a, b = b, a
+	}
+	c, d := a+cgold*(b-a), b-cgold*(b-a)
+	fc, fd := f(c), f(d)
+	res := &ScalarResult{}
+	for {
+		if fc < fd {
+			res.X, res.F = c, fc
+		} else {
+			res.X, res.F = d, fd
+		}
+		res.Tol = (b - a) / 2
+		if res.Tol <= xtol {
+			return res, nil
+		}
+		if res.Iterations >= maxIter {
+			return notConverged("golden section", "iteration limit", res)
+		}
+		res.Iterations++
+		if fc < fd {
+			b, d, fd = d, c, fc
+			c = a + cgold*(b-a)
+			fc = f(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = b - cgold*(b-a)
+			fd = f(d)
+		}
+	}
+}
+
+//BrentMin returns a minimum of f in [a,b] with Brent's method, mixing golden section and parabolic interpolation. The achievable tolerance is about √ε times |x|
+func BrentMin(f func(x float64) float64, a, b float64, s *Settings) (*ScalarResult, error) {
+	xtol, maxIter := scalarSettings(s)
+	if a > b {
+		a, b = b, a
+	}
+	x := a + cgold*(b-a)
+	w, v := x, x
+	fx := f(x)
+	fw, fv := fx, fx
+	d, e := 0.0, 0.0
+	res := &ScalarResult{}
+	for {
+		xm := (a + b) / 2
+		tol := math.Sqrt(eps)*math.Abs(x) + xtol/3
+		res.X, res.F, res.Tol = x, fx, (b-a)/2
+		if math.Abs(x-xm) <= 2*tol-(b-a)/2 {
+			return res, nil
+		}
+		if res.Iterations >= maxIter {
+			return notConverged("brent", "iteration limit", res)
+		}
+		res.Iterations++
+		golden := true
+		if math.Abs(e) > tol {
+			//parabola through x, w and v
+			r := (x - w) * (fx - fv)
+			q := (x - v) * (fx - fw)
+			p := (x-v)*q - (x-w)*r
+			q = 2 * (q - r)
+			if q > 0 {
+				p = -p
+			}
+			q = math.Abs(q)
+			if math.Abs(p) < math.Abs(q*e/2) && p > q*(a-x) && p < q*(b-x) {
+				e = d
+				d = p / q
+				golden = false
+				if u := x + d; u-a < 2*tol || b-u < 2*tol {
+					d = math.Copysign(tol, xm-x)
+				}
+			}
+		}
+		if golden {
+			if x >= xm {
+				e = a - x
+			} else {
+				e = b - x
+			}
+			d = cgold * e
+		}
+		u := x + d
+		if math.Abs(d) < tol {
+			u = x + math.Copysign(tol, d)
+		}
+		fu := f(u)
+		if fu <= fx {
+			if u >= x {
+				a = x
+			} else {
+				b = x
+			}
+			v, w, x = w, x, u
+			fv, fw, fx = fw, fx, fu
+			continue
+		}
+		if u < x {
+			a = u
+		} else {
+			b = u
+		}
+		if fu <= fw || w == x {
+			v, fv = w, fw
+			w, fw = u, fu
+		} else if fu <= fv || v == x || v == w {
+			v, fv = u, fu
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package optimize
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestLineMin(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		f    func(float64) float64
+		a, b float64
+		xmin float64
+	}{
+		{func(x float64) float64 { return (x - 1.5) * (x - 1.5) }, 0, 4, 1.5},
+		{math.Cos, 2, 5, math.Pi},
+		{func(x float64) float64 { return math.Abs(x - 0.3) }, -1, 1, 0.3},
+		{func(x float64) float64 { return x*x*x*x - 2*x }, 4, -1, math.Cbrt(0.5)},
+	}
+	for i, test := range tests {
+		for _, m := range []struct {
+			name string
+			min  func(f func(float64) float64, a, b float64, s *Settings) (*ScalarResult, error)
+		}{{"golden", Golden}, {"brent", BrentMin}} {
+			res, err := m.min(test.f, test.a, test.b, nil)
+			te.CompareError(i, nil, err)
+			te.DeepEqual(i, m.name, true, math.Abs(res.X-test.xmin) < 1e-7)
+			te.DeepEqual(i, m.name+" f", test.f(res.X), res.F)
+		}
+	}
+	res, err := BrentMin(math.Cos, 2, 5, &Settings{MaxIter: 3})
+	var ce *ConvergenceError
+	te.DeepEqual(10, "limit", true, errors.As(err, &ce) && res.Iterations == 3)
+	g, _ := Golden(math.Cos, 2, 5, nil)
+	b, _ := BrentMin(math.Cos, 2, 5, nil)
+	te.DeepEqual(11, "brent is faster", true, b.Iterations < g.Iterations)
+}
@@ -0,0 +1,117 @@
+package optimize
+
+import "math"
+
+const (
+	//armijoC1 is the sufficient decrease constant of both line searches
+	armijoC1 = 1e-4
+	//maxTrials bounds the number of function evaluations of a line search
+	maxTrials = 50
+)
+
+//point is a trial point of a line search, with its value and gradient
+type point struct {
+	x []float64
+	f float64
+	g []float64
+}
+
+//lineSearcher finds a step along d from cur. It returns false if no acceptable step was found. next holds the accepted point
+type lineSearcher interface {
+	search(ev *evaluator, cur, next *point, d []float64, step float64) (float64, bool)
+}
+
+func newPoint(n int) *point {
+	return &point{x: make([]float64, n), g: make([]float64, n)}
+}
+
+//move sets next.x to cur.x+step*d and evaluates the function there
+func move(ev *evaluator, cur, next *point, d []float64, step float64) {
+	for i, v := range cur.x {
+		next.x[i] = v + step*d[i]
+	}
+	next.f = ev.f(next.x)
+}
+
+//armijo backtracks from the initial step until f(x+a*d) <= f(x)+c1*a*g.d
+type armijo struct{}
+
+func (armijo) search(ev *evaluator, cur, next *point, d []float64, step float64) (float64, bool) {
+	slope := dot(cur.g, d)
+	for i := 0; i < maxTrials; i++ {
+		move(ev, cur, next, d, step)
+		if next.f <= cur.f+armijoC1*step*slope {
+			ev.grad(next.g, next.x)
+			return step, true
+		}
+		step /= 2
+	}
+	return 0, false
+}
+
+//wolfe brackets then zooms on a step satisfying the strong Wolfe conditions f(x+a*d) <= f(x)+c1*a*g.d and |g(x+a*d).d| <= c2*|g.d|
+type wolfe struct {
+	c2 float64
+}
+
+func (w wolfe) search(ev *evaluator, cur, next *point, d []float64, step float64) (float64, bool) {
+	slope := dot(cur.g, d)
+	prev, fPrev := 0.0, cur.f
+	for i := 0; i < maxTrials; i++ {
+		move(ev, cur, next, d, step)
+		if next.f > cur.f+armijoC1*step*slope || (i > 0 && next.f >= fPrev) {
+			return w.zoom(ev, cur, next, d, prev, step, fPrev, slope)
+		}
+		ev.grad(next.g, next.x)
+		s := dot(next.g, d)
+		if math.Abs(s) <= -w.c2*slope {
+			return step, true
+		}
+		if s >= 0 {
+			return w.zoom(ev, cur, next, d, step, prev, next.f, slope)
+		}
+		prev, fPrev = step, next.f
+		step *= 2
+	}
+	return 0, false
+}
+
+//zoom bisects [lo,hi], lo being the best step so far satisfying the sufficient decrease
+func (w wolfe) zoom(ev *evaluator, cur, next *point, d []float64, lo, hi, fLo, slope float64) (float64, bool) {
+	for i := 0; i < maxTrials; i++ {
+		step := (lo + hi) / 2
+		move(ev, cur, next, d, step)
+		if next.f > cur.f+armijoC1*step*slope || next.f >= fLo {
+			hi = step
+			continue
+		}
+		ev.grad(next.g, next.x)
+		s := dot(next.g, d)
+		if math.Abs(s) <= -w.c2*slope {
+			return step, true
+		}
+		if s*(hi-lo) >= 0 {
+			hi = lo
+		}
+		lo, fLo = step, next.f
+	}
+	//fall back on the best step with a sufficient decrease
+	if lo > 0 {
+		move(ev, cur, next, d, lo)
+		ev.grad(next.g, next.x)
+		return lo, true
+	}
+	return 0, false
+}
+
+//pickLineSearch returns the line search selected by the settings, or def. c2 is the curvature constant of the Wolfe search
+func pickLineSearch(s Settings, def LineSearch, c2 float64) lineSearcher {
+	ls := s.LineSearch
+	if ls == DefaultLineSearch {
+		ls = def
+	}
+	if ls == Wolfe {
+		return wolfe{c2: c2}
+	}
+	return armijo{}
+}
@@ -0,0 +1,53 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestLineSearch(t *testing.T) {
+	te := tester.New(t)
+	//f(x)=x² from 1 along -1: the minimum is at step 1
+	p := Problem{
+		Func: func(x *mat.M64) float64 { return x.At(0, 0) * x.At(0, 0) },
+		Grad: func(g, x *mat.M64) { g.Set(0, 0, 2*x.At(0, 0)) },
+	}
+	d := []float64{-1}
+	for i, test := range []struct {
+		ls   lineSearcher
+		step float64
+	}{
+		{armijo{}, 8},
+		{armijo{}, 1},
+		{wolfe{c2: 0.1}, 0.01},
+		{wolfe{c2: 0.1}, 8},
+		{wolfe{c2: 0.9}, 0.3},
+	} {
+		ev, x, _ := newEvaluator(p, mat.NewM64(1, 1, []float64{1}))
+		cur, next := newPoint(1), newPoint(1)
+		copy(cur.x, x)
+		cur.f = ev.f(cur.x)
+		ev.grad(cur.g, cur.x)
+		alpha, ok := test.ls.search(ev, cur, next, d, test.step)
+		te.DeepEqual(i, "found", true, ok)
+		slope := cur.g[0] * d[0]
+		te.DeepEqual(i, "sufficient decrease", true, next.f <= cur.f+armijoC1*alpha*slope)
+		te.DeepEqual(i, "point", 1-alpha, next.x[0])
+		te.DeepEqual(i, "gradient", 2*next.x[0], next.g[0])
+		if w, isWolfe := test.ls.(wolfe); isWolfe {
+			te.DeepEqual(i, "curvature", true, math.Abs(next.g[0]*d[0]) <= -w.c2*slope)
+		}
+	}
+
+	//uphill direction
+	ev, x, _ := newEvaluator(p, mat.NewM64(1, 1, []float64{1}))
+	cur, next := newPoint(1), newPoint(1)
+	copy(cur.x, x)
+	cur.f = ev.f(cur.x)
+	ev.grad(cur.g, cur.x)
+	_, ok := armijo{}.search(ev, cur, next, []float64{1}, 1)
+	te.DeepEqual(10, "uphill", false, ok)
+}
@@ -0,0 +1,116 @@
+package optimize
+
+import (
+	"math"
+	"sort"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//vertex is a point of the Nelder-Mead simplex
+type vertex struct {
+	x []float64
+	f float64
+}
+
+//NelderMead minimizes p from x0 without derivatives, by reflecting, expanding and contracting a simplex of n+1 points. The initial simplex moves each parameter by Settings.Step relatively, parameters at 0 move by 0.00025
+func NelderMead(p Problem, x0 *mat.M64, s *Settings) (*Result, error) {
+	set := settingsOrDefault(s)
+	ev, x, err := newEvaluator(p, x0)
+	if err != nil {
+		return nil, err
+	}
+	n := ev.n
+	maxIter := set.MaxIter
+	if maxIter <= 0 {
+		maxIter = 200 * n
+	}
+	funcTol := set.FuncTol
+	if funcTol <= 0 {
+		funcTol = 1e-10
+	}
+	simplex := make([]vertex, n+1)
+	for i := range simplex {
+		v := append([]float64(nil), x...)
+		if i > 0 {
+			if v[i-1] != 0 {
+				v[i-1] *= 1 + set.Step
+			} else {
+				v[i-1] = 0.00025
+			}
+		}
+		simplex[i] = vertex{x: v, f: ev.f(v)}
+	}
+	centroid := make([]float64, n)
+	trial := func(t float64) vertex {
+		//point at centroid+t*(centroid-worst)
+		v := make([]float64, n)
+		for j := range v {
+			v[j] = centroid[j] + t*(centroid[j]-simplex[n].x[j])
+		}
+		return vertex{x: v, f: ev.f(v)}
+	}
+	status := IterationLimit
+	it := 0
+	for ; ; it++ {
+		sort.SliceStable(simplex, func(i, j int) bool { return simplex[i].f < simplex[j].f })
+		best := simplex[0]
+		spread, size := 0.0, 0.0
+		for _, v := range simplex[1:] {
+			spread = math.Max(spread, math.Abs(v.f-best.f))
+			for j, xj := range v.x {
+				size = math.Max(size, math.Abs(xj-best.x[j]))
+			}
+		}
+		if spread <= funcTol*(1+math.Abs(best.f)) {
+			status = FunctionTolerance
+			break
+		}
+		if size <= set.XTol {
+			status = StepTolerance
+			break
+		}
+		if it >= maxIter {
+			break
+		}
+		for j := range centroid {
+			sum := 0.0
+			for _, v := range simplex[:n] {
+				sum += v.x[j]
+			}
+			centroid[j] = sum / float64(n)
+		}
+		r := trial(1)
+		switch {
+		case r.f < best.f:
+			if e := trial(2); e.f < r.f {
+				simplex[n] = e
+			} else {
+				simplex[n] = r
+			}
+		case r.f < simplex[n-1].f:
+			simplex[n] = r
+		default:
+			//contract outside if the reflection improved on the worst point, inside otherwise
+			t := -0.5
+			if r.f < simplex[n].f {
+				t = 0.5
+			}
+			c := trial(t)
+			if c.f < math.Min(r.f, simplex[n].f) {
+				simplex[n] = c
+				continue
+			}
+			//shrink towards the best point
+			for _, v := range simplex[1:] {
+				for j := range v.x {
+					v.x[j] = best.x[j] + 0.5*(v.x[j]-best.x[j])
+				}
+			}
+			for i := 1; i <= n; i++ {
+				simplex[i].f = ev.f(simplex[i].x)
+			}
+		}
+	}
+	return ev.finish(simplex[0].x, simplex[0].f, nil, it, status), nil
+}
@@ -0,0 +1,21 @@
+package optimize
+
+import (
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestNelderMead(t *testing.T) {
+	te := tester.New(t)
+	res, err := NelderMead(Problem{Func: rosenbrock.Func}, mat.NewM64(2, 1, []float64{-1.2, 1}), &Settings{FuncTol: 1e-14})
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "converged", true, res.Converged)
+	te.DeepEqual(0, "x", true, atOnes(res.X, 1e-4))
+	te.DeepEqual(0, "no gradient", true, res.Grad == nil && res.GradEvals == 0)
+
+	res, err = NelderMead(Problem{Func: rosenbrock.Func}, mat.NewM64(3, 1, nil), &Settings{MaxIter: 5})
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "limit", IterationLimit, res.Status)
+}
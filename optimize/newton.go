@@ -0,0 +1,42 @@
+package optimize
+
+import (
+	"fmt"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//newton solves hess*d=-g, falling back on the steepest descent when the hessian is singular
+type newton struct {
+	hess *mat.M64
+	g    *mat.M64
+}
+
+func (nt *newton) next(ev *evaluator, d []float64, p *point) {
+	ev.res.HessEvals++
+	ev.p.Hess(nt.hess, ev.wrap(p.x))
+	for i, v := range p.g {
+		nt.g.Set(i, 0, -v)
+		d[i] = -v
+	}
+	x, err := mat.Solve(nt.hess, nt.g)
+	if err != nil {
+		return
+	}
+	for i := range d {
+		d[i] = x.At(i, 0)
+	}
+}
+func (nt *newton) update(s, y []float64) {}
+func (nt *newton) reset()                {}
+func (nt *newton) unitStep() bool        { return true }
+
+//Newton minimizes p from x0 with Newton's method, using p.Hess, with an Armijo line search by default. Steps that are not descent directions (hessian not positive definite) are replaced by the steepest descent
+func Newton(p Problem, x0 *mat.M64, s *Settings) (*Result, error) {
+	if p.Hess == nil {
+		return nil, fmt.Errorf("problem has no hessian")
+	}
+	return descend(p, x0, s, func(n int) direction {
+		return &newton{hess: mat.NewM64(n, n, nil), g: mat.NewM64(n, 1, nil)}
+	}, Armijo, 0.9)
+}
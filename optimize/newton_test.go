@@ -0,0 +1,22 @@
+package optimize
+
+import (
+	"fmt"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestNewton(t *testing.T) {
+	te := tester.New(t)
+	res, err := Newton(rosenbrock, mat.NewM64(2, 1, []float64{-1.2, 1}), nil)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "converged", true, res.Converged)
+	te.DeepEqual(0, "x", true, atOnes(res.X, 1e-8))
+	te.DeepEqual(0, "fast", true, res.Iterations < 50)
+	te.DeepEqual(0, "hessian calls", true, res.HessEvals >= res.Iterations)
+
+	_, err = Newton(Problem{Func: rosenbrock.Func}, mat.NewM64(2, 1, nil), nil)
+	te.CompareError(1, fmt.Errorf("problem has no hessian"), err)
+}
@@ -0,0 +1,103 @@
+package optimize
+
+import (
+	"fmt"
+	"math"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//System describes a system of n equations F(x)=0 in n unknowns
+type System struct {
+	//Func sets fx (n*1) to F(x), x being n*1. Required
+	Func func(fx, x *mat.M64)
+	//Jac sets jac (n*n) to the jacobian of F at x. Optional, forward finite differences are used if nil
+	Jac func(jac, x *mat.M64)
+}
+
+//RootResult holds the outcome of a multivariate root finder
+type RootResult struct {
+	//X is the root found (n*1)
+	X *mat.M64
+	//F is the residual F(X) (n*1)
+	F *mat.M64
+	//Iterations is the number of iterations done
+	Iterations int
+	//Tol is the largest absolute component of the last step
+	Tol float64
+}
+
+//jacobian sets jac to the jacobian of sys at x, fx being F(x)
+func (sys System) jacobian(jac, x, fx *mat.M64) {
+	if sys.Jac != nil {
+		sys.Jac(jac, x)
+		return
+	}
+	n, _ := x.Dims()
+	probe := mat.NewM64(n, 1, nil)
+	fp := mat.NewM64(n, 1, nil)
+	for i := 0; i < n; i++ {
+		probe.Set(i, 0, x.At(i, 0))
+	}
+	for j := 0; j < n; j++ {
+		v := x.At(j, 0)
+		h := math.Sqrt(eps) * math.Max(1, math.Abs(v))
+		probe.Set(j, 0, v+h)
+		sys.Func(fp, probe)
+		probe.Set(j, 0, v)
+		for i := 0; i < n; i++ {
+			jac.Set(i, j, (fp.At(i, 0)-fx.At(i, 0))/h)
+		}
+	}
+}
+
+//NewtonRaphson returns a root of the system sys from x0 (n*1) with the Newton-Raphson method, solving J*dx=-F at each step with the LU decomposition of mat64
+func NewtonRaphson(sys System, x0 *mat.M64, s *Settings) (*RootResult, error) {
+	if sys.Func == nil {
+		return nil, fmt.Errorf("system has no function")
+	}
+	if !x0.Valid() {
+		return nil, fmt.Errorf("x0 is nil")
+	}
+	n, c := x0.Dims()
+	if c != 1 {
+		return nil, fmt.Errorf("x0 must be a colomn vector")
+	}
+	xtol, maxIter := scalarSettings(s)
+	res := &RootResult{X: mat.NewM64(n, 1, nil), F: mat.NewM64(n, 1, nil), Tol: math.Inf(1)}
+	for i := 0; i < n; i++ {
+		res.X.Set(i, 0, x0.At(i, 0))
+	}
+	jac := mat.NewM64(n, n, nil)
+	sys.Func(res.F, res.X)
+	for {
+		if res.Tol <= xtol || normInfM(res.F) == 0 {
+			return res, nil
+		}
+		if res.Iterations >= maxIter {
+			return res, &ConvergenceError{Method: "newton-raphson", Iterations: res.Iterations, Tol: res.Tol, Reason: "iteration limit"}
+		}
+		sys.jacobian(jac, res.X, res.F)
+		step, err := mat.Solve(jac, res.F)
+		if err != nil {
+			return res, &ConvergenceError{Method: "newton-raphson", Iterations: res.Iterations, Tol: res.Tol, Reason: err.Error()}
+		}
+		res.Iterations++
+		res.Tol = normInfM(step)
+		if err := res.X.Sub(step); err != nil {
+			return nil, err
+		}
+		sys.Func(res.F, res.X)
+	}
+}
+
+func normInfM(m *mat.M64) float64 {
+	max := 0.0
+	r, c := m.Dims()
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			max = math.Max(max, math.Abs(m.At(i, j)))
+		}
+	}
+	return max
+}
@@ -0,0 +1,61 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestNewtonRaphson(t *testing.T) {
+	te := tester.New(t)
+	//intersection of the circle x²+y²=4 and the curve y=e^x-1
+	sys := System{
+		Func: func(fx, x *mat.M64) {
+			a, b := x.At(0, 0), x.At(1, 0)
+			fx.Set(0, 0, a*a+b*b-4)
+			fx.Set(1, 0, math.Exp(a)-1-b)
+		},
+		Jac: func(j, x *mat.M64) {
+			a, b := x.At(0, 0), x.At(1, 0)
+			j.Set(0, 0, 2*a)
+			j.Set(0, 1, 2*b)
+			j.Set(1, 0, math.Exp(a))
+			j.Set(1, 1, -1)
+		},
+	}
+	x0 := mat.NewM64(2, 1, []float64{1, 1})
+	res, err := NewtonRaphson(sys, x0, &Settings{XTol: 1e-12})
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "residual", true, normInfM(res.F) < 1e-14)
+	te.DeepEqual(0, "iterations", true, res.Iterations < 10)
+	te.DeepEqual(0, "x0 untouched", mat.NewM64(2, 1, []float64{1, 1}), x0)
+
+	sys.Jac = nil
+	res2, err := NewtonRaphson(sys, x0, &Settings{XTol: 1e-12})
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "finite differences", true, mat.EqualApprox(res.X, res2.X, 1e-12))
+
+	_, err = NewtonRaphson(sys, x0, &Settings{MaxIter: 1})
+	var ce *ConvergenceError
+	te.DeepEqual(2, "limit", true, errors.As(err, &ce) && ce.Iterations == 1)
+
+	//singular jacobian at the start
+	sing := System{Func: func(fx, x *mat.M64) {
+		fx.Set(0, 0, x.At(0, 0)*x.At(0, 0)-1)
+		fx.Set(1, 0, x.At(1, 0)*x.At(1, 0)-1)
+	}, Jac: func(j, x *mat.M64) {
+		j.Set(0, 0, 2*x.At(0, 0))
+		j.Set(1, 1, 2*x.At(1, 0))
+	}}
+	_, err = NewtonRaphson(sing, mat.NewM64(2, 1, nil), nil)
+	te.DeepEqual(3, "singular", true, errors.As(err, &ce) && ce.Reason == "matrix is singular")
+
+	_, err = NewtonRaphson(System{}, x0, nil)
+	te.CompareError(4, fmt.Errorf("system has no function"), err)
+	_, err = NewtonRaphson(sys, mat.NewM64(1, 2, nil), nil)
+	te.CompareError(5, fmt.Errorf("x0 must be a colomn vector"), err)
+}
@@ -0,0 +1,208 @@
+//Package optimize minimizes functions of a matrix of parameters, with gradient based methods (gradient descent, conjugate gradient, L-BFGS, Newton) and the derivative free Nelder-Mead method
+package optimize
+
+import (
+	"fmt"
+	"math"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Problem describes the function to minimize
+type Problem struct {
+	//Func returns the value of the function at x. Required
+	Func func(x *mat.M64) float64
+	//Grad sets grad (same dims as x) to the gradient at x. Optional, central finite differences are used if nil
+	Grad func(grad, x *mat.M64)
+	//Hess sets hess (n*n, n being the number of parameters) to the hessian at x. Only required by Newton
+	Hess func(hess, x *mat.M64)
+}
+
+//LineSearch selects the step length rule of the gradient based methods
+type LineSearch int
+
+const (
+	//DefaultLineSearch lets each method use its usual rule: Armijo for gradient descent and Newton, Wolfe for conjugate gradient and L-BFGS
+	DefaultLineSearch LineSearch = iota
+	//Armijo backtracks until the sufficient decrease condition holds
+	Armijo
+	//Wolfe brackets a step satisfying the strong Wolfe conditions
+	Wolfe
+)
+
+//Settings holds the parameters of a minimization. Zero values are replaced by defaults
+type Settings struct {
+	//GradTol stops gradient based methods when the largest absolute gradient component is below it, 1e-8 by default
+	GradTol float64
+	//FuncTol stops when the decrease of the function is below FuncTol*(1+|f|). Disabled by default for gradient based methods, 1e-10 by default for Nelder-Mead where it applies to the spread of the simplex values
+	FuncTol float64
+	//XTol stops Nelder-Mead when the simplex is smaller than it along every axis, and the root finders and 1-D minimizers when the bracket or the step is smaller than it, 1e-8 by default
+	XTol float64
+	//MaxIter is the maximum number of iterations, 1000 by default (200 per parameter for Nelder-Mead, 100 for root finders and 1-D minimizers)
+	MaxIter int
+	//LineSearch is the step length rule of gradient based methods
+	LineSearch LineSearch
+	//Memory is the number of corrections kept by L-BFGS, 10 by default
+	Memory int
+	//Step is the relative size of the initial Nelder-Mead simplex, 0.05 by default
+	Step float64
+}
+
+//Status tells why a minimization stopped
+type Status int
+
+const (
+	//IterationLimit means MaxIter iterations were done without convergence
+	IterationLimit Status = iota
+	//GradientTolerance means the gradient is below GradTol
+	GradientTolerance
+	//FunctionTolerance means the decrease of the function (or the spread of the simplex) is below FuncTol
+	FunctionTolerance
+	//StepTolerance means the Nelder-Mead simplex is below XTol
+	StepTolerance
+	//LineSearchFailed means no step along the search direction decreased the function enough
+	LineSearchFailed
+)
+
+func (s Status) String() string {
+	switch s {
+	case IterationLimit:
+		return "iteration limit"
+	case GradientTolerance:
+		return "gradient tolerance"
+	case FunctionTolerance:
+		return "function tolerance"
+	case StepTolerance:
+		return "step tolerance"
+	case LineSearchFailed:
+		return "line search failed"
+	}
+	return fmt.Sprintf("status %d", int(s))
+}
+
+//Result holds the outcome of a minimization
+type Result struct {
+	//X is the best point found, with the dims of the initial point
+	X *mat.M64
+	//F is the value of the function at X
+	F float64
+	//Grad is the gradient at X, nil for Nelder-Mead
+	Grad *mat.M64
+	//Iterations is the number of iterations done
+	Iterations int
+	//FuncEvals, GradEvals and HessEvals count the calls to the function, the gradient and the hessian (finite differences count as function calls)
+	FuncEvals int
+	GradEvals int
+	HessEvals int
+	//Status tells why the minimization stopped
+	Status Status
+	//Converged is false if the minimization stopped on IterationLimit or LineSearchFailed
+	Converged bool
+}
+
+//evaluator counts the evaluations of a problem on flat parameter vectors of the dims of the initial point
+type evaluator struct {
+	p     Problem
+	r, c  int
+	n     int
+	res   *Result
+	probe []float64
+}
+
+func newEvaluator(p Problem, x0 *mat.M64) (*evaluator, []float64, error) {
+	if p.Func == nil {
+		return nil, nil, fmt.Errorf("problem has no function")
+	}
+	if !x0.Valid() {
+		return nil, nil, fmt.Errorf("x0 is nil")
+	}
+	r, c := x0.Dims()
+	x := make([]float64, 0, r*c)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			x = append(x, x0.At(i, j))
+		}
+	}
+	ev := &evaluator{p: p, r: r, c: c, n: r * c, res: &Result{}, probe: make([]float64, r*c)}
+	return ev, x, nil
+}
+
+//wrap returns a matrix sharing the data of the flat vector v
+func (ev *evaluator) wrap(v []float64) *mat.M64 {
+	return mat.NewM64(ev.r, ev.c, v)
+}
+
+func (ev *evaluator) f(x []float64) float64 {
+	ev.res.FuncEvals++
+	return ev.p.Func(ev.wrap(x))
+}
+
+//grad sets g to the gradient at x
+func (ev *evaluator) grad(g, x []float64) {
+	if ev.p.Grad != nil {
+		ev.res.GradEvals++
+		ev.p.Grad(ev.wrap(g), ev.wrap(x))
+		return
+	}
+	copy(ev.probe, x)
+	for i, v := range x {
+		h := math.Cbrt(2.2e-16) * math.Max(1, math.Abs(v))
+		ev.probe[i] = v + h
+		fp := ev.f(ev.probe)
+		ev.probe[i] = v - h
+		fm := ev.f(ev.probe)
+		ev.probe[i] = v
+		g[i] = (fp - fm) / (2 * h)
+	}
+}
+
+//finish fills the result with the final point
+func (ev *evaluator) finish(x []float64, f float64, g []float64, it int, status Status) *Result {
+	ev.res.X = ev.wrap(x)
+	ev.res.F = f
+	if g != nil {
+		ev.res.Grad = ev.wrap(g)
+	}
+	ev.res.Iterations = it
+	ev.res.Status = status
+	ev.res.Converged = status != IterationLimit && status != LineSearchFailed
+	return ev.res
+}
+
+func dot(x, y []float64) float64 {
+	sum := 0.0
+	for i, v := range x {
+		sum += v * y[i]
+	}
+	return sum
+}
+
+func normInf(x []float64) float64 {
+	max := 0.0
+	for _, v := range x {
+		if a := math.Abs(v); a > max {
+			max = a
+		}
+	}
+	return max
+}
+
+func settingsOrDefault(s *Settings) Settings {
+	var res Settings
+	if s != nil {
+		res = *s
+	}
+	if res.GradTol <= 0 {
+		res.GradTol = 1e-8
+	}
+	if res.XTol <= 0 {
+		res.XTol = 1e-8
+	}
+	if res.Memory <= 0 {
+		res.Memory = 10
+	}
+	if res.Step <= 0 {
+		res.Step = 0.05
+	}
+	return res
+}
@@ -0,0 +1,78 @@
+package optimize
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+//rosenbrock is the n dimensional Rosenbrock function, minimal at (1,...,1)
+var rosenbrock = Problem{
+	Func: func(x *mat.M64) float64 {
+		n := x.Size()
+		sum := 0.0
+		for i := 0; i < n-1; i++ {
+			a, b := x.At(i, 0), x.At(i+1, 0)
+			sum += 100*(b-a*a)*(b-a*a) + (1-a)*(1-a)
+		}
+		return sum
+	},
+	Grad: func(g, x *mat.M64) {
+		n := x.Size()
+		for i := 0; i < n; i++ {
+			g.Set(i, 0, 0)
+		}
+		for i := 0; i < n-1; i++ {
+			a, b := x.At(i, 0), x.At(i+1, 0)
+			g.Set(i, 0, g.At(i, 0)-400*a*(b-a*a)-2*(1-a))
+			g.Set(i+1, 0, g.At(i+1, 0)+200*(b-a*a))
+		}
+	},
+	Hess: func(h, x *mat.M64) {
+		n := x.Size()
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				h.Set(i, j, 0)
+			}
+		}
+		for i := 0; i < n-1; i++ {
+			a, b := x.At(i, 0), x.At(i+1, 0)
+			h.Set(i, i, h.At(i, i)+1200*a*a-400*b+2)
+			h.Set(i, i+1, -400*a)
+			h.Set(i+1, i, -400*a)
+			h.Set(i+1, i+1, h.At(i+1, i+1)+200)
+		}
+	},
+}
+
+func atOnes(x *mat.M64, tol float64) bool {
+	for i := 0; i < x.Size(); i++ {
+		if math.Abs(x.At(i, 0)-1) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEvaluator(t *testing.T) {
+	te := tester.New(t)
+	x := mat.NewM64(3, 1, []float64{-1.2, 1, 0.5})
+	p := Problem{Func: rosenbrock.Func}
+	ev, flat, err := newEvaluator(p, x)
+	te.CompareError(0, nil, err)
+	g := make([]float64, 3)
+	ev.grad(g, flat)
+	exp := mat.NewM64(3, 1, nil)
+	rosenbrock.Grad(exp, x)
+	te.DeepEqual(0, "finite differences", true, mat.EqualApprox(exp, mat.NewM64(3, 1, g), 1e-7))
+	te.DeepEqual(0, "evals", []int{6, 0}, []int{ev.res.FuncEvals, ev.res.GradEvals})
+
+	_, _, err = newEvaluator(Problem{}, x)
+	te.CompareError(1, fmt.Errorf("problem has no function"), err)
+	_, _, err = newEvaluator(p, nil)
+	te.CompareError(2, fmt.Errorf("x0 is nil"), err)
+	te.DeepEqual(3, "status", "line search failed", LineSearchFailed.String())
+}
@@ -0,0 +1,199 @@
+package optimize
+
+import "math"
+
+const eps = 2.220446049250313e-16
+
+//Bisection returns a root of f in [a,b] by halving the bracket. f(a) and f(b) must have opposite signs
+func Bisection(f func(x float64) float64, a, b float64, s *Settings) (*ScalarResult, error) {
+	xtol, maxIter := scalarSettings(s)
+	fa, fb := f(a), f(b)
+	if err := checkBracket(fa, fb); err != nil {
+		return nil, err
+	}
+	res := &ScalarResult{X: a, F: fa, Tol: math.Abs(b-a) / 2}
+	if fb == 0 {
+		res.X, res.F = b, fb
+	}
+	if fa == 0 || fb == 0 {
+		return res, nil
+	}
+	for res.Iterations < maxIter {
+		res.Iterations++
+		m := a + (b-a)/2
+		fm := f(m)
+		res.X, res.F, res.Tol = m, fm, math.Abs(b-a)/2
+		if fm == 0 || res.Tol <= xtol {
+			return res, nil
+		}
+		if (fm > 0) == (fa > 0) {
+			a, fa = m, fm
+		} else {
+			b = m
+		}
+	}
+	return notConverged("bisection", "iteration limit", res)
+}
+
+//Brent returns a root of f in [a,b] with Brent's method, mixing bisection, secant and inverse quadratic interpolation. f(a) and f(b) must have opposite signs
+func Brent(f func(x float64) float64, a, b float64, s *Settings) (*ScalarResult, error) {
+	xtol, maxIter := scalarSettings(s)
+	fa, fb := f(a), f(b)
+	if err := checkBracket(fa, fb); err != nil {
+		return nil, err
+	}
+	c, fc := b, fb
+	d, e := b-a, b-a
+	res := &ScalarResult{}
+	for {
+		//b is the best estimate, c the other end of the bracket, a the previous b
+		if (fb > 0) == (fc > 0) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol := 2*eps*math.Abs(b) + xtol/2
+		xm := (c - b) / 2
+		res.X, res.F, res.Tol = b, fb, math.Abs(xm)
+		if math.Abs(xm) <= tol || fb == 0 {
+			return res, nil
+		}
+		if res.Iterations >= maxIter {
+			return notConverged("brent", "iteration limit", res)
+		}
+		res.Iterations++
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				//secant
+				p = 2 * xm * s
+				q = 1 - s
+			} else {
+				//inverse quadratic interpolation
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*xm*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			}
+			p = math.Abs(p)
+			if 2*p < math.Min(3*xm*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = xm
+				e = d
+			}
+		} else {
+			d = xm
+			e = d
+		}
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else {
+			b += math.Copysign(tol, xm)
+		}
+		fb = f(b)
+	}
+}
+
+//NewtonRoot returns a root of f from x0 with Newton's method, df being the derivative of f
+func NewtonRoot(f, df func(x float64) float64, x0 float64, s *Settings) (*ScalarResult, error) {
+	xtol, maxIter := scalarSettings(s)
+	res := &ScalarResult{X: x0, F: f(x0), Tol: math.Inf(1)}
+	for res.F != 0 {
+		if res.Iterations >= maxIter {
+			return notConverged("newton", "iteration limit", res)
+		}
+		d := df(res.X)
+		if d == 0 {
+			return notConverged("newton", "zero derivative", res)
+		}
+		res.Iterations++
+		step := res.F / d
+		res.X -= step
+		res.F = f(res.X)
+		res.Tol = math.Abs(step)
+		if res.Tol <= xtol {
+			break
+		}
+	}
+	if res.F == 0 {
+		res.Tol = 0
+	}
+	return res, nil
+}
+
+//Secant returns a root of f with the secant method, from the two starting points x0 and x1
+func Secant(f func(x float64) float64, x0, x1 float64, s *Settings) (*ScalarResult, error) {
+	xtol, maxIter := scalarSettings(s)
+	f0 := f(x0)
+	res := &ScalarResult{X: x1, F: f(x1), Tol: math.Abs(x1 - x0)}
+	for res.F != 0 && res.Tol > xtol {
+		if res.Iterations >= maxIter {
+			return notConverged("secant", "iteration limit", res)
+		}
+		if res.F == f0 {
+			return notConverged("secant", "flat secant", res)
+		}
+		res.Iterations++
+		x := res.X - res.F*(res.X-x0)/(res.F-f0)
+		x0, f0 = res.X, res.F
+		res.X, res.F = x, f(x)
+		res.Tol = math.Abs(res.X - x0)
+	}
+	if res.F == 0 {
+		res.Tol = 0
+	}
+	return res, nil
+}
+
+//Illinois returns a root of f in [a,b] with the Illinois variant of the false position method, which halves the value kept at an end retained twice in a row. f(a) and f(b) must have opposite signs
+func Illinois(f func(x float64) float64, a, b float64, s *Settings) (*ScalarResult, error) {
+	xtol, maxIter := scalarSettings(s)
+	fa, fb := f(a), f(b)
+	if err := checkBracket(fa, fb); err != nil {
+		return nil, err
+	}
+	res := &ScalarResult{X: a, F: fa, Tol: math.Abs(b-a) / 2}
+	if fb == 0 {
+		res.X, res.F = b, fb
+	}
+	side := 0
+	for res.F != 0 {
+		if res.Tol <= xtol {
+			return res, nil
+		}
+		if res.Iterations >= maxIter {
+			return notConverged("illinois", "iteration limit", res)
+		}
+		res.Iterations++
+		c := (a*fb - b*fa) / (fb - fa)
+		fc := f(c)
+		switch {
+		case (fc > 0) == (fb > 0):
+			b, fb = c, fc
+			if side == -1 {
+				fa /= 2
+			}
+			side = -1
+		default:
+			a, fa = c, fc
+			if side == 1 {
+				fb /= 2
+			}
+			side = 1
+		}
+		res.X, res.F, res.Tol = c, fc, math.Abs(b-a)/2
+	}
+	res.Tol = 0
+	return res, nil
+}
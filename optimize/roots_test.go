@@ -0,0 +1,62 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestRoots(t *testing.T) {
+	te := tester.New(t)
+	cube := func(x float64) float64 { return x*x*x - 2*x - 5 }
+	dcube := func(x float64) float64 { return 3*x*x - 2 }
+	const root = 2.0945514815423265
+	type solver func(f func(float64) float64, a, b float64, s *Settings) (*ScalarResult, error)
+	newton := func(f func(float64) float64, a, b float64, s *Settings) (*ScalarResult, error) {
+		return NewtonRoot(f, dcube, b, s)
+	}
+	tests := []struct {
+		name     string
+		solve    solver
+		maxIters int
+	}{
+		{"bisection", Bisection, 40},
+		{"brent", Brent, 12},
+		{"newton", newton, 10},
+		{"secant", Secant, 12},
+		{"illinois", Illinois, 20},
+	}
+	for i, test := range tests {
+		res, err := test.solve(cube, 2, 3, &Settings{XTol: 1e-12})
+		te.CompareError(i, nil, err)
+		te.DeepEqual(i, test.name, true, math.Abs(res.X-root) < 1e-11)
+		te.DeepEqual(i, test.name+" tol", true, res.Tol <= 1e-12)
+		te.DeepEqual(i, test.name+" f", cube(res.X), res.F)
+		te.DeepEqual(i, test.name+" iterations", true, res.Iterations <= test.maxIters)
+
+		//iteration limit
+		res, err = test.solve(cube, 2, 3, &Settings{XTol: 1e-12, MaxIter: 2})
+		var ce *ConvergenceError
+		te.DeepEqual(i, test.name+" limit", true, errors.As(err, &ce) && ce.Iterations == 2 && res.Iterations == 2)
+	}
+
+	for i, solve := range []solver{Bisection, Brent, Illinois} {
+		_, err := solve(cube, 3, 4, nil)
+		te.CompareError(10+i, fmt.Errorf("f(a) and f(b) must have opposite signs"), err)
+		res, err := solve(cube, root-1, 3, nil)
+		te.CompareError(10+i, nil, err)
+		te.DeepEqual(10+i, "default tol", true, math.Abs(res.X-root) < 1e-8)
+	}
+
+	_, err := NewtonRoot(func(x float64) float64 { return x*x + 1 }, func(x float64) float64 { return 2 * x }, 0, nil)
+	var ce *ConvergenceError
+	te.DeepEqual(20, "zero derivative", true, errors.As(err, &ce) && ce.Reason == "zero derivative")
+	_, err = Secant(func(x float64) float64 { return 1 }, 0, 1, nil)
+	te.DeepEqual(21, "flat", true, errors.As(err, &ce) && ce.Reason == "flat secant")
+	res, err := Bisection(func(x float64) float64 { return x - 1 }, 1, 3, nil)
+	te.CompareError(22, nil, err)
+	te.DeepEqual(22, "root at bound", 1.0, res.X)
+}
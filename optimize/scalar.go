@@ -0,0 +1,53 @@
+package optimize
+
+import "fmt"
+
+//ScalarResult holds the outcome of a root finder or of a 1-D minimizer
+type ScalarResult struct {
+	//X is the root or the minimum found
+	X float64
+	//F is the value of the function at X
+	F float64
+	//Iterations is the number of iterations done
+	Iterations int
+	//Tol is the achieved tolerance on X: the half width of the last bracket, or the size of the last step
+	Tol float64
+}
+
+//ConvergenceError is returned with the last estimate when a solver reaches its iteration limit or cannot go on
+type ConvergenceError struct {
+	//Method is the name of the solver
+	Method string
+	//Iterations is the number of iterations done
+	Iterations int
+	//Tol is the tolerance achieved when the solver stopped
+	Tol float64
+	//Reason tells why the solver stopped
+	Reason string
+}
+
+func (e *ConvergenceError) Error() string {
+	return fmt.Sprintf("%s did not converge after %d iterations (tolerance %g): %s", e.Method, e.Iterations, e.Tol, e.Reason)
+}
+
+//scalarSettings returns the tolerance and the iteration limit of the scalar solvers
+func scalarSettings(s *Settings) (float64, int) {
+	set := settingsOrDefault(s)
+	if set.MaxIter <= 0 {
+		set.MaxIter = 100
+	}
+	return set.XTol, set.MaxIter
+}
+
+//notConverged returns res with the matching convergence error
+func notConverged(method, reason string, res *ScalarResult) (*ScalarResult, error) {
+	return res, &ConvergenceError{Method: method, Iterations: res.Iterations, Tol: res.Tol, Reason: reason}
+}
+
+//checkBracket returns an error if f(a) and f(b) have the same sign
+func checkBracket(fa, fb float64) error {
+	if fa*fb > 0 || fa != fa || fb != fb {
+		return fmt.Errorf("f(a) and f(b) must have opposite signs")
+	}
+	return nil
+}
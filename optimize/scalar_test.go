@@ -0,0 +1,18 @@
+package optimize
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestConvergenceError(t *testing.T) {
+	te := tester.New(t)
+	res, err := notConverged("secant", "iteration limit", &ScalarResult{X: 1, Iterations: 3, Tol: 0.5})
+	var ce *ConvergenceError
+	te.DeepEqual(0, "typed", true, errors.As(err, &ce))
+	te.DeepEqual(0, "fields", ConvergenceError{Method: "secant", Iterations: 3, Tol: 0.5, Reason: "iteration limit"}, *ce)
+	te.DeepEqual(0, "message", "secant did not converge after 3 iterations (tolerance 0.5): iteration limit", err.Error())
+	te.DeepEqual(0, "estimate kept", 1.0, res.X)
+}
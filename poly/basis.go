@@ -0,0 +1,78 @@
+package poly
+
+//Basis is a family of polynomials P_0,P_1,... of increasing degree, built by a three terms recurrence
+type Basis int
+
+const (
+	//Monomial is the basis 1,x,x²,...
+	Monomial Basis = iota
+	//Chebyshev is the basis of the Chebyshev polynomials of the first kind, orthogonal on [-1,1] for the weight 1/sqrt(1-x²)
+	Chebyshev
+	//Legendre is the basis of the Legendre polynomials, orthogonal on [-1,1]
+	Legendre
+	//Hermite is the basis of the (physicists') Hermite polynomials, orthogonal on the real line for the weight exp(-x²)
+	Hermite
+)
+
+//String returns the name of the basis
+func (b Basis) String() string {
+	switch b {
+	case Monomial:
+		return "monomial"
+	case Chebyshev:
+		return "chebyshev"
+	case Legendre:
+		return "legendre"
+	case Hermite:
+		return "hermite"
+	}
+	return "unknown"
+}
+
+//recurrence returns the coefficients a,c of the recurrence P_{k+1} = a*x*P_k - c*P_{k-1}. panics if b is unknown
+func (b Basis) recurrence(k int) (float64, float64) {
+	switch b {
+	case Monomial:
+		return 1, 0
+	case Chebyshev:
+		if k == 0 {
+			return 1, 0
+		}
+		return 2, 1
+	case Legendre:
+		return float64(2*k+1) / float64(k+1), float64(k) / float64(k+1)
+	case Hermite:
+		return 2, float64(2 * k)
+	}
+	panic("unknown basis")
+}
+
+//Values returns P_0(x),...,P_n(x). panics if b is unknown
+func (b Basis) Values(n int, x float64) []float64 {
+	if n < 0 {
+		return nil
+	}
+	res := make([]float64, n+1)
+	res[0] = 1
+	prev := 0.0
+	for k := 0; k < n; k++ {
+		a, c := b.recurrence(k)
+		res[k+1] = a*x*res[k] - c*prev
+		prev = res[k]
+	}
+	return res
+}
+
+//Polynomial returns P_n in the monomial basis. panics if b is unknown
+func (b Basis) Polynomial(n int) Polynomial {
+	if n < 0 {
+		return nil
+	}
+	var prev Polynomial
+	cur := Polynomial{1}
+	for k := 0; k < n; k++ {
+		a, c := b.recurrence(k)
+		prev, cur = cur, Polynomial{0, a}.Mul(cur).Sub(prev.Scale(c))
+	}
+	return cur
+}
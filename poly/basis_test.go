@@ -0,0 +1,55 @@
+package poly
+
+import (
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestBasisPolynomial(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		b   Basis
+		n   int
+		exp Polynomial
+	}{
+		{Monomial, 0, Polynomial{1}},
+		{Monomial, 3, Polynomial{0, 0, 0, 1}},
+		{Chebyshev, 1, Polynomial{0, 1}},
+		{Chebyshev, 2, Polynomial{-1, 0, 2}},
+		{Chebyshev, 4, Polynomial{1, 0, -8, 0, 8}},
+		{Legendre, 2, Polynomial{-0.5, 0, 1.5}},
+		{Legendre, 3, Polynomial{0, -1.5, 0, 2.5}},
+		{Hermite, 1, Polynomial{0, 2}},
+		{Hermite, 3, Polynomial{0, -12, 0, 8}},
+		{Hermite, 4, Polynomial{12, 0, -48, 0, 16}},
+		{Legendre, -1, nil},
+	}
+	for ind, test := range tests {
+		p := test.b.Polynomial(test.n)
+		if !near(test.exp, p, 1e-14) {
+			te.DeepEqual(ind, test.b.String(), test.exp, p)
+		}
+	}
+}
+
+func TestBasisValues(t *testing.T) {
+	te := tester.New(t)
+	for ind, b := range []Basis{Monomial, Chebyshev, Legendre, Hermite} {
+		for _, x := range []float64{-1, -0.3, 0, 0.7, 1.5} {
+			vals := b.Values(5, x)
+			exp := make([]float64, 6)
+			for k := range exp {
+				exp[k] = b.Polynomial(k).Eval(x)
+			}
+			if !near(exp, vals, 1e-12) {
+				te.DeepEqual(ind, b.String(), exp, vals)
+			}
+		}
+	}
+	//T_n(cos θ) = cos(nθ), P_n(1) = 1
+	te.DeepEqual(0, "chebyshev", true, near([]float64{1, 0.5, -0.5, -1}, Chebyshev.Values(3, 0.5), 1e-15))
+	te.DeepEqual(0, "legendre", []float64{1, 1, 1, 1, 1}, Legendre.Values(4, 1))
+	te.DeepEqual(0, "negative", []float64(nil), Hermite.Values(-1, 1))
+	te.DeepEqual(0, "name", "unknown", Basis(9).String())
+}
@@ -0,0 +1,30 @@
+package poly
+
+import (
+	"fmt"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Expand returns the polynomial features of x, a new r*(c*deg) matrix: colomn j of x is replaced by P_1(x_j),...,P_deg(x_j) in the basis b. P_0 is left out as it duplicates a bias. Chebyshev and Legendre features are best used with values scaled to [-1,1]
+func Expand(x *mat.M64, b Basis, deg int) (*mat.M64, error) {
+	if !x.Valid() {
+		return nil, fmt.Errorf("x is nil")
+	}
+	if b.String() == "unknown" {
+		return nil, fmt.Errorf("unknown basis")
+	}
+	if deg < 1 {
+		return nil, fmt.Errorf("deg must be positive")
+	}
+	r, c := x.Dims()
+	res := mat.NewM64(r, c*deg, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			for k, v := range b.Values(deg, x.At(i, j))[1:] {
+				res.Set(i, j*deg+k, v)
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,44 @@
+package poly
+
+import (
+	"fmt"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestExpand(t *testing.T) {
+	te := tester.New(t)
+	x := mat.NewM64(2, 2, []float64{
+		2, -1,
+		0.5, 3,
+	})
+	tests := []struct {
+		x   *mat.M64
+		b   Basis
+		deg int
+		exp *mat.M64
+		err error
+	}{
+		{x, Monomial, 3, mat.NewM64(2, 6, []float64{
+			2, 4, 8, -1, 1, -1,
+			0.5, 0.25, 0.125, 3, 9, 27,
+		}), nil},
+		{x, Chebyshev, 2, mat.NewM64(2, 4, []float64{
+			2, 7, -1, 1,
+			0.5, -0.5, 3, 17,
+		}), nil},
+		{x, Monomial, 1, x, nil},
+		{nil, Monomial, 2, nil, fmt.Errorf("x is nil")},
+		{x, Basis(9), 2, nil, fmt.Errorf("unknown basis")},
+		{x, Hermite, 0, nil, fmt.Errorf("deg must be positive")},
+	}
+	for ind, test := range tests {
+		got, err := Expand(test.x, test.b, test.deg)
+		te.CompareError(ind, test.err, err)
+		if err == nil {
+			te.DeepEqual(ind, "features", test.exp, got)
+		}
+	}
+}
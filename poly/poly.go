@@ -0,0 +1,151 @@
+//Package poly provides polynomials of one variable with their arithmetic, evaluation, derivatives and roots, orthogonal bases (Chebyshev, Legendre, Hermite) for approximation, and polynomial feature expansion of M64
+package poly
+
+import (
+	"fmt"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Polynomial holds the coefficients of a polynomial, constant first: p[0]+p[1]*x+...+p[n]*x^n. Methods never modify their receiver and return trimmed polynomials (no trailing zeros). The zero polynomial is empty
+type Polynomial []float64
+
+//New returns a new polynomial with the coefficients coef (constant first), trimmed
+func New(coef ...float64) Polynomial {
+	return Polynomial(append([]float64(nil), coef...)).trim()
+}
+
+//trim removes the trailing zero coefficients of p in place
+func (p Polynomial) trim() Polynomial {
+	n := len(p)
+	for n > 0 && p[n-1] == 0 {
+		n--
+	}
+	if n == 0 {
+		return nil
+	}
+	return p[:n]
+}
+
+//Degree returns the degree of p, -1 for the zero polynomial
+func (p Polynomial) Degree() int {
+	return len(p.trim()) - 1
+}
+
+//Eval returns p(x), by Horner's scheme
+func (p Polynomial) Eval(x float64) float64 {
+	res := 0.0
+	for i := len(p) - 1; i >= 0; i-- {
+		res = res*x + p[i]
+	}
+	return res
+}
+
+//EvalM64 returns a new matrix with p applied to each value of m
+func (p Polynomial) EvalM64(m *mat.M64) (*mat.M64, error) {
+	if !m.Valid() {
+		return nil, fmt.Errorf("m is nil")
+	}
+	r, c := m.Dims()
+	res := mat.NewM64(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			res.Set(i, j, p.Eval(m.At(i, j)))
+		}
+	}
+	return res, nil
+}
+
+//Add returns p+q
+func (p Polynomial) Add(q Polynomial) Polynomial {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	res := append(Polynomial(nil), p...)
+	for i, v := range q {
+		res[i] += v
+	}
+	return res.trim()
+}
+
+//Sub returns p-q
+func (p Polynomial) Sub(q Polynomial) Polynomial {
+	return p.Add(q.Scale(-1))
+}
+
+//Scale returns k*p
+func (p Polynomial) Scale(k float64) Polynomial {
+	res := make(Polynomial, len(p))
+	for i, v := range p {
+		res[i] = k * v
+	}
+	return res.trim()
+}
+
+//Mul returns p*q
+func (p Polynomial) Mul(q Polynomial) Polynomial {
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+	res := make(Polynomial, len(p)+len(q)-1)
+	for i, a := range p {
+		for j, b := range q {
+			res[i+j] += a * b
+		}
+	}
+	return res.trim()
+}
+
+//DivMod returns the quotient and the remainder of the long division of p by d: p = q*d+r with deg(r) < deg(d)
+func (p Polynomial) DivMod(d Polynomial) (Polynomial, Polynomial, error) {
+	d = d.trim()
+	if len(d) == 0 {
+		return nil, nil, fmt.Errorf("division by zero polynomial")
+	}
+	r := append(Polynomial(nil), p.trim()...)
+	if len(r) < len(d) {
+		return nil, r.trim(), nil
+	}
+	q := make(Polynomial, len(r)-len(d)+1)
+	lead := d[len(d)-1]
+	for k := len(q) - 1; k >= 0; k-- {
+		c := r[k+len(d)-1] / lead
+		q[k] = c
+		for j, v := range d {
+			r[k+j] -= c * v
+		}
+		r[k+len(d)-1] = 0
+	}
+	return q.trim(), r[:len(d)-1].trim(), nil
+}
+
+//Compose returns p(q(x))
+func (p Polynomial) Compose(q Polynomial) Polynomial {
+	var res Polynomial
+	for i := len(p) - 1; i >= 0; i-- {
+		res = res.Mul(q).Add(Polynomial{p[i]})
+	}
+	return res
+}
+
+//Deriv returns the derivative of p
+func (p Polynomial) Deriv() Polynomial {
+	if len(p) < 2 {
+		return nil
+	}
+	res := make(Polynomial, len(p)-1)
+	for i := range res {
+		res[i] = float64(i+1) * p[i+1]
+	}
+	return res.trim()
+}
+
+//Integral returns the antiderivative of p whose value at 0 is c
+func (p Polynomial) Integral(c float64) Polynomial {
+	res := make(Polynomial, len(p)+1)
+	res[0] = c
+	for i, v := range p {
+		res[i+1] = v / float64(i+1)
+	}
+	return res.trim()
+}
@@ -0,0 +1,116 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+//near returns true if a and b have the same length and differ by at most tol
+func near(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNew(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		coef []float64
+		exp  Polynomial
+		deg  int
+	}{
+		{nil, nil, -1},
+		{[]float64{0, 0}, nil, -1},
+		{[]float64{3}, Polynomial{3}, 0},
+		{[]float64{1, 2, 0, 0}, Polynomial{1, 2}, 1},
+		{[]float64{0, 0, 1}, Polynomial{0, 0, 1}, 2},
+	}
+	for ind, test := range tests {
+		p := New(test.coef...)
+		te.DeepEqual(ind, "coef", test.exp, p)
+		te.DeepEqual(ind, "degree", test.deg, p.Degree())
+	}
+}
+
+func TestEval(t *testing.T) {
+	te := tester.New(t)
+	p := New(1, -2, 0, 3)
+	tests := []struct {
+		x, exp float64
+	}{
+		{0, 1},
+		{1, 2},
+		{-1, 0},
+		{2, 21},
+	}
+	for ind, test := range tests {
+		te.DeepEqual(ind, "eval", test.exp, p.Eval(test.x))
+	}
+	te.DeepEqual(0, "zero", 0.0, Polynomial(nil).Eval(3))
+	m, err := p.EvalM64(mat.NewM64(2, 2, []float64{0, 1, -1, 2}))
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "m64", mat.NewM64(2, 2, []float64{1, 2, 0, 21}), m)
+	_, err = p.EvalM64(nil)
+	te.CompareError(1, fmt.Errorf("m is nil"), err)
+}
+
+func TestArithmetic(t *testing.T) {
+	te := tester.New(t)
+	p, q := New(1, 2, 3), New(-1, 0, -3)
+	te.DeepEqual(0, "add", Polynomial{0, 2}, p.Add(q))
+	te.DeepEqual(0, "add zero", p, p.Add(nil))
+	te.DeepEqual(0, "sub", Polynomial{2, 2, 6}, p.Sub(q))
+	te.DeepEqual(0, "sub self", Polynomial(nil), p.Sub(p))
+	te.DeepEqual(0, "scale", Polynomial{2, 4, 6}, p.Scale(2))
+	te.DeepEqual(0, "scale zero", Polynomial(nil), p.Scale(0))
+	te.DeepEqual(0, "mul", Polynomial{-1, -2, -6, -6, -9}, p.Mul(q))
+	te.DeepEqual(0, "mul zero", Polynomial(nil), p.Mul(nil))
+	//p(q(x)) with q = x+1
+	te.DeepEqual(0, "compose", Polynomial{6, 8, 3}, p.Compose(New(1, 1)))
+	te.DeepEqual(0, "receiver untouched", Polynomial{1, 2, 3}, p)
+}
+
+func TestDivMod(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		p, d, q, r Polynomial
+		err        error
+	}{
+		//(x²-1)/(x-1)
+		{New(-1, 0, 1), New(-1, 1), Polynomial{1, 1}, nil, nil},
+		//(x³+2x+5)/(x²+1) = x, remainder x+5
+		{New(5, 2, 0, 1), New(1, 0, 1), Polynomial{0, 1}, Polynomial{5, 1}, nil},
+		{New(1, 2), New(0, 0, 1), nil, Polynomial{1, 2}, nil},
+		{New(4, 2), New(2), Polynomial{2, 1}, nil, nil},
+		{New(1, 2), nil, nil, nil, fmt.Errorf("division by zero polynomial")},
+	}
+	for ind, test := range tests {
+		q, r, err := test.p.DivMod(test.d)
+		te.CompareError(ind, test.err, err)
+		te.DeepEqual(ind, "quotient", test.q, q)
+		te.DeepEqual(ind, "remainder", test.r, r)
+		if err == nil {
+			te.DeepEqual(ind, "p=q*d+r", test.p.trim(), q.Mul(test.d).Add(r))
+		}
+	}
+}
+
+func TestCalculus(t *testing.T) {
+	te := tester.New(t)
+	p := New(1, -2, 0, 4)
+	te.DeepEqual(0, "deriv", Polynomial{-2, 0, 12}, p.Deriv())
+	te.DeepEqual(0, "deriv constant", Polynomial(nil), New(3).Deriv())
+	te.DeepEqual(0, "integral", Polynomial{5, 1, -1, 0, 1}, p.Integral(5))
+	te.DeepEqual(0, "integral zero", Polynomial(nil), Polynomial(nil).Integral(0))
+	te.DeepEqual(0, "round trip", p, p.Integral(7).Deriv())
+}
@@ -0,0 +1,55 @@
+package poly
+
+import (
+	"fmt"
+	"sort"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Roots returns the complex roots of p with their multiplicity, sorted by real part then imaginary part. They are the eigenvalues of the companion matrix of p. Roots at 0 are exact. A constant polynomial has no roots
+func (p Polynomial) Roots() ([]complex128, error) {
+	p = p.trim()
+	if len(p) == 0 {
+		return nil, fmt.Errorf("polynomial is zero")
+	}
+	var roots []complex128
+	for len(p) > 1 && p[0] == 0 {
+		roots = append(roots, 0)
+		p = p[1:]
+	}
+	n := len(p) - 1
+	switch {
+	case n == 1:
+		roots = append(roots, complex(-p[0]/p[1], 0))
+	case n > 1:
+		comp := mat.NewM64(n, n, nil)
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				comp.Set(i, i-1, 1)
+			}
+			comp.Set(i, n-1, -p[i]/p[n])
+		}
+		vals, err := mat.Eigenvalues(comp)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, vals...)
+	}
+	sort.SliceStable(roots, func(i, j int) bool {
+		if real(roots[i]) != real(roots[j]) {
+			return real(roots[i]) < real(roots[j])
+		}
+		return imag(roots[i]) < imag(roots[j])
+	})
+	return roots, nil
+}
+
+//FromRoots returns the monic polynomial whose roots are the real values roots
+func FromRoots(roots ...float64) Polynomial {
+	res := Polynomial{1}
+	for _, r := range roots {
+		res = res.Mul(Polynomial{-r, 1})
+	}
+	return res
+}
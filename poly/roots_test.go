@@ -0,0 +1,56 @@
+package poly
+
+import (
+	"fmt"
+	"math/cmplx"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestRoots(t *testing.T) {
+	te := tester.New(t)
+	tests := []struct {
+		p   Polynomial
+		exp []complex128
+		err error
+	}{
+		{New(3), nil, nil},
+		{New(4, -2), []complex128{2}, nil},
+		{New(0, 0, 1), []complex128{0, 0}, nil},
+		{FromRoots(3, -1, 2), []complex128{-1, 2, 3}, nil},
+		//x²+1
+		{New(1, 0, 1), []complex128{-1i, 1i}, nil},
+		//x(x-1)(x²+2x+5)
+		{FromRoots(0, 1).Mul(New(5, 2, 1)), []complex128{-1 - 2i, -1 + 2i, 0, 1}, nil},
+		//double root
+		{FromRoots(1, 1, -2), []complex128{-2, 1, 1}, nil},
+		{nil, nil, fmt.Errorf("polynomial is zero")},
+	}
+	for ind, test := range tests {
+		roots, err := test.p.Roots()
+		te.CompareError(ind, test.err, err)
+		ok := len(roots) == len(test.exp)
+		for i := 0; ok && i < len(roots); i++ {
+			ok = cmplx.Abs(roots[i]-test.exp[i]) < 1e-7
+		}
+		if !ok {
+			te.DeepEqual(ind, "roots", test.exp, roots)
+		}
+	}
+	//roots of a higher degree polynomial cancel it
+	p := FromRoots(-3, -1.5, 0.25, 1, 2, 4.5, 7)
+	roots, err := p.Roots()
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "count", 7, len(roots))
+	for ind, r := range roots {
+		te.DeepEqual(ind, "real", true, cmplx.Abs(complex(real(r), 0)-r) < 1e-8)
+		te.DeepEqual(ind, "cancels", true, cmplx.Abs(complex(p.Eval(real(r)), 0)) < 1e-6)
+	}
+}
+
+func TestFromRoots(t *testing.T) {
+	te := tester.New(t)
+	te.DeepEqual(0, "none", Polynomial{1}, FromRoots())
+	te.DeepEqual(0, "two", Polynomial{-2, -1, 1}, FromRoots(2, -1))
+}
@@ -0,0 +1,105 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Series is a linear combination of the polynomials of a basis: Coef[0]*P_0(t)+...+Coef[n]*P_n(t), where t maps [Min,Max] linearly to [-1,1]
+type Series struct {
+	Basis Basis
+	Coef  []float64
+	Min   float64
+	Max   float64
+}
+
+//scaled returns x mapped from [s.Min,s.Max] to [-1,1]
+func (s *Series) scaled(x float64) float64 {
+	return (2*x - s.Min - s.Max) / (s.Max - s.Min)
+}
+
+//Eval returns the value of the series at x
+func (s *Series) Eval(x float64) float64 {
+	vals := s.Basis.Values(len(s.Coef)-1, s.scaled(x))
+	res := 0.0
+	for i, c := range s.Coef {
+		res += c * vals[i]
+	}
+	return res
+}
+
+//Polynomial returns the series as a polynomial of x in the monomial basis. The conversion is ill-conditioned for high degrees
+func (s *Series) Polynomial() Polynomial {
+	var res Polynomial
+	for i, c := range s.Coef {
+		res = res.Add(s.Basis.Polynomial(i).Scale(c))
+	}
+	return res.Compose(Polynomial{-(s.Min + s.Max) / (s.Max - s.Min), 2 / (s.Max - s.Min)})
+}
+
+//Fit returns the series of degree deg in the basis b minimizing the squared error over the samples (xs[i],ys[i]). The domain is [min(xs),max(xs)]. The system is solved by QR decomposition
+func Fit(b Basis, xs, ys []float64, deg int) (*Series, error) {
+	if b.String() == "unknown" {
+		return nil, fmt.Errorf("unknown basis")
+	}
+	if len(xs) != len(ys) {
+		return nil, fmt.Errorf("xs,ys lengths not equal")
+	}
+	if deg < 0 {
+		return nil, fmt.Errorf("deg must not be negative")
+	}
+	if len(xs) < deg+1 {
+		return nil, fmt.Errorf("at least %d samples are needed", deg+1)
+	}
+	s := &Series{Basis: b, Min: math.Inf(1), Max: math.Inf(-1)}
+	for _, x := range xs {
+		s.Min, s.Max = math.Min(s.Min, x), math.Max(s.Max, x)
+	}
+	if !(s.Max > s.Min) {
+		return nil, fmt.Errorf("xs must not all be equal")
+	}
+	a := mat.NewM64(len(xs), deg+1, nil)
+	for i, x := range xs {
+		for j, v := range b.Values(deg, s.scaled(x)) {
+			a.Set(i, j, v)
+		}
+	}
+	y, err := mat.FromData(len(ys), 1, append([]float64(nil), ys...))
+	if err != nil {
+		return nil, err
+	}
+	sol, err := mat.LeastSquares(a, y)
+	if err != nil {
+		return nil, err
+	}
+	s.Coef = make([]float64, deg+1)
+	for j := range s.Coef {
+		s.Coef[j] = sol.At(j, 0)
+	}
+	return s, nil
+}
+
+//Approximate returns the Chebyshev series of degree n interpolating f on [a,b] at the n+1 Chebyshev nodes, which is close to the best uniform approximation of a smooth f
+func Approximate(f func(float64) float64, a, b float64, n int) (*Series, error) {
+	if f == nil {
+		return nil, fmt.Errorf("f is nil")
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("n must not be negative")
+	}
+	if !(b > a) {
+		return nil, fmt.Errorf("b must be greater than a")
+	}
+	s := &Series{Basis: Chebyshev, Coef: make([]float64, n+1), Min: a, Max: b}
+	for j := 0; j <= n; j++ {
+		t := math.Cos(math.Pi * (float64(j) + 0.5) / float64(n+1))
+		fx := f((a+b)/2 + t*(b-a)/2)
+		for k, v := range Chebyshev.Values(n, t) {
+			s.Coef[k] += 2 * fx * v / float64(n+1)
+		}
+	}
+	s.Coef[0] /= 2
+	return s, nil
+}
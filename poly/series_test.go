@@ -0,0 +1,72 @@
+package poly
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/twiggg/tester"
+)
+
+func TestFit(t *testing.T) {
+	te := tester.New(t)
+	xs := make([]float64, 30)
+	ys := make([]float64, len(xs))
+	cubic := New(2, -1, 0.5, 0.25)
+	for i := range xs {
+		xs[i] = -1 + 5*float64(i)/float64(len(xs)-1)
+		ys[i] = cubic.Eval(xs[i])
+	}
+	//every basis represents the cubic exactly
+	for ind, b := range []Basis{Monomial, Chebyshev, Legendre, Hermite} {
+		s, err := Fit(b, xs, ys, 3)
+		te.CompareError(ind, nil, err)
+		te.DeepEqual(ind, "domain", []float64{-1, 4}, []float64{s.Min, s.Max})
+		if p := s.Polynomial(); !near(cubic, p, 1e-9) {
+			te.DeepEqual(ind, b.String(), cubic, p)
+		}
+		te.DeepEqual(ind, "eval", true, math.Abs(s.Eval(2.5)-cubic.Eval(2.5)) < 1e-10)
+	}
+	tests := []struct {
+		b      Basis
+		xs, ys []float64
+		deg    int
+		err    error
+	}{
+		{Basis(9), xs, ys, 1, fmt.Errorf("unknown basis")},
+		{Legendre, xs, ys[1:], 1, fmt.Errorf("xs,ys lengths not equal")},
+		{Legendre, xs, ys, -1, fmt.Errorf("deg must not be negative")},
+		{Legendre, xs[:2], ys[:2], 2, fmt.Errorf("at least 3 samples are needed")},
+		{Legendre, []float64{1, 1}, []float64{0, 1}, 1, fmt.Errorf("xs must not all be equal")},
+	}
+	for ind, test := range tests {
+		_, err := Fit(test.b, test.xs, test.ys, test.deg)
+		te.CompareError(ind, test.err, err)
+	}
+}
+
+func TestApproximate(t *testing.T) {
+	te := tester.New(t)
+	s, err := Approximate(math.Exp, -1, 2, 12)
+	te.CompareError(0, nil, err)
+	worst := 0.0
+	for x := -1.0; x <= 2; x += 0.01 {
+		worst = math.Max(worst, math.Abs(s.Eval(x)-math.Exp(x)))
+	}
+	te.DeepEqual(0, "exp", true, worst < 1e-10)
+	//coefficients of a smooth function decay fast
+	te.DeepEqual(0, "decay", true, math.Abs(s.Coef[12]) < 1e-9)
+
+	//a polynomial of degree <= n is reproduced
+	p := New(1, 0, -3, 2)
+	s, err = Approximate(p.Eval, 0, 1, 3)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "polynomial", true, near(p, s.Polynomial(), 1e-12))
+
+	_, err = Approximate(nil, 0, 1, 3)
+	te.CompareError(2, fmt.Errorf("f is nil"), err)
+	_, err = Approximate(math.Exp, 0, 1, -1)
+	te.CompareError(3, fmt.Errorf("n must not be negative"), err)
+	_, err = Approximate(math.Exp, 1, 1, 3)
+	te.CompareError(4, fmt.Errorf("b must be greater than a"), err)
+}
@@ -0,0 +1,161 @@
+package stat
+
+import (
+	"fmt"
+	"math"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//PCA projects datasets on the K principal axes of the dataset it was fitted on
+type PCA struct {
+	K      int  //number of components kept, all if <= 0 or greater than the number of colomns
+	Whiten bool //scale the projections to unit variance
+
+	mean       []float64
+	components *mat.M64 //c*k, one principal axis per colomn
+	variance   []float64
+	total      float64
+}
+
+//NewPCA returns a new unfitted PCA keeping k components
+func NewPCA(k int, whiten bool) *PCA {
+	return &PCA{K: k, Whiten: whiten}
+}
+
+//Fit learns the mean and the principal axes of x, from the eigen decomposition of its covariance matrix
+func (p *PCA) Fit(x *mat.M64) error {
+	cov, err := Covariance(x)
+	if err != nil {
+		return err
+	}
+	mean, _ := Mean(x)
+	vals, vecs, err := mat.EigenSym(cov)
+	if err != nil {
+		return err
+	}
+	c := len(vals)
+	k := p.K
+	if k <= 0 || k > c {
+		k = c
+	}
+	p.mean = make([]float64, c)
+	for j := range p.mean {
+		p.mean[j] = mean.At(0, j)
+	}
+	p.total = 0
+	for _, v := range vals {
+		p.total += math.Max(v, 0)
+	}
+	p.variance = make([]float64, k)
+	p.components = mat.NewM64(c, k, nil)
+	for l := 0; l < k; l++ {
+		//eigen values are ascending
+		src := c - 1 - l
+		p.variance[l] = math.Max(vals[src], 0)
+		//the sign of an axis is arbitrary, make its largest entry positive so fits are reproducible
+		sign, big := 1.0, 0.0
+		for i := 0; i < c; i++ {
+			if v := vecs.At(i, src); math.Abs(v) > big {
+				big = math.Abs(v)
+				sign = math.Copysign(1, v)
+			}
+		}
+		for i := 0; i < c; i++ {
+			p.components.Set(i, l, sign*vecs.At(i, src))
+		}
+	}
+	return nil
+}
+
+func (p *PCA) check(x *mat.M64, c int) error {
+	if err := checkData(x); err != nil {
+		return err
+	}
+	if p.components == nil {
+		return fmt.Errorf("pca is not fitted")
+	}
+	if _, xc := x.Dims(); xc != c {
+		return fmt.Errorf("x has %d colomns, expected %d", xc, c)
+	}
+	return nil
+}
+
+//scale returns the factor applied to projection l when whitening
+func (p *PCA) scale(l int) float64 {
+	if !p.Whiten || p.variance[l] == 0 {
+		return 1
+	}
+	return math.Sqrt(p.variance[l])
+}
+
+//Transform returns a new r*K matrix with the projections of the centered samples of x on the principal axes
+func (p *PCA) Transform(x *mat.M64) (*mat.M64, error) {
+	if err := p.check(x, len(p.mean)); err != nil {
+		return nil, err
+	}
+	r, c := x.Dims()
+	centered := mat.NewM64(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			centered.Set(i, j, x.At(i, j)-p.mean[j])
+		}
+	}
+	res, err := mat.Mul(centered, p.components)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < r; i++ {
+		for l := range p.variance {
+			res.Set(i, l, res.At(i, l)/p.scale(l))
+		}
+	}
+	return res, nil
+}
+
+//InverseTransform returns a new matrix with the samples rebuilt from their projections y. It is exact only when all components are kept
+func (p *PCA) InverseTransform(y *mat.M64) (*mat.M64, error) {
+	if err := p.check(y, len(p.variance)); err != nil {
+		return nil, err
+	}
+	r, k := y.Dims()
+	scaled := mat.NewM64(r, k, nil)
+	for i := 0; i < r; i++ {
+		for l := 0; l < k; l++ {
+			scaled.Set(i, l, y.At(i, l)*p.scale(l))
+		}
+	}
+	wt, _ := mat.Transpose(p.components)
+	res, err := mat.Mul(scaled, wt)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < r; i++ {
+		for j, m := range p.mean {
+			res.Set(i, j, res.At(i, j)+m)
+		}
+	}
+	return res, nil
+}
+
+//Components returns the principal axes as the colomns of a c*K matrix, by decreasing variance. nil if p is not fitted
+func (p *PCA) Components() *mat.M64 {
+	return p.components
+}
+
+//ExplainedVariance returns the variance of the dataset along each principal axis
+func (p *PCA) ExplainedVariance() []float64 {
+	return p.variance
+}
+
+//ExplainedVarianceRatio returns the share of the total variance of the dataset along each principal axis
+func (p *PCA) ExplainedVarianceRatio() []float64 {
+	res := make([]float64, len(p.variance))
+	if p.total == 0 {
+		return res
+	}
+	for l, v := range p.variance {
+		res[l] = v / p.total
+	}
+	return res
+}
@@ -0,0 +1,60 @@
+package stat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestPCA(t *testing.T) {
+	te := tester.New(t)
+	//points on the line y=x with a small orthogonal deviation
+	x := mat.NewM64(4, 2, []float64{
+		-2, -2,
+		-1, -1,
+		1, 1,
+		2, 2,
+	})
+	x.Set(1, 0, -1.1)
+	x.Set(2, 0, 1.1)
+	p := NewPCA(1, false)
+	_, err := p.Transform(x)
+	te.CompareError(0, fmt.Errorf("pca is not fitted"), err)
+	te.CompareError(0, nil, p.Fit(x))
+	s := 1 / math.Sqrt2
+	axis := p.Components()
+	te.DeepEqual(0, "axis", true, math.Abs(axis.At(0, 0)-s) < 1e-2 && math.Abs(axis.At(1, 0)-s) < 1e-2)
+	ratio := p.ExplainedVarianceRatio()
+	te.DeepEqual(0, "ratio", true, len(ratio) == 1 && ratio[0] > 0.99)
+	y, err := p.Transform(x)
+	te.CompareError(0, nil, err)
+	r, c := y.Dims()
+	te.DeepEqual(0, "dims", []int{4, 1}, []int{r, c})
+
+	//all components: exact round trip, whitened projections are uncorrelated with unit variance
+	x = mat.NewNormal(50, 3, rand.NewSource(2), 0, 1)
+	x2, _ := mat.Mul(x, mat.NewM64(3, 3, []float64{3, 1, 0, 0, 1, 0, 1, 0, 0.2}))
+	p = NewPCA(0, true)
+	te.CompareError(1, nil, p.Fit(x2))
+	sum := 0.0
+	ev := p.ExplainedVariance()
+	for i, r := range p.ExplainedVarianceRatio() {
+		sum += r
+		if i > 0 {
+			te.DeepEqual(1, "decreasing", true, ev[i-1] >= ev[i])
+		}
+	}
+	te.DeepEqual(1, "ratio sum", true, math.Abs(sum-1) < 1e-12)
+	y, _ = p.Transform(x2)
+	cov, _ := Covariance(y)
+	te.DeepEqual(1, "whitened", true, mat.EqualApprox(mat.Identity(3), cov, 1e-10))
+	back, err := p.InverseTransform(y)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "round trip", true, mat.EqualApprox(x2, back, 1e-12))
+	_, err = p.InverseTransform(mat.NewM64(2, 2, nil))
+	te.CompareError(2, fmt.Errorf("x has 2 colomns, expected 3"), err)
+}
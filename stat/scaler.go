@@ -0,0 +1,144 @@
+package stat
+
+import (
+	"fmt"
+	"math"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+//Scaler learns a per colomn transformation from a dataset and applies it to other datasets with the same colomns
+type Scaler interface {
+	Fit(x *mat.M64) error
+	Transform(x *mat.M64) (*mat.M64, error)
+	InverseTransform(x *mat.M64) (*mat.M64, error)
+}
+
+//affine holds a per colomn transformation (x-shift)/scale
+type affine struct {
+	shift []float64
+	scale []float64
+}
+
+func (a *affine) check(x *mat.M64) error {
+	if err := checkData(x); err != nil {
+		return err
+	}
+	if a.shift == nil {
+		return fmt.Errorf("scaler is not fitted")
+	}
+	if _, c := x.Dims(); c != len(a.shift) {
+		return fmt.Errorf("x has %d colomns, scaler was fitted on %d", c, len(a.shift))
+	}
+	return nil
+}
+
+func (a *affine) apply(x *mat.M64, fn func(v float64, j int) float64) (*mat.M64, error) {
+	if err := a.check(x); err != nil {
+		return nil, err
+	}
+	r, c := x.Dims()
+	res := mat.NewM64(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			res.Set(i, j, fn(x.At(i, j), j))
+		}
+	}
+	return res, nil
+}
+
+//Transform returns a new matrix with each colomn shifted and scaled
+func (a *affine) Transform(x *mat.M64) (*mat.M64, error) {
+	return a.apply(x, func(v float64, j int) float64 { return (v - a.shift[j]) / a.scale[j] })
+}
+
+//InverseTransform returns a new matrix with the transformation undone
+func (a *affine) InverseTransform(x *mat.M64) (*mat.M64, error) {
+	return a.apply(x, func(v float64, j int) float64 { return v*a.scale[j] + a.shift[j] })
+}
+
+//ZScore standardizes each colomn to zero mean and unit variance
+type ZScore struct {
+	affine
+}
+
+//NewZScore returns a new unfitted z-score scaler
+func NewZScore() *ZScore {
+	return &ZScore{}
+}
+
+//Fit learns the mean and standard deviation of each colomn of x. Constant colomns are only centered
+func (z *ZScore) Fit(x *mat.M64) error {
+	mean, err := Mean(x)
+	if err != nil {
+		return err
+	}
+	v, err := Variance(x)
+	if err != nil {
+		return err
+	}
+	_, c := x.Dims()
+	z.shift, z.scale = make([]float64, c), make([]float64, c)
+	for j := 0; j < c; j++ {
+		z.shift[j] = mean.At(0, j)
+		z.scale[j] = 1
+		if s := v.At(0, j); s > 0 {
+			z.scale[j] = math.Sqrt(s)
+		}
+	}
+	return nil
+}
+
+//Mean returns the learnt mean of each colomn, nil if z is not fitted
+func (z *ZScore) Mean() []float64 {
+	return z.shift
+}
+
+//Std returns the learnt standard deviation of each colomn, nil if z is not fitted
+func (z *ZScore) Std() []float64 {
+	return z.scale
+}
+
+//MinMax scales each colomn linearly to the range [Min,Max]
+type MinMax struct {
+	affine
+	Min float64
+	Max float64
+}
+
+//NewMinMax returns a new unfitted scaler to the range [min,max]
+func NewMinMax(min, max float64) *MinMax {
+	return &MinMax{Min: min, Max: max}
+}
+
+//Fit learns the range of each colomn of x. Constant colomns are mapped to Min
+func (m *MinMax) Fit(x *mat.M64) error {
+	if err := checkData(x); err != nil {
+		return err
+	}
+	if m.Max <= m.Min {
+		return fmt.Errorf("max must be greater than min")
+	}
+	r, c := x.Dims()
+	m.shift, m.scale = make([]float64, c), make([]float64, c)
+	for j := 0; j < c; j++ {
+		lo, hi := x.At(0, j), x.At(0, j)
+		for i := 1; i < r; i++ {
+			v := x.At(i, j)
+			if v < lo {
+				lo = v
+			}
+			if v > hi {
+				hi = v
+			}
+		}
+		//(v-lo)/(hi-lo)*(Max-Min)+Min written as (v-shift)/scale
+		scale := 1.0
+		if hi > lo {
+			scale = (hi - lo) / (m.Max - m.Min)
+		}
+		m.scale[j] = scale
+		m.shift[j] = lo - m.Min*scale
+	}
+	return nil
+}
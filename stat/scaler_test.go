@@ -0,0 +1,48 @@
+package stat
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestScalers(t *testing.T) {
+	te := tester.New(t)
+	x := mat.NewM64(3, 2, []float64{
+		1, 7,
+		3, 7,
+		5, 7,
+	})
+	z := NewZScore()
+	_, err := z.Transform(x)
+	te.CompareError(0, fmt.Errorf("scaler is not fitted"), err)
+	te.CompareError(0, nil, z.Fit(x))
+	te.DeepEqual(0, "mean", []float64{3, 7}, z.Mean())
+	te.DeepEqual(0, "std", []float64{2, 1}, z.Std())
+	y, err := z.Transform(x)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "zscore", mat.NewM64(3, 2, []float64{-1, 0, 0, 0, 1, 0}), y)
+
+	m := NewMinMax(-1, 1)
+	te.CompareError(1, nil, m.Fit(x))
+	y, err = m.Transform(x)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "minmax", mat.NewM64(3, 2, []float64{-1, -1, 0, -1, 1, -1}), y)
+	_, err = m.Transform(mat.NewM64(3, 3, nil))
+	te.CompareError(1, fmt.Errorf("x has 3 colomns, scaler was fitted on 2"), err)
+
+	te.CompareError(2, fmt.Errorf("max must be greater than min"), NewMinMax(1, 1).Fit(x))
+
+	//round trips on random data
+	x = mat.NewNormal(20, 4, rand.NewSource(1), 3, 2)
+	for i, s := range []Scaler{NewZScore(), NewMinMax(0, 1)} {
+		te.CompareError(3+i, nil, s.Fit(x))
+		y, _ := s.Transform(x)
+		back, err := s.InverseTransform(y)
+		te.CompareError(3+i, nil, err)
+		te.DeepEqual(3+i, "round trip", true, mat.EqualApprox(x, back, 1e-14))
+	}
+}
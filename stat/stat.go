@@ -0,0 +1,104 @@
+//Package stat provides descriptive statistics and preprocessing on datasets stored as M64, one sample per row and one feature per colomn
+package stat
+
+import (
+	"fmt"
+	"math"
+
+	mat "github.com/twiggg/math/mat64"
+)
+
+func checkData(x *mat.M64) error {
+	if !x.Valid() {
+		return fmt.Errorf("x is nil")
+	}
+	return nil
+}
+
+//Mean returns a new 1*c matrix with the mean of each colomn of x
+func Mean(x *mat.M64) (*mat.M64, error) {
+	if err := checkData(x); err != nil {
+		return nil, err
+	}
+	r, c := x.Dims()
+	res := mat.NewM64(1, c, nil)
+	for j := 0; j < c; j++ {
+		sum := 0.0
+		for i := 0; i < r; i++ {
+			sum += x.At(i, j)
+		}
+		res.Set(0, j, sum/float64(r))
+	}
+	return res, nil
+}
+
+//Variance returns a new 1*c matrix with the unbiased variance of each colomn of x. x must have at least 2 rows
+func Variance(x *mat.M64) (*mat.M64, error) {
+	mean, err := Mean(x)
+	if err != nil {
+		return nil, err
+	}
+	r, c := x.Dims()
+	if r < 2 {
+		return nil, fmt.Errorf("x needs at least 2 rows")
+	}
+	res := mat.NewM64(1, c, nil)
+	for j := 0; j < c; j++ {
+		sum := 0.0
+		for i := 0; i < r; i++ {
+			d := x.At(i, j) - mean.At(0, j)
+			sum += d * d
+		}
+		res.Set(0, j, sum/float64(r-1))
+	}
+	return res, nil
+}
+
+//Covariance returns a new c*c matrix with the unbiased covariance of each pair of colomns of x. x must have at least 2 rows
+func Covariance(x *mat.M64) (*mat.M64, error) {
+	mean, err := Mean(x)
+	if err != nil {
+		return nil, err
+	}
+	r, c := x.Dims()
+	if r < 2 {
+		return nil, fmt.Errorf("x needs at least 2 rows")
+	}
+	centered := mat.NewM64(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			centered.Set(i, j, x.At(i, j)-mean.At(0, j))
+		}
+	}
+	res := mat.NewM64(c, c, nil)
+	if err := mat.MulTransTo(res, centered, centered); err != nil {
+		return nil, err
+	}
+	return res, res.MapElem(func(v float64) float64 { return v / float64(r-1) })
+}
+
+//Correlation returns a new c*c matrix with the Pearson correlation of each pair of colomns of x. Constant colomns have a correlation of 0 with the others and 1 with themselves
+func Correlation(x *mat.M64) (*mat.M64, error) {
+	cov, err := Covariance(x)
+	if err != nil {
+		return nil, err
+	}
+	c, _ := cov.Dims()
+	std := make([]float64, c)
+	for j := range std {
+		std[j] = math.Sqrt(cov.At(j, j))
+	}
+	for i := 0; i < c; i++ {
+		for j := 0; j < c; j++ {
+			switch {
+			case i == j:
+				cov.Set(i, j, 1)
+			case std[i] == 0 || std[j] == 0:
+				cov.Set(i, j, 0)
+			default:
+				cov.Set(i, j, cov.At(i, j)/(std[i]*std[j]))
+			}
+		}
+	}
+	return cov, nil
+}
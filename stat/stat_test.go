@@ -0,0 +1,48 @@
+package stat
+
+import (
+	"fmt"
+	"testing"
+
+	mat "github.com/twiggg/math/mat64"
+	"github.com/twiggg/tester"
+)
+
+func TestMoments(t *testing.T) {
+	te := tester.New(t)
+	x := mat.NewM64(4, 3, []float64{
+		1, 2, 5,
+		2, 4, 5,
+		3, 6, 5,
+		4, 8, 5,
+	})
+	mean, err := Mean(x)
+	te.CompareError(0, nil, err)
+	te.DeepEqual(0, "mean", mat.NewM64(1, 3, []float64{2.5, 5, 5}), mean)
+
+	v, err := Variance(x)
+	te.CompareError(1, nil, err)
+	te.DeepEqual(1, "variance", true, mat.EqualApprox(mat.NewM64(1, 3, []float64{5.0 / 3, 20.0 / 3, 0}), v, 1e-15))
+
+	cov, err := Covariance(x)
+	te.CompareError(2, nil, err)
+	expCov := mat.NewM64(3, 3, []float64{
+		5.0 / 3, 10.0 / 3, 0,
+		10.0 / 3, 20.0 / 3, 0,
+		0, 0, 0,
+	})
+	te.DeepEqual(2, "covariance", true, mat.EqualApprox(expCov, cov, 1e-15))
+
+	corr, err := Correlation(x)
+	te.CompareError(3, nil, err)
+	te.DeepEqual(3, "correlation", true, mat.EqualApprox(mat.NewM64(3, 3, []float64{1, 1, 0, 1, 1, 0, 0, 0, 1}), corr, 1e-15))
+
+	corr, err = Correlation(mat.NewM64(3, 2, []float64{1, 3, 2, 2, 3, 1}))
+	te.CompareError(4, nil, err)
+	te.DeepEqual(4, "anti correlation", true, mat.EqualApprox(mat.NewM64(2, 2, []float64{1, -1, -1, 1}), corr, 1e-15))
+
+	_, err = Mean(nil)
+	te.CompareError(5, fmt.Errorf("x is nil"), err)
+	_, err = Covariance(mat.NewM64(1, 3, nil))
+	te.CompareError(6, fmt.Errorf("x needs at least 2 rows"), err)
+}